@@ -0,0 +1,163 @@
+package lep
+
+// ArithmeticX is an arithmetic operation: +, -, *, / or % of two values, or
+// the unary minus of Right when Left is nil.
+type ArithmeticX struct {
+	Left     Value
+	Operator string
+	Right    Value
+}
+
+var _ Value = (*ArithmeticX)(nil)
+
+func Arithmetic(left Value, operator string, right Value) *ArithmeticX {
+	return &ArithmeticX{
+		Left:     left,
+		Operator: operator,
+		Right:    right,
+	}
+}
+
+func Negate(value Value) *ArithmeticX {
+	return &ArithmeticX{
+		Operator: "-",
+		Right:    value,
+	}
+}
+
+func (e ArithmeticX) Equals(other Expression) bool {
+	expr, ok := other.(*ArithmeticX)
+	if !ok || e.Operator != expr.Operator || !e.Right.Equals(expr.Right) {
+		return false
+	}
+	if e.Left == nil || expr.Left == nil {
+		return e.Left == nil && expr.Left == nil
+	}
+	return e.Left.Equals(expr.Left)
+}
+
+func (e ArithmeticX) String() string {
+	if e.Left == nil {
+		if _, ok := e.Right.(*ParamX); ok {
+			return "-" + e.Right.String()
+		}
+		// -(5) keeps 5 from being read as the literal -5
+		return "-(" + e.Right.String() + ")"
+	}
+	precedence := e.precedence()
+	left := e.Left.String()
+	if precedenceOf(e.Left) < precedence || absorbsOffset(e.Left, e.Operator, e.Right) {
+		left = "(" + left + ")"
+	}
+	// the operators are left-associative, so a - (b - c) needs brackets
+	right := e.Right.String()
+	if precedenceOf(e.Right) <= precedence {
+		right = "(" + right + ")"
+	}
+	return left + " " + e.Operator + " " + right
+}
+
+// Value returns the result of the operation when every operand is a
+// literal, or nil.
+func (e ArithmeticX) Value() interface{} {
+	result, _ := NewEvaluator().resolve(&e, nil)
+	return result
+}
+
+func (e ArithmeticX) precedence() int {
+	switch {
+	case e.Left == nil:
+		return 3
+	case e.Operator == "+" || e.Operator == "-":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// precedenceOf is additive for relative datetimes with offsets, which are
+// sums of their base and offsets.
+func precedenceOf(value Value) int {
+	switch e := value.(type) {
+	case *ArithmeticX:
+		return e.precedence()
+	case *RelativeDateTimeX:
+		if len(e.Offsets) > 0 {
+			return 1
+		}
+	}
+	return 4
+}
+
+// absorbsOffset reports whether a relative datetime followed by + or - and
+// a duration would be read back with the duration as one of its offsets,
+// as a datetime leading a sum is.
+func absorbsOffset(left Value, op string, right Value) bool {
+	_, relative := left.(*RelativeDateTimeX)
+	_, duration := right.(*DurationX)
+	return relative && duration && (op == "+" || op == "-")
+}
+
+// parseArithmetic folds the operands of a chain of operators with the same
+// precedence from the left, so a - b - c is (a - b) - c. A nil operand has
+// reported its error already and gives nil too.
+func parseArithmetic(first, rest interface{}) (Value, error) {
+	if first == nil {
+		return nil, nil
+	}
+	left, ok := first.(Value)
+	if !ok {
+		return nil, IncorrectType("parseArithmetic", (*Value)(nil), first)
+	}
+	operations, ok := rest.([]interface{})
+	if !ok {
+		return nil, IncorrectType("parseArithmetic", []interface{}{}, rest)
+	}
+	for _, item := range operations {
+		parts, ok := item.([]interface{})
+		if !ok || len(parts) != 4 {
+			return nil, IncorrectType("parseArithmetic", []interface{}{}, item)
+		}
+		op, ok := parts[1].([]byte)
+		if !ok {
+			return nil, IncorrectType("parseArithmetic", []byte{}, parts[1])
+		}
+		if parts[3] == nil {
+			return nil, nil
+		}
+		right, ok := parts[3].(Value)
+		if !ok {
+			return nil, IncorrectType("parseArithmetic", (*Value)(nil), parts[3])
+		}
+		for _, operand := range []Value{left, right} {
+			if err := checkOperand(string(op), operand); err != nil {
+				return nil, err
+			}
+		}
+		left = Arithmetic(left, string(op), right)
+	}
+	return left, nil
+}
+
+func parseNegation(value interface{}) (Value, error) {
+	if value == nil {
+		return nil, nil
+	}
+	operand, ok := value.(Value)
+	if !ok {
+		return nil, IncorrectType("parseNegation", (*Value)(nil), value)
+	}
+	if err := checkOperand("-", operand); err != nil {
+		return nil, err
+	}
+	return Negate(operand), nil
+}
+
+// checkOperand rejects literals no arithmetic applies to.
+func checkOperand(op string, operand Value) error {
+	switch operand.(type) {
+	case *NullX, *BooleanX, *StringX:
+		return InvalidOperand(op, operand.String())
+	}
+	return nil
+}
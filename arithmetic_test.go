@@ -0,0 +1,225 @@
+package lep
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestParseArithmetic(t *testing.T) {
+	var (
+		a = Param("a")
+		b = Param("b")
+		c = Param("c")
+	)
+
+	type testParseArithmetic struct {
+		query string
+		expr  Expression
+		str   string
+	}
+	var tests = []testParseArithmetic{
+		{
+			query: `price * quantity > 1000`,
+			expr:  Compare(Arithmetic(Param("price"), "*", Param("quantity")), ">", Integer(1000)),
+			str:   `price * quantity>1000`,
+		},
+		{query: `a > b + 1`, expr: GreaterThan(a, Arithmetic(b, "+", Integer(1))), str: `a>b + 1`},
+		{query: `a-1 < b`, expr: GreaterThan(b, Arithmetic(a, "-", Integer(1))), str: `b>a - 1`},
+		{query: `a=-1`, expr: Equals(a, Integer(-1)), str: `a=-1`},
+		{query: `a = b - -1`, expr: Equals(a, Arithmetic(b, "-", Integer(-1))), str: `a=b - -1`},
+		{
+			query: `a + b * c = 1`,
+			expr:  Compare(Arithmetic(a, "+", Arithmetic(b, "*", c)), "=", Integer(1)),
+			str:   `a + b * c=1`,
+		},
+		{
+			query: `(a + b) * c = 1`,
+			expr:  Compare(Arithmetic(Arithmetic(a, "+", b), "*", c), "=", Integer(1)),
+			str:   `(a + b) * c=1`,
+		},
+		{
+			query: `a - b - c = 1`,
+			expr:  Compare(Arithmetic(Arithmetic(a, "-", b), "-", c), "=", Integer(1)),
+			str:   `a - b - c=1`,
+		},
+		{
+			query: `a - (b - c) = 1`,
+			expr:  Compare(Arithmetic(a, "-", Arithmetic(b, "-", c)), "=", Integer(1)),
+			str:   `a - (b - c)=1`,
+		},
+		{
+			query: `-a * b % 3 = 1`,
+			expr:  Compare(Arithmetic(Arithmetic(Negate(a), "*", b), "%", Integer(3)), "=", Integer(1)),
+			str:   `-a * b % 3=1`,
+		},
+		{query: `-(a + 1) = b`, expr: Equals(b, Negate(Arithmetic(a, "+", Integer(1)))), str: `b=-(a + 1)`},
+		{query: `-(5) = -(-a)`, expr: Compare(Negate(Integer(5)), "=", Negate(Negate(a))), str: `-(5)=-(-a)`},
+		{
+			query: `end - start < 1h && (a) > 2`,
+			expr:  And(Compare(Arithmetic(Param("end"), "-", Param("start")), "<", Duration(time.Hour)), GreaterThan(a, Integer(2))),
+			str:   `end - start<1h && a>2`,
+		},
+		{
+			query: `(a / 2 >= 0.8 || b=1)`,
+			expr:  Or(Compare(Arithmetic(a, "/", Integer(2)), ">=", Float(0.8)), Equals(b, Integer(1))),
+			str:   `a / 2>=0.8 || b=1`,
+		},
+		{
+			query: `created_at - 7d > now() - 1d`,
+			expr:  Compare(Arithmetic(Param("created_at"), "-", Duration(7*day)), ">", Now(Duration(-day))),
+			str:   `created_at - 1w>now() - 1d`,
+		},
+		{
+			query: `ts > now() - 1d * 2 + 1h`,
+			expr:  GreaterThan(Param("ts"), Arithmetic(Arithmetic(Now(), "-", Arithmetic(Duration(day), "*", Integer(2))), "+", Duration(time.Hour))),
+			str:   `ts>now() - 1d * 2 + 1h`,
+		},
+		{
+			query: `ts > today() + 1h - 1d * 2`,
+			expr:  GreaterThan(Param("ts"), Arithmetic(Today(Duration(time.Hour)), "-", Arithmetic(Duration(day), "*", Integer(2)))),
+			str:   `ts>today() + 1h - 1d * 2`,
+		},
+		{
+			query: `b = a * now() + 1h && c = a - now() + 1h && d = -now() + 1h`,
+			expr: And(
+				Equals(b, Arithmetic(Arithmetic(a, "*", Now()), "+", Duration(time.Hour))),
+				Equals(Param("c"), Arithmetic(Arithmetic(a, "-", Now()), "+", Duration(time.Hour))),
+				Equals(Param("d"), Arithmetic(Negate(Now()), "+", Duration(time.Hour))),
+			),
+			str: `b=a * now() + 1h && c=a - now() + 1h && d=-(now()) + 1h`,
+		},
+		{
+			query: `(now() - 1d) * 2 = a && (now()) - 1d = b && (now() - 1d) + 1h = c && d - (now() - 1d) = 1h`,
+			expr: And(
+				Equals(a, Arithmetic(Now(Duration(-day)), "*", Integer(2))),
+				Equals(b, Arithmetic(Now(), "-", Duration(day))),
+				Equals(Param("c"), Arithmetic(Now(Duration(-day)), "+", Duration(time.Hour))),
+				Compare(Arithmetic(Param("d"), "-", Now(Duration(-day))), "=", Duration(time.Hour)),
+			),
+			str: `a=(now() - 1d) * 2 && b=(now()) - 1d && c=(now() - 1d) + 1h && d - (now() - 1d)=1h`,
+		},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query)
+		if assert.NoError(t, err, tt.query) {
+			assert.True(t, tt.expr.Equals(expr), "%s: %s", tt.query, expr)
+			assert.Equal(t, tt.str, expr.String())
+
+			reparsed, err := ParseExpression(expr.String())
+			if assert.NoError(t, err, expr.String()) {
+				assert.True(t, expr.Equals(reparsed), expr.String())
+			}
+		}
+	}
+
+	for _, query := range []string{`a + = 1`, `a * "x" = 1`, `-null = a`, `a = true + 1`, `(a = 1`, `a + [1] = 1`} {
+		_, err := ParseExpression(query)
+		assert.Error(t, err, query)
+	}
+
+	_, err := ParseExpression(`a = b + "x"`)
+	assert.Contains(t, err.Error(), `invalid operand "x" of +`)
+}
+
+func TestArithmetic_Value(t *testing.T) {
+	assert.Equal(t, int64(7), Arithmetic(Integer(1), "+", Arithmetic(Integer(2), "*", Integer(3))).Value())
+	assert.Equal(t, 2.5, Arithmetic(Integer(5), "/", Integer(2)).Value())
+	assert.Nil(t, Arithmetic(Param("a"), "+", Integer(1)).Value())
+	assert.False(t, Negate(Param("a")).Equals(Arithmetic(Param("a"), "-", Param("a"))))
+}
+
+func TestCalculate(t *testing.T) {
+	dt := time.Date(2021, 5, 12, 15, 4, 5, 0, time.UTC)
+
+	type testCalculate struct {
+		op     string
+		left   interface{}
+		right  interface{}
+		result interface{}
+	}
+	var tests = []testCalculate{
+		{op: "+", left: int64(1), right: int64(2), result: int64(3)},
+		{op: "-", left: int64(1), right: int64(2), result: int64(-1)},
+		{op: "*", left: int64(-3), right: int64(2), result: int64(-6)},
+		{op: "/", left: int64(7), right: int64(2), result: 3.5},
+		{op: "%", left: int64(-7), right: int64(2), result: int64(-1)},
+		{op: "/", left: int64(1), right: int64(0), result: nil},
+		{op: "%", left: int64(1), right: int64(0), result: nil},
+		{op: "+", left: int64(math.MaxInt64), right: int64(1), result: float64(math.MaxInt64) + 1},
+		{op: "-", left: int64(math.MinInt64), right: int64(1), result: float64(math.MinInt64) - 1},
+		{op: "*", left: int64(math.MinInt64), right: int64(-1), result: -float64(math.MinInt64)},
+		{op: "+", left: int64(1), right: 0.5, result: 1.5},
+		{op: "%", left: 7.5, right: int64(2), result: 1.5},
+		{op: "/", left: 1.5, right: 0.0, result: nil},
+		{op: "/", left: big.NewRat(1, 10), right: int64(3), result: big.NewRat(1, 30)},
+		{op: "%", left: big.NewRat(7, 1), right: big.NewRat(-2, 1), result: big.NewRat(1, 1)},
+		{op: "*", left: big.NewRat(1, 2), right: 3.0, result: 1.5},
+		{op: "/", left: big.NewRat(1, 2), right: int64(0), result: nil},
+		{op: "+", left: time.Hour, right: time.Minute, result: time.Hour + time.Minute},
+		{op: "/", left: time.Hour, right: 30 * time.Minute, result: 2.0},
+		{op: "*", left: time.Hour, right: time.Hour, result: nil},
+		{op: "*", left: int64(3), right: time.Second, result: 3 * time.Second},
+		{op: "/", left: int64(3), right: time.Second, result: nil},
+		{op: "/", left: time.Hour, right: int64(4), result: 15 * time.Minute},
+		{op: "*", left: 1.5, right: time.Hour, result: 90 * time.Minute},
+		{op: "-", left: dt, right: dt.Add(-time.Hour), result: time.Hour},
+		{op: "+", left: dt, right: time.Hour, result: dt.Add(time.Hour)},
+		{op: "+", left: time.Hour, right: dt, result: dt.Add(time.Hour)},
+		{op: "+", left: dt, right: dt, result: nil},
+		{op: "+", left: "a", right: "b", result: nil},
+		{op: "+", left: nil, right: int64(1), result: nil},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.result, calculate(tt.op, tt.left, tt.right), "%v %s %v", tt.left, tt.op, tt.right)
+	}
+
+	assert.Equal(t, -float64(math.MinInt64), negate(int64(math.MinInt64)))
+	assert.Equal(t, -time.Second, negate(time.Second))
+	assert.Nil(t, negate("a"))
+}
+
+func TestArithmetic_Evaluate(t *testing.T) {
+	data := map[string]interface{}{
+		"price":    12.5,
+		"quantity": 100,
+		"start":    int64(1000),
+		"end":      int64(4000),
+		"score":    8,
+		"max":      10,
+		"zero":     0,
+		"from":     time.Date(2021, 5, 12, 10, 0, 0, 0, time.UTC),
+		"to":       time.Date(2021, 5, 12, 12, 30, 0, 0, time.UTC),
+	}
+
+	type testArithmeticEvaluate struct {
+		query  string
+		result bool
+	}
+	var tests = []testArithmeticEvaluate{
+		{query: `price * quantity > 1000`, result: true},
+		{query: `end - start < 3600 && end - start = 3000`, result: true},
+		{query: `score / max >= 0.8 && score % 3 = 2 && -score = -8`, result: true},
+		{query: `1000 < price * quantity - 250`, result: false},
+		{query: `to - from = 2h30m && from + 150m = to && to - 1h > from`, result: true},
+		{query: `score / zero > 0 || score / zero <= 0 || score % zero = 0`, result: false},
+		{query: `score / zero = null && missing + 1 = null`, result: true},
+		{query: `to > now() - 1d * 2 && from < now() - 1d * 2 + 1h`, result: true},
+		{query: `to > (now() - 1d) + 1h || to > (now()) + 1h`, result: false},
+	}
+
+	now := time.Date(2021, 5, 14, 11, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query)
+		if assert.NoError(t, err, tt.query) {
+			result, err := Evaluate(expr, data, EvalClock(func() time.Time { return now }))
+			if assert.NoError(t, err, tt.query) {
+				assert.Equal(t, tt.result, result, tt.query)
+			}
+		}
+	}
+}
@@ -0,0 +1,286 @@
+package lep
+
+// BetweenX is a range test: From <= Param <= To, written a between 1 and
+// 10. ExcludeFrom and ExcludeTo make the bounds exclusive, as in the
+// interval a in [1..10).
+type BetweenX struct {
+	Param       *ParamX
+	From        Value
+	To          Value
+	ExcludeFrom bool
+	ExcludeTo   bool
+}
+
+var _ Expression = (*BetweenX)(nil)
+
+func Between(param *ParamX, from, to Value) *BetweenX {
+	return &BetweenX{
+		Param: param,
+		From:  from,
+		To:    to,
+	}
+}
+
+func (e BetweenX) Equals(other Expression) bool {
+	if expr, ok := other.(*BetweenX); ok {
+		return e.Param.Equals(expr.Param) && e.From.Equals(expr.From) && e.To.Equals(expr.To) &&
+			e.ExcludeFrom == expr.ExcludeFrom && e.ExcludeTo == expr.ExcludeTo
+	}
+	return false
+}
+
+func (e BetweenX) String() string {
+	return rangeText(e.Param, "between", "in", e.From, e.To, e.ExcludeFrom, e.ExcludeTo)
+}
+
+// ToComparisons expands the range into comparisons of its bounds, for
+// backends without ranges.
+func (e BetweenX) ToComparisons() Expression {
+	var lower, upper Expression = GreaterThanEqual(e.Param, e.From), LessThanEqual(e.Param, e.To)
+	if e.ExcludeFrom {
+		lower = GreaterThan(e.Param, e.From)
+	}
+	if e.ExcludeTo {
+		upper = LessThan(e.Param, e.To)
+	}
+	return And(lower, upper)
+}
+
+// NotBetweenX is the negation of BetweenX, written a not_between 1 and 10
+// or a not_in [1..10).
+type NotBetweenX struct {
+	Param       *ParamX
+	From        Value
+	To          Value
+	ExcludeFrom bool
+	ExcludeTo   bool
+}
+
+var _ Expression = (*NotBetweenX)(nil)
+
+func NotBetween(param *ParamX, from, to Value) *NotBetweenX {
+	return &NotBetweenX{
+		Param: param,
+		From:  from,
+		To:    to,
+	}
+}
+
+func (e NotBetweenX) Equals(other Expression) bool {
+	if expr, ok := other.(*NotBetweenX); ok {
+		return e.Param.Equals(expr.Param) && e.From.Equals(expr.From) && e.To.Equals(expr.To) &&
+			e.ExcludeFrom == expr.ExcludeFrom && e.ExcludeTo == expr.ExcludeTo
+	}
+	return false
+}
+
+func (e NotBetweenX) String() string {
+	return rangeText(e.Param, "not_between", "not_in", e.From, e.To, e.ExcludeFrom, e.ExcludeTo)
+}
+
+// ToComparisons expands the range into comparisons of its bounds, for
+// backends without ranges.
+func (e NotBetweenX) ToComparisons() Expression {
+	var lower, upper Expression = LessThan(e.Param, e.From), GreaterThan(e.Param, e.To)
+	if e.ExcludeFrom {
+		lower = LessThanEqual(e.Param, e.From)
+	}
+	if e.ExcludeTo {
+		upper = GreaterThanEqual(e.Param, e.To)
+	}
+	return Or(lower, upper)
+}
+
+// rangeText writes inclusive ranges with between and the others as
+// intervals.
+func rangeText(left Value, between, in string, from, to Value, excludeFrom, excludeTo bool) string {
+	if !excludeFrom && !excludeTo {
+		return left.String() + " " + between + " " + from.String() + " and " + to.String()
+	}
+	lower, upper := "[", "]"
+	if excludeFrom {
+		lower = "("
+	}
+	if excludeTo {
+		upper = ")"
+	}
+	return left.String() + " " + in + " " + lower + from.String() + ".." + to.String() + upper
+}
+
+// placeholderRange is a range of a positional placeholder, like ? between 1
+// and 10, which parseBetween expands into two comparisons sharing the
+// placeholder. The comparisons are printed as this range again, since the
+// placeholder written twice would be two placeholders.
+type placeholderRange struct {
+	Placeholder *PlaceholderX
+	From        Value
+	To          Value
+	ExcludeFrom bool
+	ExcludeTo   bool
+	Negated     bool
+}
+
+func (r placeholderRange) Equals(other Expression) bool {
+	if expr, ok := other.(*placeholderRange); ok {
+		return r.Placeholder.Equals(expr.Placeholder) && r.From.Equals(expr.From) && r.To.Equals(expr.To) &&
+			r.ExcludeFrom == expr.ExcludeFrom && r.ExcludeTo == expr.ExcludeTo && r.Negated == expr.Negated
+	}
+	return false
+}
+
+func (r placeholderRange) String() string {
+	if r.Negated {
+		return rangeText(r.Placeholder, "not_between", "not_in", r.From, r.To, r.ExcludeFrom, r.ExcludeTo)
+	}
+	return rangeText(r.Placeholder, "between", "in", r.From, r.To, r.ExcludeFrom, r.ExcludeTo)
+}
+
+// joinRanges returns the clauses of an && group, or of an || group if
+// negated, with the comparisons of placeholder ranges joined again.
+func joinRanges(clauses []Expression, negated bool) []Expression {
+	var result []Expression
+	for i := 0; i < len(clauses); i++ {
+		if i+1 < len(clauses) {
+			if r, ok := rangeOf(clauses[i], clauses[i+1], negated); ok {
+				result = append(result, r)
+				i++
+				continue
+			}
+		}
+		result = append(result, clauses[i])
+	}
+	return result
+}
+
+func rangeOf(lower, upper Expression, negated bool) (*placeholderRange, bool) {
+	p, lowerOp, from, ok := rangeBound(lower)
+	if !ok || p.Name != "" {
+		return nil, false
+	}
+	q, upperOp, to, ok := rangeBound(upper)
+	if !ok || q != p {
+		return nil, false
+	}
+	if negated {
+		lowerOp, upperOp = negatedComparators[lowerOp], negatedComparators[upperOp]
+	}
+	if (lowerOp != ">=" && lowerOp != ">") || (upperOp != "<=" && upperOp != "<") {
+		return nil, false
+	}
+	return &placeholderRange{
+		Placeholder: p,
+		From:        from,
+		To:          to,
+		ExcludeFrom: lowerOp == ">",
+		ExcludeTo:   upperOp == "<",
+		Negated:     negated,
+	}, true
+}
+
+// rangeBound returns a comparison of a placeholder with a bound, turned
+// around if the bound is a param.
+func rangeBound(expr Expression) (*PlaceholderX, string, Value, bool) {
+	switch x := expr.(type) {
+	case *CompareX:
+		p, ok := x.Left.(*PlaceholderX)
+		return p, x.Operator, x.Right, ok
+	case Statement:
+		p, ok := x.GetValue().(*PlaceholderX)
+		op, flipped := flippedOperators[operatorOf(expr)]
+		return p, op, x.GetParam(), ok && flipped
+	}
+	return nil, "", nil, false
+}
+
+// bounds are the right side of between and of intervals.
+type bounds struct {
+	From        Value
+	To          Value
+	ExcludeFrom bool
+	ExcludeTo   bool
+}
+
+func newBetweenOperation(op, from, to interface{}) (interface{}, error) {
+	return newIntervalOperation(op, []byte("["), from, to, []byte("]"))
+}
+
+// newIntervalOperation makes between of in and not_between of not_in. It
+// returns no typed nil on errors, which would reach parseOperation.
+func newIntervalOperation(op, lower, from, to, upper interface{}) (interface{}, error) {
+	name, ok := op.([]byte)
+	if !ok {
+		return nil, IncorrectType("newIntervalOperation", []byte{}, op)
+	}
+	b := &bounds{}
+	if b.From, ok = from.(Value); !ok {
+		return nil, IncorrectType("newIntervalOperation", (*Value)(nil), from)
+	}
+	if b.To, ok = to.(Value); !ok {
+		return nil, IncorrectType("newIntervalOperation", (*Value)(nil), to)
+	}
+	if err := checkBounds(b.From, b.To); err != nil {
+		return nil, err
+	}
+	b.ExcludeFrom = string(lower.([]byte)) == "("
+	b.ExcludeTo = string(upper.([]byte)) == ")"
+
+	switch string(name) {
+	case "in":
+		name = []byte("between")
+	case "not_in":
+		name = []byte("not_between")
+	}
+	return newOperation(name, b)
+}
+
+// checkBounds rejects the literals which cannot bound a range.
+func checkBounds(from, to Value) error {
+	for _, bound := range []Value{from, to} {
+		switch bound.(type) {
+		case *NullX, *BooleanX:
+			return InvalidOperand("between", bound.String())
+		}
+	}
+	return nil
+}
+
+// parseBetween returns the range of a param, or the comparisons of the
+// bounds with any other operand, like 5 between min and max.
+func parseBetween(op string, left interface{}, b *bounds) (Expression, error) {
+	if param, ok := left.(*ParamX); ok {
+		if op == "not_between" {
+			return &NotBetweenX{Param: param, From: b.From, To: b.To, ExcludeFrom: b.ExcludeFrom, ExcludeTo: b.ExcludeTo}, nil
+		}
+		return &BetweenX{Param: param, From: b.From, To: b.To, ExcludeFrom: b.ExcludeFrom, ExcludeTo: b.ExcludeTo}, nil
+	}
+
+	lowerOp, upperOp := ">=", "<="
+	if b.ExcludeFrom {
+		lowerOp = ">"
+	}
+	if b.ExcludeTo {
+		upperOp = "<"
+	}
+	if op == "not_between" {
+		lowerOp, upperOp = negatedComparators[lowerOp], negatedComparators[upperOp]
+	}
+	lower, err := parseComparison(lowerOp, left, b.From)
+	if err != nil {
+		return nil, err
+	}
+	upper, err := parseComparison(upperOp, left, b.To)
+	if err != nil {
+		return nil, err
+	}
+	if op == "not_between" {
+		return Or(lower, upper), nil
+	}
+	return And(lower, upper), nil
+}
+
+var negatedComparators = map[string]string{
+	">=": "<",
+	">":  "<=",
+	"<=": ">",
+	"<":  ">=",
+}
@@ -0,0 +1,118 @@
+package lep
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseBetween(t *testing.T) {
+	var (
+		a  = Param("a")
+		ts = Param("ts")
+	)
+
+	type testParseBetween struct {
+		query string
+		expr  Expression
+		str   string
+	}
+	var tests = []testParseBetween{
+		{query: `a between 1 and 10`, expr: Between(a, Integer(1), Integer(10)), str: `a between 1 and 10`},
+		{query: `a not_between 1.5 and b+1`, expr: NotBetween(a, Float(1.5), Arithmetic(Param("b"), "+", Integer(1))), str: `a not_between 1.5 and b + 1`},
+		{query: `a in [1..10]`, expr: Between(a, Integer(1), Integer(10)), str: `a between 1 and 10`},
+		{query: `a in [1..10)`, expr: &BetweenX{Param: a, From: Integer(1), To: Integer(10), ExcludeTo: true}, str: `a in [1..10)`},
+		{query: `a not_in ( "a" .. "b" ]`, expr: &NotBetweenX{Param: a, From: String("a"), To: String("b"), ExcludeFrom: true}, str: `a not_in ("a".."b"]`},
+		{
+			query: `ts in [dt:"2024-01-01"..dt:"2024-02-01")`,
+			expr: &BetweenX{
+				Param:     ts,
+				From:      DateTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), "2006-01-02"),
+				To:        DateTime(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), "2006-01-02"),
+				ExcludeTo: true,
+			},
+			str: `ts in [dt:"2024-01-01"..dt:"2024-02-01")`,
+		},
+		{query: `a in (lo..hi)`, expr: &BetweenX{Param: a, From: Param("lo"), To: Param("hi"), ExcludeFrom: true, ExcludeTo: true}, str: `a in (lo..hi)`},
+		{
+			query: `5 between lo and hi`,
+			expr:  And(LessThanEqual(Param("lo"), Integer(5)), GreaterThanEqual(Param("hi"), Integer(5))),
+			str:   `lo<=5 && hi>=5`,
+		},
+		{
+			query: `5 not_in [lo..hi)`,
+			expr:  Or(GreaterThan(Param("lo"), Integer(5)), LessThanEqual(Param("hi"), Integer(5))),
+			str:   `lo>5 || hi<=5`,
+		},
+		{
+			query: `a between 1 and 2 && b in [1,2]`,
+			expr:  And(Between(a, Integer(1), Integer(2)), InSlice(Param("b"), Slice(Integer(1), Integer(2)))),
+			str:   `a between 1 and 2 && b in [1,2]`,
+		},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query)
+		if assert.NoError(t, err, tt.query) {
+			assert.True(t, tt.expr.Equals(expr), "%s: %s", tt.query, expr)
+			assert.Equal(t, tt.str, expr.String())
+
+			reparsed, err := ParseExpression(expr.String())
+			if assert.NoError(t, err, expr.String()) {
+				assert.True(t, expr.Equals(reparsed), expr.String())
+			}
+		}
+	}
+
+	for _, query := range []string{`a between 1`, `a between 1 and`, `a between null and 1`, `a in [1..true]`, `a in [1..2`, `a has [1..2]`, `a between 1 or 2`} {
+		_, err := ParseExpression(query)
+		assert.Error(t, err, query)
+	}
+}
+
+func TestBetween_ToComparisons(t *testing.T) {
+	var (
+		a    = Param("a")
+		from = Integer(1)
+		to   = Integer(10)
+	)
+	assert.Equal(t, And(GreaterThanEqual(a, from), LessThanEqual(a, to)), Between(a, from, to).ToComparisons())
+	assert.Equal(t, And(GreaterThan(a, from), LessThan(a, to)), (&BetweenX{Param: a, From: from, To: to, ExcludeFrom: true, ExcludeTo: true}).ToComparisons())
+	assert.Equal(t, Or(LessThan(a, from), GreaterThan(a, to)), NotBetween(a, from, to).ToComparisons())
+	assert.Equal(t, Or(LessThan(a, from), GreaterThanEqual(a, to)), (&NotBetweenX{Param: a, From: from, To: to, ExcludeTo: true}).ToComparisons())
+}
+
+func TestBetween_Evaluate(t *testing.T) {
+	data := map[string]interface{}{
+		"age":   30,
+		"score": 7.5,
+		"name":  "mike",
+		"ts":    time.Date(2024, 1, 31, 23, 59, 0, 0, time.UTC),
+		"lo":    10,
+	}
+
+	type testBetweenEvaluate struct {
+		query  string
+		result bool
+	}
+	var tests = []testBetweenEvaluate{
+		{query: `age between 18 and 65 && age between 30 and 30 && age in [30..31)`, result: true},
+		{query: `age in (30..40] || age in [20..30) || age between 31 and 40`, result: false},
+		{query: `score between 7 and 7.5 && score in (7.4..8) && score not_in [7.5..8]`, result: false},
+		{query: `name between "john" and "peter" && name not_between "a" and "b"`, result: true},
+		{query: `ts in [dt:"2024-01-01"..dt:"2024-02-01") && ts not_in [dt:"2024-02-01"..now())`, result: true},
+		{query: `age between lo and lo * 3 && 15 between lo and age`, result: true},
+		{query: `missing between 1 and 2 || age between "a" and "b"`, result: false},
+		{query: `missing not_between 1 and 2 && age not_in (30..40)`, result: true},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query)
+		if assert.NoError(t, err, tt.query) {
+			result, err := Evaluate(expr, data)
+			if assert.NoError(t, err, tt.query) {
+				assert.Equal(t, tt.result, result, tt.query)
+			}
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package lep
+
+import (
+	"github.com/araddon/dateparse"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// builtinFunctions return nil for arguments of other types than they
+// declare, like a missing param.
+var builtinFunctions = Functions{
+	// strings
+	"lower":  stringFunction(strings.ToLower),
+	"upper":  stringFunction(strings.ToUpper),
+	"trim":   stringFunction(strings.TrimSpace),
+	"concat": {Args: []Type{TypeString, TypeString}, Variadic: true, Returns: TypeString, Call: callConcat},
+
+	// numbers
+	"abs":   {Args: []Type{TypeFloat}, Returns: TypeFloat, Call: callAbs},
+	"round": roundFunction(math.Round),
+	"floor": roundFunction(math.Floor),
+	"ceil":  roundFunction(math.Ceil),
+	"min":   {Args: []Type{TypeFloat, TypeFloat}, Variadic: true, Returns: TypeFloat, Call: extremumFunction(-1)},
+	"max":   {Args: []Type{TypeFloat, TypeFloat}, Variadic: true, Returns: TypeFloat, Call: extremumFunction(1)},
+
+	// collections
+	"len": {Args: []Type{TypeAny}, Returns: TypeInteger, Call: callLen},
+	"sum": {Args: []Type{TypeArray}, Returns: TypeFloat, Call: callSum},
+
+	// dates
+	"year":    dateFunction(func(t time.Time) int { return t.Year() }),
+	"month":   dateFunction(func(t time.Time) int { return int(t.Month()) }),
+	"day":     dateFunction(func(t time.Time) int { return t.Day() }),
+	"hour":    dateFunction(func(t time.Time) int { return t.Hour() }),
+	"weekday": dateFunction(isoWeekday),
+}
+
+func stringFunction(f func(string) string) *Function {
+	return &Function{
+		Args:    []Type{TypeString},
+		Returns: TypeString,
+		Call: func(args []interface{}) (interface{}, error) {
+			if s, ok := args[0].(string); ok {
+				return f(s), nil
+			}
+			return nil, nil
+		},
+	}
+}
+
+func callConcat(args []interface{}) (interface{}, error) {
+	var b strings.Builder
+	for _, arg := range args {
+		s, ok := arg.(string)
+		if !ok {
+			return nil, nil
+		}
+		b.WriteString(s)
+	}
+	return b.String(), nil
+}
+
+func callAbs(args []interface{}) (interface{}, error) {
+	switch v := args[0].(type) {
+	case int64, float64, *big.Rat:
+		if c, _ := (&Evaluator{}).compareValues(v, int64(0)); c < 0 {
+			return negate(v), nil
+		}
+		return v, nil
+	}
+	return nil, nil
+}
+
+// roundFunction rounds floats to integers, which stay floats if they do not
+// fit into int64.
+func roundFunction(f func(float64) float64) *Function {
+	return &Function{
+		Args:    []Type{TypeFloat},
+		Returns: TypeInteger,
+		Call: func(args []interface{}) (interface{}, error) {
+			var val float64
+			switch v := args[0].(type) {
+			default:
+				return nil, nil
+			case int64:
+				return v, nil
+			case float64:
+				val = v
+			case *big.Rat:
+				val = ratFloat64(v)
+			}
+			val = f(val)
+			if val >= math.MinInt64 && val < math.MaxInt64 {
+				return int64(val), nil
+			}
+			return val, nil
+		},
+	}
+}
+
+// extremumFunction returns the smallest argument for sign -1 and the largest
+// for sign 1.
+func extremumFunction(sign int) func(args []interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		result := args[0]
+		for _, arg := range args[1:] {
+			c, ok := (&Evaluator{}).compareValues(arg, result)
+			if !ok {
+				return nil, nil
+			}
+			if c*sign > 0 {
+				result = arg
+			}
+		}
+		return result, nil
+	}
+}
+
+func callLen(args []interface{}) (interface{}, error) {
+	if s, ok := args[0].(string); ok {
+		return int64(utf8.RuneCountInString(s)), nil
+	}
+	if items := toSlice(args[0]); items != nil {
+		return int64(len(items)), nil
+	}
+	return nil, nil
+}
+
+func callSum(args []interface{}) (interface{}, error) {
+	items := toSlice(args[0])
+	if items == nil {
+		return nil, nil
+	}
+	var sum interface{} = int64(0)
+	for _, item := range items {
+		if sum = calculate("+", sum, normalizeValue(item)); sum == nil {
+			return nil, nil
+		}
+	}
+	return sum, nil
+}
+
+// dateFunction returns a part of a datetime; strings are parsed as
+// datetimes, as in comparisons.
+func dateFunction(f func(time.Time) int) *Function {
+	return &Function{
+		Args:    []Type{TypeDateTime},
+		Returns: TypeInteger,
+		Call: func(args []interface{}) (interface{}, error) {
+			switch v := args[0].(type) {
+			case time.Time:
+				return int64(f(v)), nil
+			case string:
+				if t, err := dateparse.ParseAny(v); err == nil {
+					return int64(f(t)), nil
+				}
+			}
+			return nil, nil
+		},
+	}
+}
+
+// isoWeekday numbers the days of the week from 1 for Monday to 7 for Sunday.
+func isoWeekday(t time.Time) int {
+	if t.Weekday() == time.Sunday {
+		return 7
+	}
+	return int(t.Weekday())
+}
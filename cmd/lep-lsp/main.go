@@ -0,0 +1,34 @@
+// Command lep-lsp is a Language Server Protocol server for the logic
+// expression language. It speaks JSON-RPC over stdin/stdout.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	lep "github.com/mgudov/logic-expression-parser"
+	"log"
+	"os"
+)
+
+func main() {
+	schemaPath := flag.String("schema", "", "path to a JSON file mapping param names to types")
+	flag.Parse()
+
+	log.SetFlags(0)
+	log.SetPrefix("lep-lsp: ")
+
+	var schema lep.Schema
+	if *schemaPath != "" {
+		data, err := os.ReadFile(*schemaPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := json.Unmarshal(data, &schema); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if err := NewServer(os.Stdin, os.Stdout, schema).Run(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,431 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	lep "github.com/mgudov/logic-expression-parser"
+	"io"
+	"sort"
+	"strings"
+)
+
+type Server struct {
+	conn     *conn
+	schema   lep.Schema
+	docs     map[string]string
+	shutdown bool
+}
+
+func NewServer(r io.Reader, w io.Writer, schema lep.Schema) *Server {
+	return &Server{
+		conn:   newConn(r, w),
+		schema: schema,
+		docs:   make(map[string]string),
+	}
+}
+
+// Run serves requests until the client sends the exit notification or
+// closes the input stream.
+func (s *Server) Run() error {
+	for {
+		msg, err := s.conn.read()
+		if err == io.EOF {
+			return nil
+		}
+		if respErr, ok := err.(*responseError); ok {
+			if err := s.conn.reply(nil, nil, respErr); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if msg.Method == "exit" {
+			if !s.shutdown {
+				return fmt.Errorf("exit without shutdown")
+			}
+			return nil
+		}
+
+		result, err := s.handle(msg)
+		if msg.isRequest() {
+			if err := s.conn.reply(msg.ID, result, err); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *Server) handle(msg *message) (interface{}, error) {
+	if s.shutdown && msg.Method != "exit" {
+		return nil, &responseError{Code: codeInvalidRequest, Message: "server is shutting down"}
+	}
+
+	switch msg.Method {
+	default:
+		if msg.isRequest() {
+			return nil, &responseError{Code: codeMethodNotFound, Message: "method not found: " + msg.Method}
+		}
+		return nil, nil
+	case "initialize":
+		var params InitializeParams
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return s.initialize(params), nil
+	case "initialized":
+		return nil, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		s.docs[params.TextDocument.URI] = params.TextDocument.Text
+		return nil, s.publishDiagnostics(params.TextDocument.URI)
+	case "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		if n := len(params.ContentChanges); n > 0 {
+			s.docs[params.TextDocument.URI] = params.ContentChanges[n-1].Text
+		}
+		return nil, s.publishDiagnostics(params.TextDocument.URI)
+	case "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		delete(s.docs, params.TextDocument.URI)
+		return nil, s.conn.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+			URI:         params.TextDocument.URI,
+			Diagnostics: []Diagnostic{},
+		})
+	case "textDocument/hover":
+		var params TextDocumentPositionParams
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return s.hover(params), nil
+	case "textDocument/completion":
+		var params TextDocumentPositionParams
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return s.completion(params), nil
+	case "textDocument/formatting":
+		var params DocumentFormattingParams
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return s.formatting(params), nil
+	}
+}
+
+func unmarshalParams(msg *message, v interface{}) error {
+	if len(msg.Params) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(msg.Params, v); err != nil {
+		return &responseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+func (s *Server) initialize(params InitializeParams) InitializeResult {
+	if opts := params.InitializationOptions; opts != nil && opts.Schema != nil {
+		s.schema = opts.Schema
+	}
+	return InitializeResult{
+		Capabilities: ServerCapabilities{
+			TextDocumentSync: syncFull,
+			HoverProvider:    true,
+			CompletionProvider: &CompletionOptions{
+				TriggerCharacters: []string{" ", "(", "&", "|"},
+			},
+			DocumentFormattingProvider: true,
+		},
+		ServerInfo: ServerInfo{Name: "lep-lsp"},
+	}
+}
+
+func (s *Server) publishDiagnostics(uri string) error {
+	return s.conn.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+		URI:         uri,
+		Diagnostics: s.diagnostics(s.docs[uri]),
+	})
+}
+
+func (s *Server) diagnostics(text string) []Diagnostic {
+	diagnostics := []Diagnostic{}
+	if strings.TrimSpace(text) == "" {
+		return diagnostics
+	}
+
+	expr, err := lep.ParseExpression(text)
+	if err != nil {
+		syntaxErrors := lep.SyntaxErrors(err)
+		if len(syntaxErrors) == 0 {
+			return append(diagnostics, newDiagnostic(text, 0, len(text), err.Error()))
+		}
+		for _, e := range syntaxErrors {
+			diagnostics = append(diagnostics, newDiagnostic(text, e.Offset, nextRuneOffset(text, e.Offset), e.Message))
+		}
+		return diagnostics
+	}
+
+	if len(s.schema) == 0 {
+		return diagnostics
+	}
+	tokens := tokenize(text)
+	seen := make(map[string]int)
+	for _, err := range s.schema.Check(expr) {
+		var param string
+		switch e := err.(type) {
+		case lep.ErrUnknownParam:
+			param = e.Param
+		case lep.ErrTypeMismatch:
+			param = e.Param
+		}
+		start, end := locateParam(tokens, param, seen[param])
+		seen[param]++
+		diagnostics = append(diagnostics, newDiagnostic(text, start, end, err.Error()))
+	}
+	return diagnostics
+}
+
+// schemaName returns the name of a param in the schema, which has no
+// quotes: `first name` is first name.
+func schemaName(text string) string {
+	var b strings.Builder
+	quoted := false
+	for i := 0; i < len(text); i++ {
+		switch {
+		case text[i] != '`':
+			b.WriteByte(text[i])
+		case quoted && i+1 < len(text) && text[i+1] == '`':
+			// a doubled backquote stands for one
+			b.WriteByte('`')
+			i++
+		default:
+			quoted = !quoted
+		}
+	}
+	return b.String()
+}
+
+// locateParam returns the span of the n-th occurrence of the param in the
+// document, falling back to the first one.
+func locateParam(tokens []token, name string, n int) (int, int) {
+	var occurrences []token
+	for _, tok := range tokens {
+		if tok.kind == tokenParam && schemaName(tok.text) == schemaName(name) {
+			occurrences = append(occurrences, tok)
+		}
+	}
+	switch {
+	case len(occurrences) == 0:
+		return 0, 0
+	case n < len(occurrences):
+		return occurrences[n].start, occurrences[n].end
+	default:
+		return occurrences[0].start, occurrences[0].end
+	}
+}
+
+func newDiagnostic(text string, start, end int, message string) Diagnostic {
+	return Diagnostic{
+		Range:    rangeOf(text, start, end),
+		Severity: severityError,
+		Source:   "lep",
+		Message:  message,
+	}
+}
+
+func (s *Server) hover(params TextDocumentPositionParams) *Hover {
+	text, ok := s.docs[params.TextDocument.URI]
+	if !ok {
+		return nil
+	}
+	tok, ok := tokenAt(tokenize(text), positionToOffset(text, params.Position))
+	if !ok {
+		return nil
+	}
+
+	var lines []string
+	switch tok.kind {
+	default:
+		return nil
+	case tokenParam:
+		lines = append(lines, "**ParamX** `"+tok.text+"`")
+		if t, ok := s.schema[schemaName(tok.text)]; ok {
+			lines = append(lines, "type: `"+string(t)+"`")
+		} else if len(s.schema) > 0 {
+			lines = append(lines, "unknown param")
+		}
+	case tokenKeyword:
+		lines = append(lines, "**"+keywords[tok.text]+"** `"+tok.text+"`")
+	case tokenFunction:
+		lines = append(lines, "**FunctionCallX** `"+tok.text+"`")
+		if fn, ok := lep.Builtins()[tok.text]; ok {
+			lines = append(lines, "`"+signature(tok.text, fn)+"`")
+		} else {
+			lines = append(lines, "unknown function")
+		}
+	case tokenOperator:
+		lines = append(lines, "**"+operators[tok.text]+"** `"+tok.text+"`")
+	case tokenPlaceholder:
+		lines = append(lines, "**PlaceholderX** `"+tok.text+"`", "bound with lep.Bind")
+	case tokenString, tokenDateTime, tokenNumber, tokenRegexp, tokenLiteral:
+		lines = hoverValue(tok)
+		if lines == nil {
+			return nil
+		}
+	}
+
+	r := rangeOf(text, tok.start, tok.end)
+	return &Hover{
+		Contents: MarkupContent{Kind: markupKindMarkdown, Value: strings.Join(lines, "\n\n")},
+		Range:    &r,
+	}
+}
+
+// signature writes the argument and return types of a function, like
+// concat(string, string...) string.
+func signature(name string, fn *lep.Function) string {
+	args := make([]string, len(fn.Args))
+	for i, t := range fn.Args {
+		args[i] = string(t)
+	}
+	if fn.Variadic && len(args) > 0 {
+		args[len(args)-1] += "..."
+	}
+	return name + "(" + strings.Join(args, ", ") + ") " + string(fn.Returns)
+}
+
+func hoverValue(tok token) []string {
+	entrypoint := "Values"
+	if tok.kind == tokenRegexp {
+		entrypoint = "Regexp"
+	}
+	result, err := lep.Parse("hover", []byte(tok.text), lep.Entrypoint(entrypoint))
+	if err != nil {
+		return []string{"`" + tok.text + "`", err.Error()}
+	}
+	value, ok := result.(lep.Value)
+	if !ok {
+		return nil
+	}
+
+	lines := []string{
+		"**" + strings.TrimPrefix(fmt.Sprintf("%T", value), "*lep.") + "** `" + value.String() + "`",
+		"type: `" + string(lep.TypeOf(value)) + "`",
+	}
+	if dt, ok := value.(*lep.DateTimeX); ok {
+		lines = append(lines, "format: `"+dt.Format+"`", "value: `"+dt.Val.String()+"`")
+	}
+	return lines
+}
+
+func (s *Server) completion(params TextDocumentPositionParams) *CompletionList {
+	text := s.docs[params.TextDocument.URI]
+	offset := positionToOffset(text, params.Position)
+	tokens := tokenize(text)
+
+	// the word being typed is not part of the context
+	prev, ok := tokenBefore(tokens, offset)
+	if ok && prev.end == offset && (prev.kind == tokenParam || prev.kind == tokenKeyword) {
+		prev, ok = tokenBefore(tokens, prev.start)
+	}
+
+	items := []CompletionItem{}
+	switch {
+	case ok && (prev.kind == tokenParam || prev.kind == tokenPlaceholder || prev.kind == tokenLiteral && prev.text != "null"):
+		items = append(items, operatorItems()...)
+	case ok && (prev.kind == tokenOperator && prev.text != "&&" && prev.text != "||" || prev.kind == tokenKeyword):
+		items = append(items, valueItems()...)
+		items = append(items, s.paramItems(tokens)...)
+	default:
+		items = append(items, s.paramItems(tokens)...)
+	}
+	return &CompletionList{Items: items}
+}
+
+func (s *Server) paramItems(tokens []token) []CompletionItem {
+	var items []CompletionItem
+	seen := make(map[string]bool)
+	var names []string
+	for name := range s.schema {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		seen[name] = true
+		items = append(items, CompletionItem{
+			Label:  lep.Param(name).String(),
+			Kind:   completionKindField,
+			Detail: string(s.schema[name]),
+		})
+	}
+	for _, tok := range tokens {
+		if tok.kind == tokenParam && !seen[schemaName(tok.text)] {
+			seen[schemaName(tok.text)] = true
+			items = append(items, CompletionItem{Label: tok.text, Kind: completionKindField})
+		}
+	}
+	return items
+}
+
+func operatorItems() []CompletionItem {
+	var items []CompletionItem
+	for _, op := range []string{"=", "!=", ">", ">=", "<", "<=", "=~", "!~", "=*"} {
+		items = append(items, CompletionItem{Label: op, Kind: completionKindOperator, Detail: operators[op]})
+	}
+	var names []string
+	for keyword := range keywords {
+		names = append(names, keyword)
+	}
+	sort.Strings(names)
+	for _, keyword := range names {
+		items = append(items, CompletionItem{Label: keyword, Kind: completionKindKeyword, Detail: keywords[keyword]})
+	}
+	return items
+}
+
+func valueItems() []CompletionItem {
+	return []CompletionItem{
+		{Label: "true", Kind: completionKindValue, Detail: "BooleanX"},
+		{Label: "false", Kind: completionKindValue, Detail: "BooleanX"},
+		{Label: "null", Kind: completionKindValue, Detail: "NullX"},
+		{Label: `dt:""`, Kind: completionKindValue, Detail: "DateTimeX", InsertText: `dt:"`},
+		{Label: "now()", Kind: completionKindValue, Detail: "RelativeDateTimeX"},
+		{Label: "today()", Kind: completionKindValue, Detail: "RelativeDateTimeX"},
+		{Label: `startOf("")`, Kind: completionKindValue, Detail: "RelativeDateTimeX", InsertText: `startOf("`},
+	}
+}
+
+func (s *Server) formatting(params DocumentFormattingParams) []TextEdit {
+	text, ok := s.docs[params.TextDocument.URI]
+	if !ok || strings.TrimSpace(text) == "" {
+		return nil
+	}
+	expr, err := lep.ParseExpression(text)
+	if err != nil {
+		return nil
+	}
+
+	formatted := lep.Print(expr)
+	if strings.HasSuffix(text, "\n") {
+		formatted += "\n"
+	}
+	if formatted == text {
+		return []TextEdit{}
+	}
+	return []TextEdit{
+		{Range: rangeOf(text, 0, len(text)), NewText: formatted},
+	}
+}
@@ -0,0 +1,357 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	lep "github.com/mgudov/logic-expression-parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"testing"
+)
+
+type testClient struct {
+	t             *testing.T
+	conn          *conn
+	nextID        int
+	notifications []*message
+	done          chan error
+}
+
+func newTestClient(t *testing.T, schema lep.Schema) *testClient {
+	serverIn, clientOut := io.Pipe()
+	clientIn, serverOut := io.Pipe()
+
+	c := &testClient{
+		t:    t,
+		conn: newConn(clientIn, clientOut),
+		done: make(chan error, 1),
+	}
+	go func() {
+		err := NewServer(serverIn, serverOut, schema).Run()
+		serverOut.Close()
+		c.done <- err
+	}()
+	t.Cleanup(func() {
+		clientOut.Close()
+		clientIn.Close()
+	})
+	return c
+}
+
+func (c *testClient) request(method string, params interface{}, result interface{}) *responseError {
+	c.nextID++
+	id := json.RawMessage(fmt.Sprintf("%d", c.nextID))
+	body, err := json.Marshal(params)
+	require.NoError(c.t, err)
+	require.NoError(c.t, c.conn.write(message{JSONRPC: "2.0", ID: &id, Method: method, Params: body}))
+
+	for {
+		msg, err := c.conn.read()
+		require.NoError(c.t, err)
+		if msg.ID == nil {
+			c.notifications = append(c.notifications, msg)
+			continue
+		}
+		require.Equal(c.t, string(id), string(*msg.ID))
+		if msg.Error != nil {
+			return msg.Error
+		}
+		if result != nil {
+			require.NoError(c.t, json.Unmarshal(msg.Result, result))
+		}
+		return nil
+	}
+}
+
+func (c *testClient) notify(method string, params interface{}) {
+	require.NoError(c.t, c.conn.notify(method, params))
+}
+
+func (c *testClient) diagnostics() PublishDiagnosticsParams {
+	msg, err := c.conn.read()
+	require.NoError(c.t, err)
+	require.Equal(c.t, "textDocument/publishDiagnostics", msg.Method)
+
+	var params PublishDiagnosticsParams
+	require.NoError(c.t, json.Unmarshal(msg.Params, &params))
+	return params
+}
+
+func (c *testClient) open(uri, text string) PublishDiagnosticsParams {
+	c.notify("textDocument/didOpen", DidOpenTextDocumentParams{
+		TextDocument: TextDocumentItem{URI: uri, LanguageID: "lep", Version: 1, Text: text},
+	})
+	return c.diagnostics()
+}
+
+func (c *testClient) initialize(opts *InitializationOptions) InitializeResult {
+	var result InitializeResult
+	require.Nil(c.t, c.request("initialize", InitializeParams{InitializationOptions: opts}, &result))
+	c.notify("initialized", struct{}{})
+	return result
+}
+
+var testSchema = lep.Schema{
+	"name":       lep.TypeString,
+	"age":        lep.TypeInteger,
+	"created_at": lep.TypeDateTime,
+	"first name": lep.TypeString,
+	"in":         lep.TypeInteger,
+}
+
+func TestServer_Lifecycle(t *testing.T) {
+	c := newTestClient(t, nil)
+
+	result := c.initialize(nil)
+	assert.Equal(t, syncFull, result.Capabilities.TextDocumentSync)
+	assert.True(t, result.Capabilities.HoverProvider)
+	assert.True(t, result.Capabilities.DocumentFormattingProvider)
+	assert.NotNil(t, result.Capabilities.CompletionProvider)
+	assert.Equal(t, "lep-lsp", result.ServerInfo.Name)
+
+	respErr := c.request("workspace/unknown", struct{}{}, nil)
+	if assert.NotNil(t, respErr) {
+		assert.Equal(t, codeMethodNotFound, respErr.Code)
+	}
+
+	assert.Nil(t, c.request("shutdown", nil, nil))
+	c.notify("exit", nil)
+	assert.NoError(t, <-c.done)
+}
+
+func TestServer_Diagnostics(t *testing.T) {
+	c := newTestClient(t, testSchema)
+	c.initialize(nil)
+
+	type testDiagnostics struct {
+		text   string
+		ranges []Range
+	}
+	var tests = []testDiagnostics{
+		{
+			text: `name="foo" && age>18`,
+		},
+		{
+			text: "",
+		},
+		{
+			text: "name=\"foo\" &&\n  age==18",
+			ranges: []Range{
+				{Start: Position{Line: 1, Character: 6}, End: Position{Line: 1, Character: 7}},
+			},
+		},
+		{
+			text: `name=1 && foo=2 || age="x" && name starts_with "a"`,
+			ranges: []Range{
+				{Start: Position{Line: 0, Character: 0}, End: Position{Line: 0, Character: 4}},
+				{Start: Position{Line: 0, Character: 10}, End: Position{Line: 0, Character: 13}},
+				{Start: Position{Line: 0, Character: 19}, End: Position{Line: 0, Character: 22}},
+			},
+		},
+		{
+			text: "`in`=2 && name=\"a\" && `first name`=1",
+			ranges: []Range{
+				{Start: Position{Line: 0, Character: 22}, End: Position{Line: 0, Character: 34}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		params := c.open("file:///rule.lep", tt.text)
+		assert.Equal(t, "file:///rule.lep", params.URI)
+		if assert.Len(t, params.Diagnostics, len(tt.ranges), tt.text) {
+			for i, d := range params.Diagnostics {
+				assert.Equal(t, tt.ranges[i], d.Range)
+				assert.Equal(t, severityError, d.Severity)
+				assert.Equal(t, "lep", d.Source)
+				assert.NotEmpty(t, d.Message)
+			}
+		}
+	}
+
+	c.notify("textDocument/didChange", DidChangeTextDocumentParams{
+		TextDocument:   TextDocumentIdentifier{URI: "file:///rule.lep"},
+		ContentChanges: []TextDocumentContentChangeEvent{{Text: `age>`}},
+	})
+	assert.Len(t, c.diagnostics().Diagnostics, 1)
+
+	c.notify("textDocument/didClose", DidCloseTextDocumentParams{
+		TextDocument: TextDocumentIdentifier{URI: "file:///rule.lep"},
+	})
+	assert.Len(t, c.diagnostics().Diagnostics, 0)
+}
+
+func TestServer_SchemaFromInitializationOptions(t *testing.T) {
+	c := newTestClient(t, nil)
+	c.initialize(&InitializationOptions{Schema: lep.Schema{"a": lep.TypeBoolean}})
+
+	params := c.open("file:///rule.lep", `a=true && b=1`)
+	if assert.Len(t, params.Diagnostics, 1) {
+		assert.Equal(t, lep.UnknownParam("b").Error(), params.Diagnostics[0].Message)
+	}
+}
+
+func TestServer_Hover(t *testing.T) {
+	c := newTestClient(t, testSchema)
+	c.initialize(nil)
+	c.open("file:///rule.lep", `created_at>dt:"2020-03-04 10:20" && age in [1,2.5] || name =~ /foo/ || x=null || y>1h30m || z=-1.5e-3 || total/count-1>0 || lower(name)=geo(x) || age in [1..10) || items[*].price>1 || `+"`first name`"+`="x" || t=:tenant`)
+
+	type testHover struct {
+		character int
+		contains  []string
+	}
+	var tests = []testHover{
+		{character: 3, contains: []string{"ParamX", "`created_at`", "type: `datetime`"}},
+		{character: 10, contains: []string{"GreaterThanX"}},
+		{character: 15, contains: []string{"DateTimeX", "type: `datetime`", "format: `2006-01-02 15:04`"}},
+		{character: 40, contains: []string{"InSliceX"}},
+		{character: 46, contains: []string{"FloatX", "type: `float`"}},
+		{character: 63, contains: []string{"RegexpX", "type: `regexp`"}},
+		{character: 71, contains: []string{"ParamX", "unknown param"}},
+		{character: 74, contains: []string{"NullX", "type: `null`"}},
+		{character: 85, contains: []string{"DurationX", "`1h30m`", "type: `duration`"}},
+		{character: 99, contains: []string{"FloatX", "`-1.5e-3`", "type: `float`"}},
+		{character: 110, contains: []string{"ArithmeticX", "`/`"}},
+		{character: 112, contains: []string{"ParamX", "`count`"}},
+		{character: 116, contains: []string{"ArithmeticX", "`-`"}},
+		{character: 126, contains: []string{"FunctionCallX", "`lower(string) string`"}},
+		{character: 137, contains: []string{"FunctionCallX", "unknown function"}},
+		{character: 154, contains: []string{"IntegerX", "`1`"}},
+		{character: 155, contains: []string{"BetweenX"}},
+		{character: 157, contains: []string{"IntegerX", "`10`"}},
+		{character: 170, contains: []string{"AnyX", "`[*]`"}},
+		{character: 174, contains: []string{"ParamX", "`price`"}},
+		{character: 186, contains: []string{"ParamX", "``first name``", "type: `string`"}},
+		{character: 208, contains: []string{"PlaceholderX", "`:tenant`"}},
+	}
+
+	for _, tt := range tests {
+		var hover *Hover
+		params := TextDocumentPositionParams{
+			TextDocument: TextDocumentIdentifier{URI: "file:///rule.lep"},
+			Position:     Position{Line: 0, Character: tt.character},
+		}
+		if assert.Nil(t, c.request("textDocument/hover", params, &hover)) && assert.NotNil(t, hover, tt.character) {
+			assert.Equal(t, markupKindMarkdown, hover.Contents.Kind)
+			for _, s := range tt.contains {
+				assert.Contains(t, hover.Contents.Value, s)
+			}
+		}
+	}
+
+	var hover *Hover
+	params := TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: "file:///rule.lep"},
+		Position:     Position{Line: 0, Character: 43},
+	}
+	assert.Nil(t, c.request("textDocument/hover", params, &hover))
+	assert.Nil(t, hover)
+}
+
+func TestServer_Completion(t *testing.T) {
+	c := newTestClient(t, testSchema)
+	c.initialize(nil)
+
+	type testCompletion struct {
+		text     string
+		contains []string
+		excludes []string
+	}
+	var tests = []testCompletion{
+		{
+			text:     ``,
+			contains: []string{"age", "created_at", "name", "`first name`", "`in`"},
+			excludes: []string{"in", "true", "first name"},
+		},
+		{
+			text:     `age `,
+			contains: []string{"=", ">=", "in", "starts_with"},
+			excludes: []string{"name"},
+		},
+		{
+			text:     `age >= `,
+			contains: []string{"true", "null", `dt:""`, "name"},
+			excludes: []string{"in"},
+		},
+		{
+			text:     `age >= 1 && other=1 && na`,
+			contains: []string{"name", "other"},
+			excludes: []string{"in"},
+		},
+	}
+
+	for _, tt := range tests {
+		c.open("file:///rule.lep", tt.text)
+
+		var list CompletionList
+		params := TextDocumentPositionParams{
+			TextDocument: TextDocumentIdentifier{URI: "file:///rule.lep"},
+			Position:     Position{Line: 0, Character: len(tt.text)},
+		}
+		if assert.Nil(t, c.request("textDocument/completion", params, &list)) {
+			labels := make(map[string]bool)
+			for _, item := range list.Items {
+				labels[item.Label] = true
+			}
+			for _, label := range tt.contains {
+				assert.True(t, labels[label], "%q should contain %q", tt.text, label)
+			}
+			for _, label := range tt.excludes {
+				assert.False(t, labels[label], "%q should not contain %q", tt.text, label)
+			}
+		}
+	}
+}
+
+func TestServer_Formatting(t *testing.T) {
+	c := newTestClient(t, nil)
+	c.initialize(nil)
+
+	type testFormatting struct {
+		text  string
+		edits []TextEdit
+	}
+	var tests = []testFormatting{
+		{
+			text: "(a >= 100 && a <= 200)\n|| b = \"Foo\"\n",
+			edits: []TextEdit{
+				{
+					Range:   Range{End: Position{Line: 2}},
+					NewText: "a>=100 && a<=200 || b=\"Foo\"\n",
+				},
+			},
+		},
+		{
+			text:  `a=1 && b="x"`,
+			edits: []TextEdit{},
+		},
+		{
+			text: `a=`,
+		},
+	}
+
+	for _, tt := range tests {
+		c.open("file:///rule.lep", tt.text)
+
+		var edits []TextEdit
+		params := DocumentFormattingParams{TextDocument: TextDocumentIdentifier{URI: "file:///rule.lep"}}
+		if assert.Nil(t, c.request("textDocument/formatting", params, &edits)) {
+			assert.Equal(t, tt.edits, edits)
+		}
+	}
+}
+
+func TestPositionConversion(t *testing.T) {
+	text := "a=\"ü\" &&\nb=\"😀\" && c=1"
+
+	assert.Equal(t, Position{Line: 0, Character: 0}, offsetToPosition(text, 0))
+	assert.Equal(t, Position{Line: 0, Character: 5}, offsetToPosition(text, 6))
+	assert.Equal(t, Position{Line: 1, Character: 0}, offsetToPosition(text, 10))
+	assert.Equal(t, Position{Line: 1, Character: 5}, offsetToPosition(text, 17))
+
+	assert.Equal(t, 6, positionToOffset(text, Position{Line: 0, Character: 5}))
+	assert.Equal(t, 17, positionToOffset(text, Position{Line: 1, Character: 5}))
+	assert.Equal(t, 9, positionToOffset(text, Position{Line: 0, Character: 100}))
+	assert.Equal(t, len(text), positionToOffset(text, Position{Line: 5}))
+}
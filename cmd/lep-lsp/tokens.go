@@ -0,0 +1,253 @@
+package main
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenParam tokenKind = iota
+	tokenKeyword
+	tokenOperator
+	tokenString
+	tokenDateTime
+	tokenNumber
+	tokenRegexp
+	tokenLiteral
+	tokenPunct
+	tokenFunction
+	tokenPlaceholder
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	start int
+	end   int
+}
+
+var keywords = map[string]string{
+	"starts_with":  "StartsWithX",
+	"ends_with":    "EndsWithX",
+	"istarts_with": "IStartsWithX",
+	"iends_with":   "IEndsWithX",
+	"ieq":          "IEqualsX",
+	"contains":     "ContainsX",
+	"not_contains": "NotContainsX",
+	"like":         "LikeX",
+	"not_like":     "NotLikeX",
+	"ilike":        "ILikeX",
+	"glob":         "GlobX",
+	"between":      "BetweenX",
+	"not_between":  "NotBetweenX",
+	"and":          "BetweenX",
+	"is":           "IsNullX / IsEmptyX",
+	"not":          "IsNotNullX / IsNotEmptyX",
+	"empty":        "IsEmptyX",
+	"exists":       "ExistsX",
+	"any":          "AnyX",
+	"all":          "AllX",
+	"in":           "InSliceX",
+	"not_in":       "NotInSliceX",
+	"has":          "HasX",
+	"not_has":      "NotHasX",
+	"has_any":      "HasAnyX",
+	"has_all":      "HasAllX",
+}
+
+var operators = map[string]string{
+	"=":   "EqualsX",
+	"!=":  "NotEqualsX",
+	">":   "GreaterThanX",
+	">=":  "GreaterThanEqualX",
+	"<":   "LessThanX",
+	"<=":  "LessThanEqualX",
+	"=~":  "MatchRegexpX",
+	"!~":  "NotMatchRegexpX",
+	"=*":  "IEqualsX",
+	"..":  "BetweenX",
+	"[*]": "AnyX",
+	"&&":  "AndX",
+	"||":  "OrX",
+	"+":   "ArithmeticX",
+	"-":   "ArithmeticX",
+	"*":   "ArithmeticX",
+	"/":   "ArithmeticX",
+	"%":   "ArithmeticX",
+}
+
+var literals = map[string]bool{
+	"true":  true,
+	"false": true,
+	"null":  true,
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isIdentStart accepts underscores and the bytes of non-ASCII letters too,
+// which start params with the identifier parse options.
+func isIdentStart(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_' || c >= utf8.RuneSelf
+}
+
+func isIdent(c byte) bool {
+	return isIdentStart(c) || isDigit(c) || c == '_' || c == '.'
+}
+
+// isRangeDots reports whether text has the .. of an interval at i, which
+// ends the number or param before it.
+func isRangeDots(text string, i int) bool {
+	return strings.HasPrefix(text[i:], "..")
+}
+
+// tokenize splits text into the tokens of the expression language. It is
+// deliberately forgiving, so it can be used on documents which do not parse.
+func tokenize(text string) []token {
+	var tokens []token
+	i := 0
+	for i < len(text) {
+		c := text[i]
+		start := i
+		kind := tokenPunct
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+			continue
+		case strings.HasPrefix(text[i:], `dt:"`):
+			kind = tokenDateTime
+			i = scanUntil(text, i+4, '"')
+		case c == '"':
+			kind = tokenString
+			i = scanUntil(text, i+1, '"')
+		case c == '/' && afterRegexpOperator(tokens):
+			kind = tokenRegexp
+			i = scanUntil(text, i+1, '/')
+			for i < len(text) && isIdentStart(text[i]) {
+				i++
+			}
+		case isDigit(c) || (strings.IndexByte("-+.", c) >= 0 && i+1 < len(text) && isDigit(text[i+1]) && !afterOperand(tokens)):
+			kind = tokenNumber
+			i++
+			// letters are kept for hex digits, exponents and the units of
+			// durations like 1h30m
+			for i < len(text) && !isRangeDots(text, i) && (isIdent(text[i]) || isExponentSign(text, i)) {
+				i++
+			}
+		case c == '`':
+			kind = tokenParam
+			i = scanUntil(text, i+1, '`')
+			// a doubled backquote stands for one
+			for i < len(text) && text[i] == '`' {
+				i = scanUntil(text, i+1, '`')
+			}
+		case isIdentStart(c):
+			for i < len(text) && !isRangeDots(text, i) && isIdent(text[i]) {
+				i++
+			}
+			word := text[start:i]
+			if _, ok := keywords[word]; ok {
+				kind = tokenKeyword
+			} else if literals[word] {
+				kind = tokenLiteral
+			} else if i < len(text) && text[i] == '(' {
+				kind = tokenFunction
+			} else {
+				kind = tokenParam
+			}
+		case c == '?' || c == ':' && i+1 < len(text) && isIdentStart(text[i+1]):
+			kind = tokenPlaceholder
+			i++
+			for c == ':' && i < len(text) && (isIdentStart(text[i]) || isDigit(text[i])) {
+				i++
+			}
+		case strings.HasPrefix(text[i:], "[*]"):
+			kind = tokenOperator
+			i += 3
+		case i+1 < len(text) && operators[text[i:i+2]] != "":
+			kind = tokenOperator
+			i += 2
+		case operators[text[i:i+1]] != "":
+			kind = tokenOperator
+			i++
+		default:
+			i++
+		}
+		tokens = append(tokens, token{kind: kind, text: text[start:i], start: start, end: i})
+	}
+	return tokens
+}
+
+// afterRegexpOperator reports whether a slash starts a regexp rather than a
+// division.
+func afterRegexpOperator(tokens []token) bool {
+	if len(tokens) == 0 {
+		return false
+	}
+	last := tokens[len(tokens)-1]
+	return last.kind == tokenOperator && (last.text == "=~" || last.text == "!~")
+}
+
+// afterOperand reports whether a sign is a binary operator, as in a-1,
+// rather than the sign of a number.
+func afterOperand(tokens []token) bool {
+	if len(tokens) == 0 {
+		return false
+	}
+	last := tokens[len(tokens)-1]
+	switch last.kind {
+	case tokenParam, tokenNumber, tokenString, tokenDateTime, tokenLiteral, tokenPlaceholder:
+		return true
+	}
+	return last.text == ")"
+}
+
+func isExponentSign(text string, i int) bool {
+	return (text[i] == '-' || text[i] == '+') && (text[i-1] == 'e' || text[i-1] == 'E') &&
+		i+1 < len(text) && isDigit(text[i+1])
+}
+
+func scanUntil(text string, i int, delim byte) int {
+	for i < len(text) && text[i] != delim && text[i] != '\n' {
+		if text[i] == '\\' && i+1 < len(text) && text[i+1] != '\n' {
+			i++
+		}
+		i++
+	}
+	if i < len(text) && text[i] == delim {
+		i++
+	}
+	return i
+}
+
+func tokenAt(tokens []token, offset int) (token, bool) {
+	for _, tok := range tokens {
+		if offset >= tok.start && offset < tok.end {
+			return tok, true
+		}
+	}
+	// the cursor right after the last character still points to the token
+	for _, tok := range tokens {
+		if offset == tok.end && tok.kind != tokenPunct {
+			return tok, true
+		}
+	}
+	return token{}, false
+}
+
+func tokenBefore(tokens []token, offset int) (token, bool) {
+	var (
+		result token
+		found  bool
+	)
+	for _, tok := range tokens {
+		if tok.end > offset {
+			break
+		}
+		result, found = tok, true
+	}
+	return result, found
+}
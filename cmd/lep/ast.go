@@ -0,0 +1,136 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	lep "github.com/mgudov/logic-expression-parser"
+	"io"
+	"strings"
+	"time"
+)
+
+type node struct {
+	Type     string      `json:"type"`
+	Value    interface{} `json:"value,omitempty"`
+	Format   string      `json:"format,omitempty"`
+	Children []*node     `json:"children,omitempty"`
+
+	text string
+}
+
+func typeName(expr lep.Expression) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", expr), "*lep.")
+}
+
+func describe(expr lep.Expression) *node {
+	n := &node{Type: typeName(expr)}
+	switch e := expr.(type) {
+	case *lep.AndX:
+		for _, conjunct := range e.Conjuncts {
+			n.Children = append(n.Children, describe(conjunct))
+		}
+	case *lep.OrX:
+		for _, disjunction := range e.Disjunctions {
+			n.Children = append(n.Children, describe(disjunction))
+		}
+	case *lep.SliceX:
+		for _, value := range e.Values {
+			n.Children = append(n.Children, describe(value))
+		}
+	case lep.Statement:
+		n.Children = append(n.Children, describe(e.GetParam()), describe(e.GetValue()))
+	case *lep.CompareX:
+		n.Value = e.Operator
+		n.text = e.Operator
+		n.Children = append(n.Children, describe(e.Left), describe(e.Right))
+	case *lep.BetweenX:
+		bounds := rangeBounds(e.ExcludeFrom, e.ExcludeTo)
+		n.Value, n.text = bounds, bounds
+		n.Children = append(n.Children, describe(e.Param), describe(e.From), describe(e.To))
+	case *lep.NotBetweenX:
+		bounds := rangeBounds(e.ExcludeFrom, e.ExcludeTo)
+		n.Value, n.text = bounds, bounds
+		n.Children = append(n.Children, describe(e.Param), describe(e.From), describe(e.To))
+	case *lep.ExistsX:
+		n.Children = append(n.Children, describe(e.Param))
+	case *lep.IsNullX:
+		n.Children = append(n.Children, describe(e.Param))
+	case *lep.IsNotNullX:
+		n.Children = append(n.Children, describe(e.Param))
+	case *lep.IsEmptyX:
+		n.Children = append(n.Children, describe(e.Param))
+	case *lep.IsNotEmptyX:
+		n.Children = append(n.Children, describe(e.Param))
+	case *lep.AnyX:
+		n.Children = append(n.Children, describe(e.Param), describe(e.Expr))
+	case *lep.AllX:
+		n.Children = append(n.Children, describe(e.Param), describe(e.Expr))
+	case *lep.ArithmeticX:
+		n.Value = e.Operator
+		n.text = e.Operator
+		if e.Left != nil {
+			n.Children = append(n.Children, describe(e.Left))
+		}
+		n.Children = append(n.Children, describe(e.Right))
+	case *lep.FunctionCallX:
+		n.Value = e.Name
+		n.text = e.Name
+		for _, arg := range e.Args {
+			n.Children = append(n.Children, describe(arg))
+		}
+	case *lep.DateTimeX:
+		n.Value = e.Val.Format(time.RFC3339Nano)
+		n.Format = e.Format
+		n.text = e.String()
+	case *lep.DecimalX:
+		n.Value = json.Number(e.Canonical())
+		n.text = e.String()
+	case *lep.RegexpX:
+		n.Value = e.String()
+		n.text = e.String()
+	case lep.Value:
+		n.Value = e.Value()
+		n.text = e.String()
+	}
+	return n
+}
+
+// rangeBounds writes the bounds of a range as in an interval: [], [), (]
+// or ().
+func rangeBounds(excludeFrom, excludeTo bool) string {
+	bounds := []byte("[]")
+	if excludeFrom {
+		bounds[0] = '('
+	}
+	if excludeTo {
+		bounds[1] = ')'
+	}
+	return string(bounds)
+}
+
+func printTree(w io.Writer, n *node) {
+	fmt.Fprintln(w, n.label())
+	printChildren(w, n.Children, "")
+}
+
+func printChildren(w io.Writer, children []*node, indent string) {
+	for i, child := range children {
+		branch, next := "├── ", "│   "
+		if i == len(children)-1 {
+			branch, next = "└── ", "    "
+		}
+		fmt.Fprintln(w, indent+branch+child.label())
+		printChildren(w, child.Children, indent+next)
+	}
+}
+
+func (n *node) label() string {
+	label := n.Type
+	if n.text != "" {
+		label += " " + n.text
+	}
+	if n.Format != "" {
+		label += " (format: " + n.Format + ")"
+	}
+	return label
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	lep "github.com/mgudov/logic-expression-parser"
+	"github.com/mgudov/logic-expression-parser/sql"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("lep "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	return fs
+}
+
+// readExpression parses the expression given with -e, as positional
+// arguments or, if allowed, on stdin.
+func readExpression(flagValue string, args []string, stdin io.Reader) (lep.Expression, error) {
+	query := flagValue
+	if query == "" {
+		query = strings.Join(args, " ")
+	}
+	if query == "" && stdin != nil {
+		data, err := ioutil.ReadAll(stdin)
+		if err != nil {
+			return nil, err
+		}
+		query = string(data)
+	}
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("no expression given")
+	}
+	return lep.ParseExpression(query)
+}
+
+func fail(stderr io.Writer, err error) int {
+	fmt.Fprintf(stderr, "lep: %v\n", err)
+	return 1
+}
+
+func runParse(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("parse", stderr)
+	query := fs.String("e", "", "expression")
+	format := fs.String("format", "tree", "output format: json or tree")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	expr, err := readExpression(*query, fs.Args(), stdin)
+	if err != nil {
+		return fail(stderr, err)
+	}
+	switch *format {
+	default:
+		return fail(stderr, fmt.Errorf("unknown format %q", *format))
+	case "tree":
+		printTree(stdout, describe(expr))
+	case "json":
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(describe(expr)); err != nil {
+			return fail(stderr, err)
+		}
+	}
+	return 0
+}
+
+func runFmt(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("fmt", stderr)
+	query := fs.String("e", "", "expression")
+	width := fs.Int("width", 0, "break groups longer than this many columns across lines")
+	multiline := fs.Bool("multiline", false, "break every group across lines")
+	indent := fs.String("indent", "  ", "indentation of nested groups")
+	spaced := fs.Bool("spaced", false, "put spaces around comparators")
+	sorted := fs.Bool("sort", false, "sort the clauses of every group")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	expr, err := readExpression(*query, fs.Args(), stdin)
+	if err != nil {
+		return fail(stderr, err)
+	}
+	opts := []lep.PrintOption{lep.PrintMaxWidth(*width), lep.PrintIndent(*indent)}
+	if *multiline {
+		opts = append(opts, lep.PrintMultiline())
+	}
+	if *spaced {
+		opts = append(opts, lep.PrintSpaced())
+	}
+	if *sorted {
+		opts = append(opts, lep.PrintSorted())
+	}
+	fmt.Fprintln(stdout, lep.Print(expr, opts...))
+	return 0
+}
+
+func runEval(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("eval", stderr)
+	query := fs.String("e", "", "expression")
+	nullsName := fs.String("nulls", "two-valued", "null semantics: two-valued, strict or three-valued")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	nulls, err := parseNulls(*nullsName)
+	if err != nil {
+		return fail(stderr, err)
+	}
+	expr, err := readExpression(*query, fs.Args(), nil)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	scanner := bufio.NewScanner(stdin)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	w := bufio.NewWriter(stdout)
+	defer w.Flush()
+
+	evaluator := lep.NewEvaluator(lep.EvalNulls(nulls))
+	for line := 1; scanner.Scan(); line++ {
+		raw := scanner.Bytes()
+		if len(bytes.TrimSpace(raw)) == 0 {
+			continue
+		}
+
+		var record map[string]interface{}
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.UseNumber()
+		if err := dec.Decode(&record); err != nil {
+			return fail(stderr, fmt.Errorf("line %d: %w", line, err))
+		}
+		ok, err := evaluator.Evaluate(expr, record)
+		if err != nil {
+			return fail(stderr, fmt.Errorf("line %d: %w", line, err))
+		}
+		if ok {
+			w.Write(raw)
+			w.WriteByte('\n')
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fail(stderr, err)
+	}
+	return 0
+}
+
+func runCheck(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("check", stderr)
+	query := fs.String("e", "", "expression")
+	schemaPath := fs.String("schema", "", "path to a JSON file mapping param names to types")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *schemaPath == "" {
+		fmt.Fprintln(stderr, "lep check: -schema is required")
+		return 2
+	}
+
+	schema, err := readSchema(*schemaPath)
+	if err != nil {
+		return fail(stderr, err)
+	}
+	expr, err := readExpression(*query, fs.Args(), stdin)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	errs := schema.Check(expr)
+	for _, err := range errs {
+		fmt.Fprintln(stdout, err)
+	}
+	if len(errs) > 0 {
+		return 1
+	}
+	return 0
+}
+
+func parseNulls(name string) (lep.Nulls, error) {
+	switch name {
+	case "two-valued":
+		return lep.TwoValuedNulls, nil
+	case "strict":
+		return lep.StrictNulls, nil
+	case "three-valued":
+		return lep.ThreeValuedNulls, nil
+	}
+	return 0, fmt.Errorf("unknown null semantics %q", name)
+}
+
+func readSchema(path string) (lep.Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var schema lep.Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return schema, nil
+}
+
+func runSQL(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("sql", stderr)
+	query := fs.String("e", "", "expression")
+	dialectName := fs.String("dialect", string(sql.Postgres), "SQL dialect: postgres, mysql or sqlite")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	dialect, err := sql.ParseDialect(*dialectName)
+	if err != nil {
+		return fail(stderr, err)
+	}
+	expr, err := readExpression(*query, fs.Args(), stdin)
+	if err != nil {
+		return fail(stderr, err)
+	}
+	where, params, err := sql.Translate(expr, dialect)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	if params == nil {
+		params = []interface{}{}
+	}
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return fail(stderr, err)
+	}
+	fmt.Fprintln(stdout, where)
+	fmt.Fprintln(stdout, string(encoded))
+	return 0
+}
@@ -0,0 +1,63 @@
+// Command lep parses, formats, evaluates and translates logic expressions.
+//
+// Usage:
+//
+//	lep parse [-format json|tree] [expression]
+//	lep fmt [-width n] [-multiline] [-indent s] [-spaced] [-sort] [expression]
+//	lep eval -e expression < records.jsonl
+//	lep check -schema schema.json [expression]
+//	lep sql [-dialect postgres|mysql|sqlite] [expression]
+//	lep repl [-load record.json] [-schema schema.json] [-history file]
+//
+// If the expression is not given as an argument (or with -e), it is read
+// from stdin.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+const usage = `usage: lep <command> [flags] [expression]
+
+commands:
+  parse   print the parsed expression as JSON or as a tree
+  fmt     print the expression in canonical form
+  eval    print JSON lines from stdin matching the expression
+  check   check the expression against a schema
+  sql     print the SQL WHERE fragment for the expression
+  repl    build and test expressions interactively
+`
+
+type command func(args []string, stdin io.Reader, stdout, stderr io.Writer) int
+
+var commands = map[string]command{
+	"parse": runParse,
+	"fmt":   runFmt,
+	"eval":  runEval,
+	"check": runCheck,
+	"sql":   runSQL,
+	"repl":  runREPL,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprint(stdout, usage)
+		return 0
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "lep: unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+	return cmd(args[1:], stdin, stdout, stderr)
+}
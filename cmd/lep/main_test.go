@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type testRun struct {
+	args   []string
+	stdin  string
+	stdout string
+	stderr string
+	code   int
+}
+
+func (tt testRun) check(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+	assert.Equal(t, tt.code, code, "%v: %s", tt.args, stderr.String())
+	assert.Equal(t, tt.stdout, stdout.String(), "%v", tt.args)
+	if tt.stderr != "" {
+		assert.Contains(t, stderr.String(), tt.stderr)
+	}
+}
+
+func TestRun(t *testing.T) {
+	var tests = []testRun{
+		{args: nil, stderr: "usage: lep", code: 2},
+		{args: []string{"help"}, stdout: usage},
+		{args: []string{"unknown"}, stderr: `unknown command "unknown"`, code: 2},
+	}
+	for _, tt := range tests {
+		tt.check(t)
+	}
+}
+
+func TestParse(t *testing.T) {
+	var tests = []testRun{
+		{
+			args: []string{"parse", `a=false && (b in [1,"x"] || c>dt:"2020-01-02")`},
+			stdout: `AndX
+├── EqualsX
+│   ├── ParamX a
+│   └── BooleanX false
+└── OrX
+    ├── InSliceX
+    │   ├── ParamX b
+    │   └── SliceX
+    │       ├── IntegerX 1
+    │       └── StringX "x"
+    └── GreaterThanX
+        ├── ParamX c
+        └── DateTimeX dt:"2020-01-02" (format: 2006-01-02)
+`,
+		},
+		{
+			args:  []string{"parse", "-format", "json"},
+			stdin: `a=null || b>=1.5`,
+			stdout: `{
+  "type": "OrX",
+  "children": [
+    {
+      "type": "EqualsX",
+      "children": [
+        {
+          "type": "ParamX",
+          "value": "a"
+        },
+        {
+          "type": "NullX"
+        }
+      ]
+    },
+    {
+      "type": "GreaterThanEqualX",
+      "children": [
+        {
+          "type": "ParamX",
+          "value": "b"
+        },
+        {
+          "type": "FloatX",
+          "value": 1.5
+        }
+      ]
+    }
+  ]
+}
+`,
+		},
+		{
+			args: []string{"parse", `len(tags) * 2 > -abs(delta)`},
+			stdout: `CompareX >
+├── ArithmeticX *
+│   ├── FunctionCallX len
+│   │   └── ParamX tags
+│   └── IntegerX 2
+└── ArithmeticX -
+    └── FunctionCallX abs
+        └── ParamX delta
+`,
+		},
+		{
+			args: []string{"parse", `age in [18..65)`},
+			stdout: `BetweenX [)
+├── ParamX age
+├── IntegerX 18
+└── IntegerX 65
+`,
+		},
+		{
+			args: []string{"parse", `items[*].price > 100`},
+			stdout: `AnyX
+├── ParamX items
+└── GreaterThanX
+    ├── ParamX price
+    └── IntegerX 100
+`,
+		},
+		{args: []string{"parse", "-format", "yaml", "a=1"}, stderr: `unknown format "yaml"`, code: 1},
+		{args: []string{"parse", "a=="}, stderr: "no match found", code: 1},
+		{args: []string{"parse"}, stderr: "no expression given", code: 1},
+		{args: []string{"parse", "-unknown"}, stderr: "flag provided but not defined", code: 2},
+	}
+	for _, tt := range tests {
+		tt.check(t)
+	}
+}
+
+func TestFmt(t *testing.T) {
+	var tests = []testRun{
+		{args: []string{"fmt", "(a >= 100 && a <= 200)", "||", "b = \"Foo\""}, stdout: "a>=100 && a<=200 || b=\"Foo\"\n"},
+		{args: []string{"fmt", "-e", "((a=1))"}, stdout: "a=1\n"},
+		{args: []string{"fmt"}, stdin: "\n  a in [1,2]\n", stdout: "a in [1,2]\n"},
+		{args: []string{"fmt", "-spaced", "-sort", "b<1.0 && a !~ /x/"}, stdout: "a !~ /x/ && b < 1.0\n"},
+		{args: []string{"fmt", "-multiline", "-indent", "\t", "a=1 && (b=2 || c=3)"}, stdout: "a=1\n&& (\n\tb=2\n\t|| c=3\n)\n"},
+		{args: []string{"fmt", "-width", "16", "a=1 && (b=2 || c=3)"}, stdout: "a=1\n&& (b=2 || c=3)\n"},
+	}
+	for _, tt := range tests {
+		tt.check(t)
+	}
+}
+
+func TestEval(t *testing.T) {
+	records := `{"name":"john","age":42,"tags":["a","b"]}
+
+{"name":"jane","age":17,"tags":["b"]}
+{"name":"bob","age":99999999999999999999,"address":{"city":"Berlin"}}
+`
+	var tests = []testRun{
+		{
+			args:   []string{"eval", "-e", `age>=18`},
+			stdin:  records,
+			stdout: "{\"name\":\"john\",\"age\":42,\"tags\":[\"a\",\"b\"]}\n{\"name\":\"bob\",\"age\":99999999999999999999,\"address\":{\"city\":\"Berlin\"}}\n",
+		},
+		{
+			args:   []string{"eval", "-e", `tags has "b" && name starts_with "ja" || address.city="Berlin"`},
+			stdin:  records,
+			stdout: "{\"name\":\"jane\",\"age\":17,\"tags\":[\"b\"]}\n{\"name\":\"bob\",\"age\":99999999999999999999,\"address\":{\"city\":\"Berlin\"}}\n",
+		},
+		{
+			args:   []string{"eval", "-nulls", "three-valued", "-e", `address.city != "Paris"`},
+			stdin:  records,
+			stdout: "{\"name\":\"bob\",\"age\":99999999999999999999,\"address\":{\"city\":\"Berlin\"}}\n",
+		},
+		{args: []string{"eval", "-nulls", "strict", "-e", `tags has "b"`}, stdin: records, stdout: "{\"name\":\"john\",\"age\":42,\"tags\":[\"a\",\"b\"]}\n{\"name\":\"jane\",\"age\":17,\"tags\":[\"b\"]}\n", stderr: "line 4: missing param: tags", code: 1},
+		{args: []string{"eval", "-nulls", "sql", "-e", `a=1`}, stderr: `unknown null semantics "sql"`, code: 1},
+		{args: []string{"eval", "-e", `a=1`}, stdin: "{\"a\":1}\nnot json\n", stdout: "{\"a\":1}\n", stderr: "line 2", code: 1},
+		{args: []string{"eval"}, stdin: records, stderr: "no expression given", code: 1},
+	}
+	for _, tt := range tests {
+		tt.check(t)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+	schema := filepath.Join(dir, "schema.json")
+	assert.NoError(t, os.WriteFile(schema, []byte(`{"name":"string","age":"integer","first name":"string","in":"integer"}`), 0o644))
+	invalid := filepath.Join(dir, "invalid.json")
+	assert.NoError(t, os.WriteFile(invalid, []byte(`[]`), 0o644))
+
+	var tests = []testRun{
+		{args: []string{"check", "-schema", schema, `name="x" && age>1`}},
+		{args: []string{"check", "-schema", schema, "`first name`=\"x\" && `in`>1"}},
+		{
+			args:   []string{"check", "--schema", schema, `name=1 || foo=2`},
+			stdout: "name: type mismatch; expected: string; received: integer\nunknown param: foo\n",
+			code:   1,
+		},
+		{args: []string{"check", `a=1`}, stderr: "-schema is required", code: 2},
+		{args: []string{"check", "-schema", filepath.Join(dir, "missing.json"), `a=1`}, stderr: "missing.json", code: 1},
+		{args: []string{"check", "-schema", invalid, `a=1`}, stderr: "invalid.json", code: 1},
+	}
+	for _, tt := range tests {
+		tt.check(t)
+	}
+}
+
+func TestSQL(t *testing.T) {
+	var tests = []testRun{
+		{
+			args:   []string{"sql", `active=true && (last_login>dt:"2010-01-01" || role in ["client","customer"])`},
+			stdout: "active = $1 AND (last_login > $2 OR role IN ($3, $4))\n[true,\"2010-01-01T00:00:00Z\",\"client\",\"customer\"]\n",
+		},
+		{
+			args:   []string{"sql", "--dialect", "mysql", `a=1 && b=null`},
+			stdout: "a = ? AND b IS NULL\n[1]\n",
+		},
+		{
+			args:   []string{"sql", `a=null`},
+			stdout: "a IS NULL\n[]\n",
+		},
+		{args: []string{"sql", "-dialect", "oracle", `a=1`}, stderr: "unknown dialect", code: 1},
+	}
+	for _, tt := range tests {
+		tt.check(t)
+	}
+}
@@ -0,0 +1,347 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	lep "github.com/mgudov/logic-expression-parser"
+	"github.com/mgudov/logic-expression-parser/sql"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const replHelp = `type an expression to see its AST, canonical form, result and trace
+
+commands:
+  :load <file>            load the record to evaluate against (JSON or YAML)
+  :record                 print the loaded record
+  :schema                 print the schema
+  :schema <file>          load the schema from a JSON file
+  :schema <name>=<type>   set the type of a param (any, string, integer,
+                          float, boolean, datetime, array, regexp, null)
+  :sql [dialect]          translate the last expression to SQL
+  :history                list previous lines; !n repeats line n, !! the last
+  :help                   print this help
+  :quit                   exit
+`
+
+var schemaTypes = map[lep.Type]bool{
+	lep.TypeAny:      true,
+	lep.TypeString:   true,
+	lep.TypeInteger:  true,
+	lep.TypeFloat:    true,
+	lep.TypeBoolean:  true,
+	lep.TypeDateTime: true,
+	lep.TypeDuration: true,
+	lep.TypeArray:    true,
+	lep.TypeRegexp:   true,
+	lep.TypeNull:     true,
+}
+
+type repl struct {
+	out       io.Writer
+	evaluator *lep.Evaluator
+	record    map[string]interface{}
+	schema    lep.Schema
+	history   []string
+	last      lep.Expression
+
+	historyFile io.Writer
+}
+
+func runREPL(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("repl", stderr)
+	recordPath := fs.String("load", "", "path to the record to evaluate against (JSON or YAML)")
+	schemaPath := fs.String("schema", "", "path to a JSON file mapping param names to types")
+	historyPath := fs.String("history", "", "path to a file to keep the history in")
+	nullsName := fs.String("nulls", "two-valued", "null semantics: two-valued, strict or three-valued")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	nulls, err := parseNulls(*nullsName)
+	if err != nil {
+		return fail(stderr, err)
+	}
+	r := &repl{
+		out:       stdout,
+		evaluator: lep.NewEvaluator(lep.EvalNulls(nulls)),
+		record:    map[string]interface{}{},
+		schema:    lep.Schema{},
+	}
+	if *recordPath != "" {
+		if err := r.load(*recordPath); err != nil {
+			return fail(stderr, err)
+		}
+	}
+	if *schemaPath != "" {
+		schema, err := readSchema(*schemaPath)
+		if err != nil {
+			return fail(stderr, err)
+		}
+		r.schema = schema
+	}
+	if *historyPath != "" {
+		f, err := r.openHistory(*historyPath)
+		if err != nil {
+			return fail(stderr, err)
+		}
+		defer f.Close()
+		r.historyFile = f
+	}
+
+	scanner := bufio.NewScanner(stdin)
+	for {
+		fmt.Fprint(stdout, "lep> ")
+		if !scanner.Scan() {
+			fmt.Fprintln(stdout)
+			break
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		line, err := r.expand(line)
+		if err != nil {
+			r.error(err)
+			continue
+		}
+		r.remember(line)
+		if !r.exec(line) {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fail(stderr, err)
+	}
+	return 0
+}
+
+func (r *repl) openHistory(path string) (*os.File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			r.history = append(r.history, line)
+		}
+	}
+	return os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+}
+
+// expand replaces the history references !! and !n with the lines they
+// refer to.
+func (r *repl) expand(line string) (string, error) {
+	if !strings.HasPrefix(line, "!") {
+		return line, nil
+	}
+	if line == "!!" {
+		if len(r.history) == 0 {
+			return "", fmt.Errorf("history is empty")
+		}
+		line = r.history[len(r.history)-1]
+	} else {
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 1 || n > len(r.history) {
+			return "", fmt.Errorf("no such history entry: %s", line)
+		}
+		line = r.history[n-1]
+	}
+	fmt.Fprintln(r.out, line)
+	return line, nil
+}
+
+func (r *repl) remember(line string) {
+	r.history = append(r.history, line)
+	if r.historyFile != nil {
+		fmt.Fprintln(r.historyFile, line)
+	}
+}
+
+func (r *repl) error(err error) {
+	fmt.Fprintf(r.out, "error: %v\n", err)
+}
+
+// exec runs a single line and reports whether the session should go on.
+func (r *repl) exec(line string) bool {
+	if !strings.HasPrefix(line, ":") {
+		r.expression(line)
+		return true
+	}
+
+	fields := strings.Fields(line)
+	switch cmd, args := fields[0], fields[1:]; cmd {
+	default:
+		r.error(fmt.Errorf("unknown command %s; type :help for the list of commands", cmd))
+	case ":quit", ":q", ":exit":
+		return false
+	case ":help":
+		fmt.Fprint(r.out, replHelp)
+	case ":load":
+		if len(args) != 1 {
+			r.error(fmt.Errorf("usage: :load <file>"))
+		} else if err := r.load(args[0]); err != nil {
+			r.error(err)
+		} else {
+			fmt.Fprintf(r.out, "loaded %s\n", args[0])
+		}
+	case ":record":
+		data, err := json.MarshalIndent(r.record, "", "  ")
+		if err != nil {
+			r.error(err)
+		} else {
+			fmt.Fprintln(r.out, string(data))
+		}
+	case ":schema":
+		if err := r.setSchema(args); err != nil {
+			r.error(err)
+		} else {
+			r.printSchema()
+		}
+	case ":sql":
+		r.sql(args)
+	case ":history":
+		for i, entry := range r.history {
+			fmt.Fprintf(r.out, "%4d  %s\n", i+1, entry)
+		}
+	}
+	return true
+}
+
+func (r *repl) load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var record map[string]interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &record)
+	default:
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		err = dec.Decode(&record)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if record == nil {
+		record = map[string]interface{}{}
+	}
+	r.record = record
+	return nil
+}
+
+func (r *repl) setSchema(args []string) error {
+	if len(args) == 1 && !strings.Contains(args[0], "=") {
+		schema, err := readSchema(args[0])
+		if err != nil {
+			return err
+		}
+		r.schema = schema
+		return nil
+	}
+	for _, arg := range args {
+		i := strings.Index(arg, "=")
+		if i <= 0 {
+			return fmt.Errorf("usage: :schema <name>=<type> ...")
+		}
+		typ := lep.Type(strings.ToLower(arg[i+1:]))
+		if !schemaTypes[typ] {
+			return fmt.Errorf("unknown type: %s", arg[i+1:])
+		}
+		r.schema[arg[:i]] = typ
+	}
+	return nil
+}
+
+func (r *repl) printSchema() {
+	var names []string
+	for name := range r.schema {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(r.out, "%s: %s\n", name, r.schema[name])
+	}
+}
+
+func (r *repl) sql(args []string) {
+	dialect := sql.Postgres
+	if len(args) > 0 {
+		d, err := sql.ParseDialect(args[0])
+		if err != nil {
+			r.error(err)
+			return
+		}
+		dialect = d
+	}
+	if r.last == nil {
+		r.error(fmt.Errorf("no expression yet"))
+		return
+	}
+	where, params, err := sql.Translate(r.last, dialect)
+	if err != nil {
+		r.error(err)
+		return
+	}
+	fmt.Fprintln(r.out, where)
+	for i, param := range params {
+		fmt.Fprintf(r.out, "  %d: %#v\n", i+1, param)
+	}
+}
+
+func (r *repl) expression(query string) {
+	expr, err := lep.ParseExpression(query)
+	if err != nil {
+		r.error(err)
+		return
+	}
+	r.last = expr
+
+	fmt.Fprintln(r.out, "ast:")
+	var tree bytes.Buffer
+	printTree(&tree, describe(expr))
+	for _, line := range strings.SplitAfter(strings.TrimSuffix(tree.String(), "\n"), "\n") {
+		fmt.Fprint(r.out, "  "+line)
+	}
+	fmt.Fprintln(r.out)
+	fmt.Fprintf(r.out, "string: %s\n", expr)
+	if len(r.schema) > 0 {
+		for _, err := range r.schema.Check(expr) {
+			fmt.Fprintf(r.out, "schema: %v\n", err)
+		}
+	}
+
+	trace, err := r.evaluator.Trace(expr, r.record)
+	if err != nil {
+		r.error(err)
+		return
+	}
+	fmt.Fprintf(r.out, "result: %s\n", traceResult(trace))
+	if len(trace.Children) > 0 {
+		fmt.Fprintln(r.out, "trace:")
+		printTrace(r.out, trace, "  ")
+	}
+}
+
+func traceResult(trace *lep.Trace) string {
+	if trace.Unknown {
+		return "unknown"
+	}
+	return fmt.Sprint(trace.Result)
+}
+
+func printTrace(w io.Writer, trace *lep.Trace, indent string) {
+	fmt.Fprintf(w, "%s%-5s  %s\n", indent, traceResult(trace), trace.Expression)
+	for _, child := range trace.Children {
+		printTrace(w, child, indent+"  ")
+	}
+}
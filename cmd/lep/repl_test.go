@@ -0,0 +1,110 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestREPL(t *testing.T) {
+	dir := t.TempDir()
+	recordJSON := filepath.Join(dir, "user.json")
+	recordYAML := filepath.Join(dir, "user.yaml")
+	history := filepath.Join(dir, "history")
+	assert.NoError(t, os.WriteFile(recordJSON, []byte(`{"age": 30, "name": "bob"}`), 0o600))
+	assert.NoError(t, os.WriteFile(recordYAML, []byte("age: 10\nname: alice\n"), 0o600))
+
+	session := strings.Join([]string{
+		":load " + recordJSON,
+		`age>18 && name="alice"`,
+		":schema age=string",
+		"!2",
+		":sql mysql",
+		":load " + recordYAML,
+		"age<18",
+		"a=",
+		":history",
+		":unknown",
+		":quit",
+		"ignored",
+	}, "\n")
+
+	tt := testRun{
+		args:  []string{"repl", "-history", history},
+		stdin: session,
+		stdout: `lep> loaded ` + recordJSON + `
+lep> ast:
+  AndX
+  ├── GreaterThanX
+  │   ├── ParamX age
+  │   └── IntegerX 18
+  └── EqualsX
+      ├── ParamX name
+      └── StringX "alice"
+string: age>18 && name="alice"
+result: false
+trace:
+  false  age>18 && name="alice"
+    true   age>18
+    false  name="alice"
+lep> age: string
+lep> age>18 && name="alice"
+ast:
+  AndX
+  ├── GreaterThanX
+  │   ├── ParamX age
+  │   └── IntegerX 18
+  └── EqualsX
+      ├── ParamX name
+      └── StringX "alice"
+string: age>18 && name="alice"
+schema: age: type mismatch; expected: string; received: integer
+schema: unknown param: name
+result: false
+trace:
+  false  age>18 && name="alice"
+    true   age>18
+    false  name="alice"
+lep> age > ? AND name = ?
+  1: 18
+  2: "alice"
+lep> loaded ` + recordYAML + `
+lep> ast:
+  LessThanX
+  ├── ParamX age
+  └── IntegerX 18
+string: age<18
+schema: age: type mismatch; expected: string; received: integer
+result: true
+lep> error: data:1:3 (2): no match found, expected: "(", "-", ".", "0", ":", "?", "\"", "` + "`" + `", "dt:", "false", "now", "null", "startOf", "today", "true", [ \n\t\r], [+-], [0-9] or [a-zA-Z]
+lep>    1  :load ` + recordJSON + `
+   2  age>18 && name="alice"
+   3  :schema age=string
+   4  age>18 && name="alice"
+   5  :sql mysql
+   6  :load ` + recordYAML + `
+   7  age<18
+   8  a=
+   9  :history
+lep> error: unknown command :unknown; type :help for the list of commands
+lep> `,
+	}
+	tt.check(t)
+
+	data, err := os.ReadFile(history)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 11, strings.Count(string(data), "\n"))
+	}
+
+	tt = testRun{
+		args:   []string{"repl", "-history", history},
+		stdin:  "!7\n:record\n",
+		stdout: "lep> age<18\nast:\n  LessThanX\n  ├── ParamX age\n  └── IntegerX 18\nstring: age<18\nresult: false\nlep> {}\nlep> \n",
+	}
+	tt.check(t)
+
+	tt = testRun{args: []string{"repl", "-load", filepath.Join(dir, "missing.json")}, stderr: "missing.json", code: 1}
+	tt.check(t)
+}
@@ -0,0 +1,258 @@
+package lep
+
+// CompareX is a comparison that has no statement node: both sides are
+// literals, or the operator cannot be turned around to put the param on
+// the left, like "foobar" starts_with prefix.
+type CompareX struct {
+	Left     Value
+	Operator string
+	Right    Value
+}
+
+var _ Expression = (*CompareX)(nil)
+
+func Compare(left Value, operator string, right Value) *CompareX {
+	return &CompareX{
+		Left:     left,
+		Operator: operator,
+		Right:    right,
+	}
+}
+
+func (e CompareX) Equals(other Expression) bool {
+	if expr, ok := other.(*CompareX); ok {
+		return e.Operator == expr.Operator && e.Left.Equals(expr.Left) && e.Right.Equals(expr.Right)
+	}
+	return false
+}
+
+func (e CompareX) String() string {
+	return e.Left.String() + operatorText(e.Operator) + e.Right.String()
+}
+
+// operatorText returns the operator as it is written between operands.
+func operatorText(op string) string {
+	switch op {
+	case "=", "!=", "<", "<=", ">", ">=", "=*":
+		return op
+	default:
+		return " " + op + " "
+	}
+}
+
+// operatorOf returns the operator of a statement or CompareX, or "" for
+// other expressions.
+func operatorOf(expr Expression) string {
+	switch x := expr.(type) {
+	case *EqualsX:
+		return "="
+	case *NotEqualsX:
+		return "!="
+	case *GreaterThanX:
+		return ">"
+	case *GreaterThanEqualX:
+		return ">="
+	case *LessThanX:
+		return "<"
+	case *LessThanEqualX:
+		return "<="
+	case *MatchRegexpX:
+		return "=~"
+	case *NotMatchRegexpX:
+		return "!~"
+	case *StartsWithX:
+		return "starts_with"
+	case *EndsWithX:
+		return "ends_with"
+	case *IStartsWithX:
+		return "istarts_with"
+	case *IEndsWithX:
+		return "iends_with"
+	case *IEqualsX:
+		return "=*"
+	case *ContainsX:
+		return "contains"
+	case *NotContainsX:
+		return "not_contains"
+	case *LikeX:
+		return "like"
+	case *NotLikeX:
+		return "not_like"
+	case *ILikeX:
+		return "ilike"
+	case *GlobX:
+		return "glob"
+	case *InSliceX:
+		return "in"
+	case *NotInSliceX:
+		return "not_in"
+	case *HasX:
+		return "has"
+	case *NotHasX:
+		return "not_has"
+	case *HasAnyX:
+		return "has_any"
+	case *HasAllX:
+		return "has_all"
+	case *CompareX:
+		return x.Operator
+	}
+	return ""
+}
+
+// operation is an operator with its right operand, parsed before it is known
+// which statement the left operand makes of it.
+type operation struct {
+	Operator string
+	Right    interface{}
+}
+
+func newOperation(op []byte, right interface{}) (*operation, error) {
+	return &operation{Operator: string(op), Right: right}, nil
+}
+
+func parseOperation(left, op interface{}) (Expression, error) {
+	o, ok := op.(*operation)
+	if !ok {
+		return nil, IncorrectType("parseOperation", (*operation)(nil), op)
+	}
+	return parseComparison(o.Operator, left, o.Right)
+}
+
+// parseComparison builds the statement of op with the param on the left,
+// turning the operands around if needed: 10<age becomes age>10 and
+// "admin" in roles becomes roles has "admin". Otherwise it returns a
+// CompareX. A nil operand has reported its error already and gives nil too.
+func parseComparison(op string, left, right interface{}) (Expression, error) {
+	if left == nil || right == nil {
+		return nil, nil
+	}
+	if err := checkPattern(op, right); err != nil {
+		return nil, err
+	}
+	if b, ok := right.(*bounds); ok {
+		return parseBetween(op, left, b)
+	}
+	_, leftParam := left.(*ParamX)
+	_, rightParam := right.(*ParamX)
+	_, rightSlice := right.(*SliceX)
+	sliceOp := op == "in" || op == "not_in" || op == "has_any" || op == "has_all"
+	switch {
+	case leftParam && rightParam && (op == "in" || op == "not_in"):
+		return parseOperator(flippedOperators[op], right, left)
+	case leftParam && sliceOp && !rightSlice:
+		// there is no statement for two arrays or for a function call
+	case leftParam:
+		return parseOperator(op, left, right)
+	case rightParam:
+		if flipped, ok := flipOperator(op, left); ok {
+			return parseOperator(flipped, right, left)
+		}
+	}
+	return parseCompare(left, op, right)
+}
+
+var flippedOperators = map[string]string{
+	"=":       "=",
+	"!=":      "!=",
+	"<":       ">",
+	"<=":      ">=",
+	">":       "<",
+	">=":      "<=",
+	"=*":      "=*",
+	"in":      "has",
+	"not_in":  "not_has",
+	"has":     "in",
+	"not_has": "not_in",
+	"has_any": "has_any",
+}
+
+func flipOperator(op string, left interface{}) (string, bool) {
+	switch op {
+	case "has", "not_has", "has_any":
+		// only a slice can be the right side of in and has_any
+		if _, ok := left.(*SliceX); !ok {
+			return "", false
+		}
+	}
+	flipped, ok := flippedOperators[op]
+	return flipped, ok
+}
+
+func parseOperator(op string, left, right interface{}) (Expression, error) {
+	switch op {
+	default:
+		return nil, IncorrectValue("parseOperator", "operator", op)
+	case "=":
+		return expressionOf(parseEquals(left, right))
+	case "!=":
+		return expressionOf(parseNotEquals(left, right))
+	case ">":
+		return expressionOf(parseGreaterThan(left, right))
+	case ">=":
+		return expressionOf(parseGreaterThanEqual(left, right))
+	case "<":
+		return expressionOf(parseLessThan(left, right))
+	case "<=":
+		return expressionOf(parseLessThanEqual(left, right))
+	case "=~":
+		return expressionOf(parseMatchRegexp(left, right))
+	case "!~":
+		return expressionOf(parseNotMatchRegexp(left, right))
+	case "starts_with":
+		return expressionOf(parseStartsWith(left, right))
+	case "ends_with":
+		return expressionOf(parseEndsWith(left, right))
+	case "istarts_with":
+		return expressionOf(parseIStartsWith(left, right))
+	case "iends_with":
+		return expressionOf(parseIEndsWith(left, right))
+	case "=*":
+		return expressionOf(parseIEquals(left, right))
+	case "contains":
+		return expressionOf(parseContains(left, right))
+	case "not_contains":
+		return expressionOf(parseNotContains(left, right))
+	case "like":
+		return expressionOf(parseLike(left, right))
+	case "not_like":
+		return expressionOf(parseNotLike(left, right))
+	case "ilike":
+		return expressionOf(parseILike(left, right))
+	case "glob":
+		return expressionOf(parseGlob(left, right))
+	case "in":
+		return expressionOf(parseInSlice(left, right))
+	case "not_in":
+		return expressionOf(parseNotInSlice(left, right))
+	case "has":
+		return expressionOf(parseHas(left, right))
+	case "not_has":
+		return expressionOf(parseNotHas(left, right))
+	case "has_any":
+		return expressionOf(parseHasAny(left, right))
+	case "has_all":
+		return expressionOf(parseHasAll(left, right))
+	}
+}
+
+// expressionOf keeps the nil node of a failed parse from becoming a non-nil
+// Expression.
+func expressionOf(expr Expression, err error) (Expression, error) {
+	if err != nil {
+		return nil, err
+	}
+	return expr, nil
+}
+
+func parseCompare(left interface{}, op string, right interface{}) (*CompareX, error) {
+	l, ok := left.(Value)
+	if !ok {
+		return nil, IncorrectType("parseCompare", (*Value)(nil), left)
+	}
+	r, ok := right.(Value)
+	if !ok {
+		return nil, IncorrectType("parseCompare", (*Value)(nil), right)
+	}
+	return Compare(l, op, r), nil
+}
@@ -0,0 +1,104 @@
+package lep
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseComparison(t *testing.T) {
+	var (
+		a     = Param("a")
+		b     = Param("b")
+		age   = Param("age")
+		roles = Param("roles")
+	)
+
+	type testParseComparison struct {
+		query string
+		expr  Expression
+		str   string
+	}
+	var tests = []testParseComparison{
+		{query: `10 < age`, expr: GreaterThan(age, Integer(10)), str: `age>10`},
+		{query: `10 <= age`, expr: GreaterThanEqual(age, Integer(10)), str: `age>=10`},
+		{query: `10>age`, expr: LessThan(age, Integer(10)), str: `age<10`},
+		{query: `10 >= age`, expr: LessThanEqual(age, Integer(10)), str: `age<=10`},
+		{query: `null = a`, expr: Equals(a, Null()), str: `a=null`},
+		{query: `"x" != a`, expr: NotEquals(a, String("x")), str: `a!="x"`},
+		{query: `a = b`, expr: Equals(a, b), str: `a=b`},
+		{query: `"admin" in roles`, expr: Has(roles, String("admin")), str: `roles has "admin"`},
+		{query: `"admin" not_in roles`, expr: NotHas(roles, String("admin")), str: `roles not_has "admin"`},
+		{query: `a in roles`, expr: Has(roles, a), str: `roles has a`},
+		{query: `a not_in roles`, expr: NotHas(roles, a), str: `roles not_has a`},
+		{query: `roles has a`, expr: Has(roles, a), str: `roles has a`},
+		{query: `[1,2] has a`, expr: InSlice(a, Slice(Integer(1), Integer(2))), str: `a in [1,2]`},
+		{query: `[1,2] not_has a`, expr: NotInSlice(a, Slice(Integer(1), Integer(2))), str: `a not_in [1,2]`},
+		{query: `[1,2] has_any roles`, expr: HasAny(roles, Slice(Integer(1), Integer(2))), str: `roles has_any [1,2]`},
+		{
+			query: `[1,2] has_all roles`,
+			expr:  Compare(Slice(Integer(1), Integer(2)), "has_all", roles),
+			str:   `[1,2] has_all roles`,
+		},
+		{query: `a has_any b`, expr: Compare(a, "has_any", b), str: `a has_any b`},
+		{query: `a has_all b`, expr: Compare(a, "has_all", b), str: `a has_all b`},
+		{query: `1 = 1.0`, expr: Compare(Integer(1), "=", Float(1)), str: `1=1.0`},
+		{query: `"foobar" starts_with a`, expr: Compare(String("foobar"), "starts_with", a), str: `"foobar" starts_with a`},
+		{query: `"foo" ends_with "o"`, expr: Compare(String("foo"), "ends_with", String("o")), str: `"foo" ends_with "o"`},
+		{query: `"abc" =~ /b/`, expr: Compare(String("abc"), "=~", MustCompileRegexp("b", "")), str: `"abc" =~ /b/`},
+		{query: `"a" in ["a","b"]`, expr: Compare(String("a"), "in", Slice(String("a"), String("b"))), str: `"a" in ["a","b"]`},
+		{query: `nullable = 1 && trueish = false`, expr: And(Equals(Param("nullable"), Integer(1)), Equals(Param("trueish"), Boolean(false))), str: `nullable=1 && trueish=false`},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query)
+		if assert.NoError(t, err, tt.query) {
+			assert.True(t, tt.expr.Equals(expr), "%s: %s", tt.query, expr)
+			assert.Equal(t, tt.str, expr.String())
+
+			reparsed, err := ParseExpression(expr.String())
+			if assert.NoError(t, err, expr.String()) {
+				assert.True(t, expr.Equals(reparsed), expr.String())
+			}
+		}
+	}
+
+	for _, query := range []string{`a index`, `[1,2] = a`, `a =~ b`, `1 has_any 2`} {
+		_, err := ParseExpression(query)
+		assert.Error(t, err, query)
+	}
+}
+
+func TestCompare_Equals(t *testing.T) {
+	type testCompareEquals struct {
+		c1     Expression
+		c2     Expression
+		result bool
+	}
+	var tests = []testCompareEquals{
+		{
+			c1:     Compare(Integer(1), "=", Integer(1)),
+			c2:     Compare(Integer(1), "=", Integer(1)),
+			result: true,
+		},
+		{
+			c1:     Compare(Integer(1), "=", Integer(1)),
+			c2:     Compare(Integer(1), "!=", Integer(1)),
+			result: false,
+		},
+		{
+			c1:     Compare(Integer(1), "=", Integer(1)),
+			c2:     Compare(Integer(1), "=", Integer(2)),
+			result: false,
+		},
+		{
+			c1:     Compare(Param("a"), "=", Integer(1)),
+			c2:     Equals(Param("a"), Integer(1)),
+			result: false,
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.result, tt.c1.Equals(tt.c2))
+		assert.Equal(t, tt.result, tt.c2.Equals(tt.c1))
+	}
+}
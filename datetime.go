@@ -1,7 +1,10 @@
 package lep
 
 import (
+	"fmt"
 	"github.com/araddon/dateparse"
+	"regexp"
+	"strings"
 	"time"
 )
 
@@ -21,11 +24,17 @@ func DateTime(dt time.Time, format string) *DateTimeX {
 
 func (v DateTimeX) Equals(other Expression) bool {
 	if expr, ok := other.(*DateTimeX); ok {
-		return v.Val == expr.Val
+		return v.Val.Equal(expr.Val)
 	}
 	return false
 }
 
+// Compare returns -1, 0 or +1 as v is before, at the same instant as, or
+// after other.
+func (v DateTimeX) Compare(other *DateTimeX) int {
+	return compareTime(v.Val, other.Val, 0)
+}
+
 func (v DateTimeX) String() string {
 	return `dt:"` + v.Val.Format(v.Format) + `"`
 }
@@ -34,16 +43,66 @@ func (v DateTimeX) Value() interface{} {
 	return v.Val
 }
 
-func parseDateTime(val interface{}) (*DateTimeX, error) {
+func parseDateTime(val interface{}, opts *parseOptions) (*DateTimeX, error) {
 	strVal, ok := val.(*StringX)
 	if !ok {
 		return nil, IncorrectType("parseDateTime", (*StringX)(nil), val)
 	}
-	format, err := dateparse.ParseFormat(strVal.Val)
-	if err != nil {
-		return nil, err
+	if opts == nil {
+		opts = &parseOptions{}
+	}
+	loc := opts.dateTimeLocation
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	if len(opts.dateTimeLayouts) > 0 {
+		for _, layout := range opts.dateTimeLayouts {
+			if dt, err := time.ParseInLocation(layout, strVal.Val, loc); err == nil {
+				return DateTime(dt, layout), nil
+			}
+		}
+		return nil, InvalidDateTime(strVal.Val, fmt.Errorf("layout is not one of %q", opts.dateTimeLayouts))
 	}
 
-	dt, _ := time.Parse(format, strVal.Val)
+	format, err := dateTimeFormat(strVal.Val, opts.dateTimeDayFirst)
+	if err != nil {
+		return nil, InvalidDateTime(strVal.Val, err)
+	}
+	dt, err := time.ParseInLocation(format, strVal.Val, loc)
+	if err != nil {
+		return nil, InvalidDateTime(strVal.Val, err)
+	}
 	return DateTime(dt, format), nil
 }
+
+var numericDate = regexp.MustCompile(`^(\d{1,2})([./-])(\d{1,2})([./-])(\d{4}|\d{2})\b`)
+
+// dateTimeFormat returns the layout of a datetime literal. Dates like
+// 01/02/2020 are read month first unless dayFirst is set.
+func dateTimeFormat(literal string, dayFirst bool) (string, error) {
+	m := numericDate.FindStringSubmatch(literal)
+	if !dayFirst || m == nil {
+		format, err := dateparse.ParseFormat(literal)
+		return utcSuffix(format), err
+	}
+
+	// let dateparse read the date month first, then swap day and month back
+	swapped := m[3] + m[2] + m[1] + m[4] + m[5] + literal[len(m[0]):]
+	format, err := dateparse.ParseFormat(swapped)
+	if err != nil {
+		return "", err
+	}
+	month := format[:len(m[3])]
+	day := format[len(m[3])+1 : len(m[3])+1+len(m[1])]
+	return utcSuffix(day + m[2] + month + format[len(m[3])+1+len(m[1]):]), nil
+}
+
+// utcSuffix makes the trailing Z of ISO 8601 layouts a time zone rather than
+// a literal character.
+func utcSuffix(format string) string {
+	if strings.HasSuffix(format, "Z") {
+		return strings.TrimSuffix(format, "Z") + "Z07:00"
+	}
+	return format
+}
@@ -24,7 +24,7 @@ func TestParseDateTime(t *testing.T) {
 		},
 		{
 			string: String("20.10.2020"),
-			err:    errors.New(`parsing time "20.10.2020": month out of range`),
+			err:    InvalidDateTime("20.10.2020", errors.New(`parsing time "20.10.2020": month out of range`)),
 		},
 		{
 			string: Integer(1),
@@ -33,7 +33,7 @@ func TestParseDateTime(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		d, err := parseDateTime(tt.string)
+		d, err := parseDateTime(tt.string, nil)
 		if tt.err == nil && assert.NoError(t, err) {
 			assert.IsType(t, (*DateTimeX)(nil), d)
 			assert.Equal(t, tt.result, d.Val)
@@ -45,6 +45,96 @@ func TestParseDateTime(t *testing.T) {
 	}
 }
 
+func TestParseDateTime_Options(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	type testParseDateTimeOptions struct {
+		query  string
+		opts   []Option
+		result time.Time
+		format string
+		err    string
+	}
+	var tests = []testParseDateTimeOptions{
+		{
+			query:  `a=dt:"01/02/2020"`,
+			result: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+			format: "01/02/2006",
+		},
+		{
+			query:  `a=dt:"01/02/2020"`,
+			opts:   []Option{DateTimeDayFirst(true)},
+			result: time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+			format: "02/01/2006",
+		},
+		{
+			query:  `a=dt:"20.10.2020"`,
+			opts:   []Option{DateTimeDayFirst(true)},
+			result: time.Date(2020, 10, 20, 0, 0, 0, 0, time.UTC),
+			format: "02.01.2006",
+		},
+		{
+			query:  `a=dt:"2020-10-20 10:20:30"`,
+			opts:   []Option{DateTimeLocation(berlin)},
+			result: time.Date(2020, 10, 20, 10, 20, 30, 0, berlin),
+			format: "2006-01-02 15:04:05",
+		},
+		{
+			query:  `a=dt:"2020-10-20T10:20:30Z"`,
+			opts:   []Option{DateTimeLocation(berlin)},
+			result: time.Date(2020, 10, 20, 10, 20, 30, 0, time.UTC),
+			format: time.RFC3339,
+		},
+		{
+			query:  `a=dt:"20/10/2020 10:20"`,
+			opts:   []Option{DateTimeDayFirst(true)},
+			result: time.Date(2020, 10, 20, 10, 20, 0, 0, time.UTC),
+			format: "02/01/2006 15:04",
+		},
+		{
+			query:  `a=dt:"5.3.21"`,
+			opts:   []Option{DateTimeDayFirst(true)},
+			result: time.Date(2021, 3, 5, 0, 0, 0, 0, time.UTC),
+			format: "2.1.06",
+		},
+		{
+			query:  `a=dt:"2020-10-20T10:20:30+02:00"`,
+			opts:   []Option{DateTimeLayouts(time.RFC3339)},
+			result: time.Date(2020, 10, 20, 8, 20, 30, 0, time.UTC),
+			format: time.RFC3339,
+		},
+		{
+			query: `a=dt:"2020-10-20"`,
+			opts:  []Option{DateTimeLayouts(time.RFC3339, time.RFC1123)},
+			err:   `invalid datetime "2020-10-20": layout is not one of ["2006-01-02T15:04:05Z07:00" "Mon, 02 Jan 2006 15:04:05 MST"]`,
+		},
+		{
+			query: `a=dt:"2020-13-45"`,
+			err:   `invalid datetime "2020-13-45": parsing time "2020-13-45": month out of range`,
+		},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query, tt.opts...)
+		if tt.err == "" && assert.NoError(t, err, tt.query) {
+			dt := expr.(*EqualsX).Value.(*DateTimeX)
+			assert.True(t, tt.result.Equal(dt.Val), "%s: %s", tt.query, dt.Val)
+			assert.Equal(t, tt.format, dt.Format)
+		} else if tt.err != "" && assert.Error(t, err, tt.query) {
+			assert.Contains(t, err.Error(), tt.err)
+		}
+	}
+
+	_, err = parseDateTime(String("2020-13-45"), nil)
+	var target ErrInvalidDateTime
+	if assert.ErrorAs(t, err, &target) {
+		assert.Equal(t, "2020-13-45", target.Literal)
+	}
+}
+
 func TestDateTime_Equals(t *testing.T) {
 	var (
 		date1 = time.Date(2020, 10, 20, 10, 20, 30, 0, time.UTC)
@@ -87,6 +177,11 @@ func TestDateTime_Equals(t *testing.T) {
 			d2:     DateTime(date2, "2006-01-02 15:04:05"),
 			result: false,
 		},
+		{
+			d1:     DateTime(date1, time.RFC3339),
+			d2:     DateTime(date1.In(time.FixedZone("UTC+2", 2*60*60)), time.RFC3339),
+			result: true,
+		},
 		{
 			d1:     DateTime(date1, "2006-01-02"),
 			d2:     String(date1.String()),
@@ -98,4 +193,20 @@ func TestDateTime_Equals(t *testing.T) {
 		assert.Equal(t, tt.result, tt.d1.Equals(tt.d2))
 		assert.Equal(t, tt.result, tt.d2.Equals(tt.d1))
 	}
+
+	// the monotonic clock reading must not matter either
+	now := time.Now()
+	assert.True(t, DateTime(now, time.RFC3339Nano).Equals(DateTime(now.Round(0), time.RFC3339Nano)))
+}
+
+func TestDateTime_Compare(t *testing.T) {
+	var (
+		date1 = DateTime(time.Date(2020, 10, 20, 10, 20, 30, 0, time.UTC), time.RFC3339)
+		date2 = DateTime(time.Date(2020, 10, 20, 12, 20, 30, 0, time.FixedZone("UTC+2", 2*60*60)), time.RFC3339)
+		date3 = DateTime(time.Date(2020, 10, 20, 10, 20, 30, 1, time.UTC), time.RFC3339Nano)
+	)
+
+	assert.Equal(t, 0, date1.Compare(date2))
+	assert.Equal(t, -1, date1.Compare(date3))
+	assert.Equal(t, 1, date3.Compare(date2))
 }
@@ -0,0 +1,99 @@
+package lep
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// DecimalX is an exact number, read from numeric literals when the parser is
+// given DecimalNumbers. Raw keeps the text of the literal.
+type DecimalX struct {
+	Val *big.Rat
+	Raw string
+}
+
+var _ Value = (*DecimalX)(nil)
+
+// Decimal returns a decimal without literal text. Values without a finite
+// decimal expansion, like 1/3, are printed rounded to 20 decimal places.
+func Decimal(val *big.Rat) *DecimalX {
+	return &DecimalX{Val: val}
+}
+
+func (d DecimalX) Equals(other Expression) bool {
+	if expr, ok := other.(*DecimalX); ok {
+		return d.Val.Cmp(expr.Val) == 0
+	}
+	return false
+}
+
+func (d DecimalX) String() string {
+	if d.Raw != "" {
+		return d.Raw
+	}
+	return d.Canonical()
+}
+
+// Canonical returns the shortest decimal form of the value, e.g. 0.1 for a
+// literal written as 0.100.
+func (d DecimalX) Canonical() string {
+	if d.Val.IsInt() {
+		return d.Val.Num().String()
+	}
+	return d.Val.FloatString(decimalPlaces(d.Val.Denom()))
+}
+
+func (d DecimalX) Value() interface{} {
+	return d.Val
+}
+
+// decimalPlaces returns the number of decimal places needed to write a
+// fraction with the denominator exactly.
+func decimalPlaces(denom *big.Int) int {
+	var twos, fives int
+	n := new(big.Int).Set(denom)
+	two, five := big.NewInt(2), big.NewInt(5)
+	mod := new(big.Int)
+	for n.Cmp(big.NewInt(1)) > 0 {
+		switch {
+		case mod.Mod(n, two).Sign() == 0:
+			n.Quo(n, two)
+			twos++
+		case mod.Mod(n, five).Sign() == 0:
+			n.Quo(n, five)
+			fives++
+		default:
+			return 20
+		}
+	}
+	if twos > fives {
+		return twos
+	}
+	return fives
+}
+
+// maxDecimalExponent bounds the exponent of decimal literals, which would
+// otherwise let 1e100000000 allocate a number with as many digits.
+const maxDecimalExponent = 1000
+
+func parseDecimal(b []byte) (*DecimalX, error) {
+	raw := strings.TrimSpace(string(b))
+	digits, base := numberDigits(raw)
+	if i := strings.IndexAny(digits, "eE"); base == 10 && i >= 0 {
+		exp, err := strconv.Atoi(digits[i+1:])
+		if err != nil || exp > maxDecimalExponent || exp < -maxDecimalExponent {
+			return nil, OutOfRange(raw, "decimal")
+		}
+	}
+	var val *big.Rat
+	if base == 10 {
+		val, _ = new(big.Rat).SetString(digits)
+	} else if i, ok := new(big.Int).SetString(digits, base); ok {
+		val = new(big.Rat).SetInt(i)
+	}
+	if val == nil {
+		return nil, IncorrectValue("parseDecimal", "decimal", raw)
+	}
+	return &DecimalX{Val: val, Raw: raw}, nil
+}
@@ -0,0 +1,108 @@
+package lep
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	type testParseDecimal struct {
+		query     string
+		value     *big.Rat
+		str       string
+		canonical string
+	}
+	var tests = []testParseDecimal{
+		{query: `a=0.1`, value: big.NewRat(1, 10), str: `a=0.1`, canonical: "0.1"},
+		{query: `a=-12.500`, value: big.NewRat(-25, 2), str: `a=-12.500`, canonical: "-12.5"},
+		{query: `a=10.0`, value: big.NewRat(10, 1), str: `a=10.0`, canonical: "10"},
+		{query: `a=007`, value: big.NewRat(7, 1), str: `a=007`, canonical: "7"},
+		{query: `a=1.5e3`, value: big.NewRat(1500, 1), str: `a=1.5e3`, canonical: "1500"},
+		{query: `a=-0xFF`, value: big.NewRat(-255, 1), str: `a=-0xFF`, canonical: "-255"},
+		{query: `a=+.25`, value: big.NewRat(1, 4), str: `a=+.25`, canonical: "0.25"},
+		{query: `a=1_000.000_1`, value: big.NewRat(10000001, 10000), str: `a=1_000.000_1`, canonical: "1000.0001"},
+		{
+			query:     `a=123456789012345678901234567890`,
+			value:     ratOf("123456789012345678901234567890"),
+			str:       `a=123456789012345678901234567890`,
+			canonical: "123456789012345678901234567890",
+		},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query, DecimalNumbers())
+		if assert.NoError(t, err, tt.query) {
+			d, ok := expr.(*EqualsX).Value.(*DecimalX)
+			if assert.True(t, ok, tt.query) {
+				assert.Equal(t, 0, tt.value.Cmp(d.Val), tt.query)
+				assert.Equal(t, tt.str, expr.String())
+				assert.Equal(t, tt.canonical, d.Canonical())
+			}
+		}
+	}
+
+	_, err := ParseExpression(`a=1e1001`, DecimalNumbers())
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), OutOfRange("1e1001", "decimal").Error())
+	}
+
+	expr, err := ParseExpression(`a=0.1 && b in [1,2.5] && c>250ms`)
+	if assert.NoError(t, err) {
+		assert.IsType(t, (*FloatX)(nil), expr.(*AndX).Conjuncts[0].(*EqualsX).Value)
+	}
+	expr, err = ParseExpression(`a in [1,2.5] && c>250ms`, DecimalNumbers())
+	if assert.NoError(t, err) {
+		slice := expr.(*AndX).Conjuncts[0].(*InSliceX).Slice
+		assert.IsType(t, (*DecimalX)(nil), slice.Values[0])
+		assert.IsType(t, (*DurationX)(nil), expr.(*AndX).Conjuncts[1].(*GreaterThanX).Value)
+	}
+}
+
+func TestDecimal_String(t *testing.T) {
+	assert.Equal(t, "0.125", Decimal(big.NewRat(1, 8)).String())
+	assert.Equal(t, "-3", Decimal(big.NewRat(-3, 1)).String())
+	assert.Equal(t, "0.33333333333333333333", Decimal(big.NewRat(1, 3)).String())
+	assert.True(t, Decimal(big.NewRat(1, 2)).Equals(&DecimalX{Val: big.NewRat(5, 10), Raw: "0.50"}))
+	assert.False(t, Decimal(big.NewRat(1, 2)).Equals(Float(0.5)))
+}
+
+func TestDecimal_Evaluate(t *testing.T) {
+	var data map[string]interface{}
+	d := json.NewDecoder(strings.NewReader(`{"amount": 0.1, "id": 123456789012345678901, "count": 3, "price": 0.30000000000000004}`))
+	d.UseNumber()
+	if !assert.NoError(t, d.Decode(&data)) {
+		return
+	}
+	data["rate"] = 0.1
+	data["big"], _ = new(big.Int).SetString("123456789012345678901", 10)
+
+	type testDecimalEvaluate struct {
+		query  string
+		result bool
+	}
+	var tests = []testDecimalEvaluate{
+		{query: `amount=0.1 && amount<0.10000000000000001`, result: true},
+		{query: `id=123456789012345678901 && id!=123456789012345678900`, result: true},
+		{query: `id>123456789012345678900 && big=123456789012345678901`, result: true},
+		{query: `count=3.0 && count<3.5 && rate=0.1`, result: true},
+		{query: `price=0.3 || price<0.30000000000000004`, result: false},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query, DecimalNumbers())
+		if assert.NoError(t, err, tt.query) {
+			result, err := Evaluate(expr, data)
+			if assert.NoError(t, err, tt.query) {
+				assert.Equal(t, tt.result, result, tt.query)
+			}
+		}
+	}
+}
+
+func ratOf(s string) *big.Rat {
+	r, _ := new(big.Rat).SetString(s)
+	return r
+}
@@ -0,0 +1,114 @@
+package lep
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	day  = 24 * time.Hour
+	week = 7 * day
+)
+
+var durationUnits = []struct {
+	suffix string
+	unit   time.Duration
+}{
+	{"w", week},
+	{"d", day},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+	{"ms", time.Millisecond},
+	{"us", time.Microsecond},
+	{"ns", time.Nanosecond},
+}
+
+type DurationX struct {
+	Val time.Duration
+}
+
+var _ Value = (*DurationX)(nil)
+
+func Duration(val time.Duration) *DurationX {
+	return &DurationX{Val: val}
+}
+
+func (d DurationX) Equals(other Expression) bool {
+	if expr, ok := other.(*DurationX); ok {
+		return d.Val == expr.Val
+	}
+	return false
+}
+
+// String splits the duration into units from weeks down to nanoseconds, so
+// 90m is printed as 1h30m.
+func (d DurationX) String() string {
+	if d.Val == 0 {
+		return "0s"
+	}
+	var b strings.Builder
+	// work with the magnitude as uint64 so math.MinInt64 does not overflow
+	val := uint64(d.Val)
+	if d.Val < 0 {
+		b.WriteByte('-')
+		val = -val
+	}
+	for _, u := range durationUnits {
+		if n := val / uint64(u.unit); n > 0 {
+			b.WriteString(strconv.FormatUint(n, 10) + u.suffix)
+			val -= n * uint64(u.unit)
+		}
+	}
+	return b.String()
+}
+
+func (d DurationX) Value() interface{} {
+	return d.Val
+}
+
+func parseDuration(b []byte) (*DurationX, error) {
+	literal := strings.TrimSpace(string(b))
+	text := strings.TrimPrefix(literal, "-")
+	if text == "" {
+		return nil, IncorrectValue("parseDuration", "duration", literal)
+	}
+
+	var total uint64
+	for text != "" {
+		end := strings.IndexFunc(text, func(r rune) bool { return r < '0' || r > '9' })
+		if end <= 0 {
+			return nil, IncorrectValue("parseDuration", "duration", literal)
+		}
+		n, err := strconv.ParseUint(text[:end], 10, 64)
+		if err != nil {
+			return nil, &strconv.NumError{Func: "parseDuration", Num: literal, Err: strconv.ErrRange}
+		}
+		text = text[end:]
+
+		// the longest suffix wins, so 1ms is not read as 1m followed by s
+		var unit time.Duration
+		var suffix string
+		for _, u := range durationUnits {
+			if strings.HasPrefix(text, u.suffix) && len(u.suffix) > len(suffix) {
+				unit, suffix = u.unit, u.suffix
+			}
+		}
+		if unit == 0 {
+			return nil, IncorrectValue("parseDuration", "duration", literal)
+		}
+		text = text[len(suffix):]
+
+		if n > math.MaxInt64/uint64(unit) || total+n*uint64(unit) > math.MaxInt64 {
+			return nil, &strconv.NumError{Func: "parseDuration", Num: literal, Err: strconv.ErrRange}
+		}
+		total += n * uint64(unit)
+	}
+
+	if strings.HasPrefix(literal, "-") {
+		return Duration(-time.Duration(total)), nil
+	}
+	return Duration(time.Duration(total)), nil
+}
@@ -0,0 +1,75 @@
+package lep
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	type testParseDuration struct {
+		raw    []byte
+		result time.Duration
+		str    string
+		err    bool
+	}
+	var tests = []testParseDuration{
+		{raw: []byte("250ms"), result: 250 * time.Millisecond, str: "250ms"},
+		{raw: []byte("1h30m"), result: 90 * time.Minute, str: "1h30m"},
+		{raw: []byte("90m"), result: 90 * time.Minute, str: "1h30m"},
+		{raw: []byte("7d"), result: 7 * 24 * time.Hour, str: "1w"},
+		{raw: []byte("1m1ms1us1ns"), result: time.Minute + time.Millisecond + time.Microsecond + time.Nanosecond, str: "1m1ms1us1ns"},
+		{raw: []byte("-1500ms"), result: -1500 * time.Millisecond, str: "-1s500ms"},
+		{raw: []byte("0s"), result: 0, str: "0s"},
+		{raw: []byte("9223372036854775807ns"), result: math.MaxInt64, str: "15250w1d23h47m16s854ms775us807ns"},
+		{raw: []byte("9223372036854775808ns"), err: true},
+		{raw: []byte("20000000w"), err: true},
+		{raw: []byte("15250w2d"), err: true},
+		{raw: []byte("1y"), err: true},
+		{raw: []byte("h"), err: true},
+	}
+
+	for _, tt := range tests {
+		d, err := parseDuration(tt.raw)
+		if tt.err {
+			assert.Error(t, err, string(tt.raw))
+		} else if assert.NoError(t, err, string(tt.raw)) {
+			assert.Equal(t, tt.result, d.Val)
+			assert.Equal(t, tt.result, d.Value())
+			assert.Equal(t, tt.str, d.String())
+		}
+	}
+	assert.Equal(t, "-15250w1d23h47m16s854ms775us808ns", Duration(math.MinInt64).String())
+}
+
+func TestDuration_Evaluate(t *testing.T) {
+	data := map[string]interface{}{
+		"latency": 300 * time.Millisecond,
+		"ttl_ns":  int64(2 * time.Hour),
+		"timeout": "1m30s",
+	}
+
+	type testDurationEvaluate struct {
+		query  string
+		result bool
+	}
+	var tests = []testDurationEvaluate{
+		{query: `latency>250ms && latency<=300ms`, result: true},
+		{query: `latency=300ms && latency!=1s`, result: true},
+		{query: `ttl_ns>=1h30m && ttl_ns<1d`, result: true},
+		{query: `ttl_ns=2h && timeout=90s`, result: true},
+		{query: `latency in [100ms,300ms] && timeout>1m`, result: true},
+		{query: `latency>1s || ttl_ns<-1h || missing>0s`, result: false},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query)
+		if assert.NoError(t, err, tt.query) {
+			result, err := Evaluate(expr, data)
+			if assert.NoError(t, err, tt.query) {
+				assert.Equal(t, tt.result, result, tt.query)
+			}
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package lep
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type ErrIncorrectType struct {
 	FuncName string
@@ -37,3 +40,328 @@ func IncorrectValue(funcName string, expected, received interface{}) error {
 func (e ErrIncorrectValue) Error() string {
 	return fmt.Sprintf("%s: incorrect value; expected: %T; received: %T", e.FuncName, e.Expected, e.Received)
 }
+
+type ErrSyntax struct {
+	Line     int
+	Col      int
+	Offset   int
+	Message  string
+	Expected []string
+}
+
+func (e ErrSyntax) Error() string {
+	return fmt.Sprintf("%d:%d (%d): %s", e.Line, e.Col, e.Offset, e.Message)
+}
+
+func SyntaxErrors(err error) []ErrSyntax {
+	var list errList
+	switch e := err.(type) {
+	default:
+		return nil
+	case errList:
+		list = e
+	case *parserError:
+		list = errList{e}
+	}
+
+	var result []ErrSyntax
+	for _, item := range list {
+		if pe, ok := item.(*parserError); ok {
+			result = append(result, ErrSyntax{
+				Line:     pe.pos.line,
+				Col:      pe.pos.col,
+				Offset:   pe.pos.offset,
+				Message:  pe.Inner.Error(),
+				Expected: pe.expected,
+			})
+		}
+	}
+	return result
+}
+
+type ErrUnknownParam struct {
+	Param string
+}
+
+func UnknownParam(param string) error {
+	return ErrUnknownParam{Param: param}
+}
+
+func (e ErrUnknownParam) Error() string {
+	return fmt.Sprintf("unknown param: %s", e.Param)
+}
+
+type ErrMissingParam struct {
+	Param string
+}
+
+func MissingParam(param string) error {
+	return ErrMissingParam{Param: param}
+}
+
+func (e ErrMissingParam) Error() string {
+	return fmt.Sprintf("missing param: %s", e.Param)
+}
+
+type ErrTypeMismatch struct {
+	Param    string
+	Expected Type
+	Received Type
+}
+
+func TypeMismatch(param string, expected, received Type) error {
+	return ErrTypeMismatch{
+		Param:    param,
+		Expected: expected,
+		Received: received,
+	}
+}
+
+func (e ErrTypeMismatch) Error() string {
+	return fmt.Sprintf("%s: type mismatch; expected: %s; received: %s", e.Param, e.Expected, e.Received)
+}
+
+type ErrUnsupportedExpression struct {
+	FuncName   string
+	Expression Expression
+}
+
+func UnsupportedExpression(funcName string, expr Expression) error {
+	return ErrUnsupportedExpression{
+		FuncName:   funcName,
+		Expression: expr,
+	}
+}
+
+func (e ErrUnsupportedExpression) Error() string {
+	return fmt.Sprintf("%s: unsupported expression: %T", e.FuncName, e.Expression)
+}
+
+type ErrUnsupportedFlag struct {
+	Regexp string
+	Flag   string
+}
+
+func UnsupportedFlag(regexp, flag string) error {
+	return ErrUnsupportedFlag{
+		Regexp: regexp,
+		Flag:   flag,
+	}
+}
+
+func (e ErrUnsupportedFlag) Error() string {
+	return fmt.Sprintf("%s: unsupported regexp flag %q; supported flags: i, m, s, U", e.Regexp, e.Flag)
+}
+
+type ErrRegexpLimit struct {
+	Regexp string
+	Limit  string
+	Value  int
+	Max    int
+}
+
+func RegexpLimit(regexp, limit string, value, max int) error {
+	return ErrRegexpLimit{
+		Regexp: regexp,
+		Limit:  limit,
+		Value:  value,
+		Max:    max,
+	}
+}
+
+func (e ErrRegexpLimit) Error() string {
+	return fmt.Sprintf("%s: regexp %s %d exceeds the limit of %d", e.Regexp, e.Limit, e.Value, e.Max)
+}
+
+type ErrInvalidDateTime struct {
+	Literal string
+	Err     error
+}
+
+func InvalidDateTime(literal string, err error) error {
+	return ErrInvalidDateTime{
+		Literal: literal,
+		Err:     err,
+	}
+}
+
+func (e ErrInvalidDateTime) Error() string {
+	return fmt.Sprintf("invalid datetime %q: %v", e.Literal, e.Err)
+}
+
+func (e ErrInvalidDateTime) Unwrap() error {
+	return e.Err
+}
+
+type ErrInvalidUnit struct {
+	FuncName string
+	Unit     string
+	Expected []string
+}
+
+func InvalidUnit(funcName, unit string, expected []string) error {
+	return ErrInvalidUnit{
+		FuncName: funcName,
+		Unit:     unit,
+		Expected: expected,
+	}
+}
+
+func (e ErrInvalidUnit) Error() string {
+	return fmt.Sprintf("%s: unknown unit %q; expected one of: %s", e.FuncName, e.Unit, strings.Join(e.Expected, ", "))
+}
+
+type ErrOutOfRange struct {
+	Literal string
+	Type    string
+}
+
+func OutOfRange(literal, typ string) error {
+	return ErrOutOfRange{
+		Literal: literal,
+		Type:    typ,
+	}
+}
+
+func (e ErrOutOfRange) Error() string {
+	return fmt.Sprintf("number %s is out of the range of %s", e.Literal, e.Type)
+}
+
+type ErrInvalidOperand struct {
+	Operator string
+	Operand  string
+}
+
+func InvalidOperand(operator, operand string) error {
+	return ErrInvalidOperand{
+		Operator: operator,
+		Operand:  operand,
+	}
+}
+
+func (e ErrInvalidOperand) Error() string {
+	return fmt.Sprintf("invalid operand %s of %s", e.Operand, e.Operator)
+}
+
+type ErrUnknownFunction struct {
+	Name string
+}
+
+func UnknownFunction(name string) error {
+	return ErrUnknownFunction{Name: name}
+}
+
+func (e ErrUnknownFunction) Error() string {
+	return fmt.Sprintf("unknown function: %s", e.Name)
+}
+
+type ErrArgumentCount struct {
+	Function string
+	Expected int
+	Variadic bool
+	Received int
+}
+
+func ArgumentCount(function string, expected int, variadic bool, received int) error {
+	return ErrArgumentCount{
+		Function: function,
+		Expected: expected,
+		Variadic: variadic,
+		Received: received,
+	}
+}
+
+func (e ErrArgumentCount) Error() string {
+	expected := fmt.Sprint(e.Expected)
+	if e.Variadic {
+		expected = "at least " + expected
+	}
+	return fmt.Sprintf("%s: wrong number of arguments; expected: %s; received: %d", e.Function, expected, e.Received)
+}
+
+type ErrInvalidArgument struct {
+	Function string
+	Index    int
+	Expected Type
+	Received Type
+}
+
+func InvalidArgument(function string, index int, expected, received Type) error {
+	return ErrInvalidArgument{
+		Function: function,
+		Index:    index,
+		Expected: expected,
+		Received: received,
+	}
+}
+
+func (e ErrInvalidArgument) Error() string {
+	return fmt.Sprintf("%s: argument %d: type mismatch; expected: %s; received: %s", e.Function, e.Index, e.Expected, e.Received)
+}
+
+type ErrInvalidPattern struct {
+	Pattern string
+	Reason  string
+}
+
+func InvalidPattern(pattern, reason string) error {
+	return ErrInvalidPattern{
+		Pattern: pattern,
+		Reason:  reason,
+	}
+}
+
+func (e ErrInvalidPattern) Error() string {
+	return fmt.Sprintf("invalid pattern %q: %s", e.Pattern, e.Reason)
+}
+
+type ErrUnboundPlaceholder struct {
+	Placeholder string
+}
+
+func UnboundPlaceholder(placeholder string) error {
+	return ErrUnboundPlaceholder{Placeholder: placeholder}
+}
+
+func (e ErrUnboundPlaceholder) Error() string {
+	return fmt.Sprintf("unbound placeholder: %s", e.Placeholder)
+}
+
+type ErrBindMismatch struct {
+	Unbound []string
+	Extra   []string
+}
+
+func BindMismatch(unbound, extra []string) error {
+	return ErrBindMismatch{
+		Unbound: unbound,
+		Extra:   extra,
+	}
+}
+
+func (e ErrBindMismatch) Error() string {
+	var parts []string
+	if len(e.Unbound) > 0 {
+		parts = append(parts, "unbound placeholders: "+strings.Join(e.Unbound, ", "))
+	}
+	if len(e.Extra) > 0 {
+		parts = append(parts, "extra values: "+strings.Join(e.Extra, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+type ErrInvalidBinding struct {
+	Placeholder string
+	Value       string
+}
+
+func InvalidBinding(placeholder, value string) error {
+	return ErrInvalidBinding{
+		Placeholder: placeholder,
+		Value:       value,
+	}
+}
+
+func (e ErrInvalidBinding) Error() string {
+	return fmt.Sprintf("placeholder %s: invalid value %s", e.Placeholder, e.Value)
+}
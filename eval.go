@@ -0,0 +1,891 @@
+package lep
+
+import (
+	"encoding/json"
+	"github.com/araddon/dateparse"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+)
+
+type EvalOption func(*Evaluator)
+
+type Evaluator struct {
+	now           func() time.Time
+	timePrecision time.Duration
+	nfkc          bool
+	nulls         Nulls
+}
+
+func NewEvaluator(opts ...EvalOption) *Evaluator {
+	e := &Evaluator{now: time.Now}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// EvalClock sets the clock that now(), today() and startOf() are resolved
+// against. The default is time.Now.
+func EvalClock(now func() time.Time) EvalOption {
+	return func(e *Evaluator) {
+		e.now = now
+	}
+}
+
+// EvalTimePrecision compares datetimes truncated to a multiple of precision,
+// e.g. time.Second for databases that store whole seconds. Truncation is
+// relative to the zero time, as with time.Time.Truncate.
+func EvalTimePrecision(precision time.Duration) EvalOption {
+	return func(e *Evaluator) {
+		e.timePrecision = precision
+	}
+}
+
+// EvalNFKC normalizes both sides of the string operators to NFKC before
+// they are compared, so "ﬁ" matches "fi" and full-width "Ａ" matches "A".
+// Plain = and != still compare strings exactly.
+func EvalNFKC() EvalOption {
+	return func(e *Evaluator) {
+		e.nfkc = true
+	}
+}
+
+// Evaluate reports whether data matches the expression. Params are looked up
+// in data by name; dotted names descend into nested maps.
+func Evaluate(expr Expression, data map[string]interface{}, opts ...EvalOption) (bool, error) {
+	return NewEvaluator(opts...).Evaluate(expr, data)
+}
+
+func (e *Evaluator) Evaluate(expr Expression, data map[string]interface{}) (bool, error) {
+	truth, err := e.Truth(expr, data)
+	return truth == True, err
+}
+
+// Truth evaluates the expression in three-valued logic; the result is
+// Unknown only with ThreeValuedNulls, and Evaluate reports it as false.
+func (e *Evaluator) Truth(expr Expression, data map[string]interface{}) (Truth, error) {
+	switch x := expr.(type) {
+	default:
+		return False, UnsupportedExpression("Evaluate", expr)
+	case *AndX:
+		result := True
+		for _, conjunct := range x.Conjuncts {
+			truth, err := e.Truth(conjunct, data)
+			if err != nil || truth == False {
+				return False, err
+			}
+			result = result.And(truth)
+		}
+		return result, nil
+	case *OrX:
+		result := False
+		for _, disjunction := range x.Disjunctions {
+			truth, err := e.Truth(disjunction, data)
+			if err != nil || truth == True {
+				return truth, err
+			}
+			result = result.Or(truth)
+		}
+		return result, nil
+	case Statement:
+		return e.evalStatement(x, data)
+	case *CompareX:
+		return e.evalOperator(x, x.Operator, x.Left, x.Right, data)
+	case *BetweenX:
+		return e.evalBetween(x.Param, x.From, x.To, x.ExcludeFrom, x.ExcludeTo, data)
+	case *NotBetweenX:
+		truth, err := e.evalBetween(x.Param, x.From, x.To, x.ExcludeFrom, x.ExcludeTo, data)
+		if err != nil {
+			return False, err
+		}
+		return truth.Not(), nil
+	case *ExistsX:
+		_, ok := lookup(data, x.Param.Segments())
+		return truthOf(ok), nil
+	case *IsNullX:
+		return truthOf(paramValue(x.Param, data) == nil), nil
+	case *IsNotNullX:
+		return truthOf(paramValue(x.Param, data) != nil), nil
+	case *IsEmptyX:
+		empty, ok := isEmpty(paramValue(x.Param, data))
+		return truthOf(ok && empty), nil
+	case *IsNotEmptyX:
+		empty, ok := isEmpty(paramValue(x.Param, data))
+		return truthOf(ok && !empty), nil
+	case *AnyX:
+		return e.evalQuantifier(x.Param, x.Expr, false, data)
+	case *AllX:
+		return e.evalQuantifier(x.Param, x.Expr, true, data)
+	}
+}
+
+type Trace struct {
+	Expression Expression
+	Result     bool
+	// Unknown is set for clauses which are unknown with ThreeValuedNulls;
+	// their Result is false.
+	Unknown  bool
+	Children []*Trace
+}
+
+// Trace evaluates every clause of the expression, without short-circuiting,
+// and returns the result of each one.
+func (e *Evaluator) Trace(expr Expression, data map[string]interface{}) (*Trace, error) {
+	trace, _, err := e.trace(expr, data)
+	return trace, err
+}
+
+func (e *Evaluator) trace(expr Expression, data map[string]interface{}) (*Trace, Truth, error) {
+	trace := &Trace{Expression: expr}
+	var truth Truth
+	switch x := expr.(type) {
+	case *AndX:
+		truth = True
+		for _, conjunct := range x.Conjuncts {
+			child, childTruth, err := e.trace(conjunct, data)
+			if err != nil {
+				return nil, False, err
+			}
+			truth = truth.And(childTruth)
+			trace.Children = append(trace.Children, child)
+		}
+	case *OrX:
+		for _, disjunction := range x.Disjunctions {
+			child, childTruth, err := e.trace(disjunction, data)
+			if err != nil {
+				return nil, False, err
+			}
+			truth = truth.Or(childTruth)
+			trace.Children = append(trace.Children, child)
+		}
+	default:
+		var err error
+		if truth, err = e.Truth(expr, data); err != nil {
+			return nil, False, err
+		}
+	}
+	trace.Result = truth == True
+	trace.Unknown = truth == Unknown
+	return trace, truth, nil
+}
+
+func (e *Evaluator) evalStatement(st Statement, data map[string]interface{}) (Truth, error) {
+	expr := st.(Expression)
+	return e.evalOperator(expr, operatorOf(expr), st.GetParam(), st.GetValue(), data)
+}
+
+// evalBetween compares the param with both bounds, like the comparisons of
+// ToComparisons, but resolves it once.
+func (e *Evaluator) evalBetween(param *ParamX, from, to Value, excludeFrom, excludeTo bool, data map[string]interface{}) (Truth, error) {
+	values, err := e.resolveAll([]Value{param, from, to}, data)
+	if err != nil {
+		return False, err
+	}
+	lowerOp, upperOp := ">=", "<="
+	if excludeFrom {
+		lowerOp = ">"
+	}
+	if excludeTo {
+		upperOp = "<"
+	}
+	lower, _ := e.truth(lowerOp, values[0], values[1])
+	upper, _ := e.truth(upperOp, values[0], values[2])
+	return lower.And(upper), nil
+}
+
+func (e *Evaluator) evalOperator(expr Expression, op string, left, right Value, data map[string]interface{}) (Truth, error) {
+	l, err := e.resolve(left, data)
+	if err != nil {
+		return False, err
+	}
+	r, err := e.resolve(right, data)
+	if err != nil {
+		return False, err
+	}
+	var (
+		truth Truth
+		ok    bool
+	)
+	if isNullTest(op, left, right) {
+		var result bool
+		result, ok = e.apply(op, l, r)
+		truth = truthOf(result)
+	} else {
+		truth, ok = e.truth(op, l, r)
+	}
+	if !ok {
+		return False, UnsupportedExpression("Evaluate", expr)
+	}
+	return truth, nil
+}
+
+// truth applies the operator to resolved operands, which is unknown with
+// null operands and ThreeValuedNulls.
+func (e *Evaluator) truth(op string, left, right interface{}) (Truth, bool) {
+	if e.nulls == ThreeValuedNulls && e.unknown(op, left, right) {
+		// unknown operators are still reported
+		_, ok := e.apply(op, left, right)
+		return Unknown, ok
+	}
+	result, ok := e.apply(op, left, right)
+	return truthOf(result), ok
+}
+
+// apply returns the result of the operator on resolved operands; ok is false
+// for unknown operators.
+func (e *Evaluator) apply(op string, left, right interface{}) (result bool, ok bool) {
+	switch op {
+	default:
+		return false, false
+	case "=":
+		return e.equalValues(left, right), true
+	case "!=":
+		return !e.equalValues(left, right), true
+	case ">":
+		c, ok := e.compareValues(left, right)
+		return ok && c > 0, true
+	case ">=":
+		c, ok := e.compareValues(left, right)
+		return ok && c >= 0, true
+	case "<":
+		c, ok := e.compareValues(left, right)
+		return ok && c < 0, true
+	case "<=":
+		c, ok := e.compareValues(left, right)
+		return ok && c <= 0, true
+	case "starts_with", "ends_with", "contains", "istarts_with", "iends_with", "=*":
+		l, r, ok := e.stringOperands(op, left, right)
+		return ok && matchString(op, l, r), true
+	case "not_contains":
+		l, r, ok := e.stringOperands(op, left, right)
+		return !ok || !strings.Contains(l, r), true
+	case "like", "ilike", "glob":
+		l, r, ok := e.stringOperands(op, left, right)
+		return ok && matchPattern(op, l, r), true
+	case "not_like":
+		l, r, ok := e.stringOperands(op, left, right)
+		return !ok || !matchPattern(op, l, r), true
+	case "=~":
+		l, lok := left.(string)
+		m, mok := right.(Matcher)
+		return lok && mok && m.MatchString(l), true
+	case "!~":
+		l, lok := left.(string)
+		m, mok := right.(Matcher)
+		return !lok || !mok || !m.MatchString(l), true
+	case "in":
+		return e.containsValue(toSlice(right), left), true
+	case "not_in":
+		return !e.containsValue(toSlice(right), left), true
+	case "has":
+		return e.containsValue(toSlice(left), right), true
+	case "not_has":
+		return !e.containsValue(toSlice(left), right), true
+	case "has_any":
+		items := toSlice(left)
+		for _, value := range toSlice(right) {
+			if e.containsValue(items, value) {
+				return true, true
+			}
+		}
+		return false, true
+	case "has_all":
+		items := toSlice(left)
+		if items == nil {
+			return false, true
+		}
+		for _, value := range toSlice(right) {
+			if !e.containsValue(items, value) {
+				return false, true
+			}
+		}
+		return true, true
+	}
+}
+
+// stringOperands returns both operands of a string operator, normalized
+// and, for the case-insensitive operators, case folded; ok is false unless
+// both are strings.
+func (e *Evaluator) stringOperands(op string, left, right interface{}) (l, r string, ok bool) {
+	l, lok := left.(string)
+	r, rok := right.(string)
+	if !lok || !rok {
+		return "", "", false
+	}
+	if e.nfkc {
+		l, r = norm.NFKC.String(l), norm.NFKC.String(r)
+	}
+	switch op {
+	case "istarts_with", "iends_with", "=*", "ilike":
+		fold := cases.Fold()
+		l, r = fold.String(l), fold.String(r)
+	}
+	return l, r, true
+}
+
+func matchString(op, l, r string) bool {
+	switch op {
+	case "starts_with", "istarts_with":
+		return strings.HasPrefix(l, r)
+	case "ends_with", "iends_with":
+		return strings.HasSuffix(l, r)
+	case "contains":
+		return strings.Contains(l, r)
+	default:
+		return l == r
+	}
+}
+
+// resolve returns the value of a param or literal; only function calls can
+// fail.
+func (e *Evaluator) resolve(value Value, data map[string]interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case *ParamX:
+		result, ok := lookup(data, v.Segments())
+		if !ok && e.nulls == StrictNulls {
+			return nil, MissingParam(v.Name)
+		}
+		return normalizeValue(result), nil
+	case *SliceX:
+		return e.resolveAll(v.Values, data)
+	case *PlaceholderX:
+		return nil, UnboundPlaceholder(v.Key())
+	case *RegexpX:
+		return v.Regexp, nil
+	case *RelativeDateTimeX:
+		return v.Resolve(e.now()), nil
+	case *ArithmeticX:
+		if v.Left == nil {
+			right, err := e.resolve(v.Right, data)
+			return negate(right), err
+		}
+		operands, err := e.resolveAll([]Value{v.Left, v.Right}, data)
+		if err != nil {
+			return nil, err
+		}
+		return calculate(v.Operator, operands[0], operands[1]), nil
+	case *FunctionCallX:
+		if v.Function == nil {
+			return nil, UnknownFunction(v.Name)
+		}
+		args, err := e.resolveAll(v.Args, data)
+		if err != nil {
+			return nil, err
+		}
+		for i, arg := range args {
+			if items := toSlice(arg); items != nil {
+				normalized := make([]interface{}, len(items))
+				for j, item := range items {
+					normalized[j] = normalizeValue(item)
+				}
+				args[i] = normalized
+			}
+		}
+		result, err := v.Function.Call(args)
+		if err != nil {
+			return nil, err
+		}
+		return normalizeValue(result), nil
+	default:
+		return normalizeValue(value.Value()), nil
+	}
+}
+
+// paramValue returns the value of a param for the checks and quantifiers,
+// which treat a missing param as null with any Nulls.
+func paramValue(param *ParamX, data map[string]interface{}) interface{} {
+	value, _ := lookup(data, param.Segments())
+	return normalizeValue(value)
+}
+
+func (e *Evaluator) resolveAll(values []Value, data map[string]interface{}) ([]interface{}, error) {
+	items := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		item, err := e.resolve(value, data)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// lookup follows a param path from value; ok is false for missing keys and
+// indices out of range. Negative indices count from the end of arrays, and
+// runs of fields are looked up as dotted keys first, longest first, so
+// {"a.b": 1} has a param a.b.
+func lookup(value interface{}, path []Segment) (interface{}, bool) {
+	if len(path) == 0 {
+		return value, true
+	}
+	segment := path[0]
+	if segment.Kind == SegmentIndex {
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return nil, false
+		}
+		index := segment.Index
+		if index < 0 {
+			index += rv.Len()
+		}
+		if index < 0 || index >= rv.Len() {
+			return nil, false
+		}
+		return lookup(rv.Index(index).Interface(), path[1:])
+	}
+	if segment.Kind == SegmentField {
+		var fields []string
+		for _, s := range path {
+			if s.Kind != SegmentField {
+				break
+			}
+			fields = append(fields, s.Name)
+		}
+		for n := len(fields); n > 1; n-- {
+			if nested, ok := mapValue(value, strings.Join(fields[:n], ".")); ok {
+				return lookup(nested, path[n:])
+			}
+		}
+	}
+	nested, ok := mapValue(value, segment.Name)
+	if !ok {
+		return nil, false
+	}
+	return lookup(nested, path[1:])
+}
+
+// mapValue returns the value of a key of a map with string keys.
+func mapValue(value interface{}, key string) (interface{}, bool) {
+	if m, ok := value.(map[string]interface{}); ok {
+		result, ok := m[key]
+		return result, ok
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	result := rv.MapIndex(reflect.ValueOf(key).Convert(rv.Type().Key()))
+	if !result.IsValid() {
+		return nil, false
+	}
+	return result.Interface(), true
+}
+
+// normalizeValue converts numbers to int64 or float64 so values coming from
+// different sources (Go structs, encoding/json) can be compared.
+func normalizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return int64(v)
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case uint:
+		return normalizeUint(uint64(v))
+	case uint8:
+		return int64(v)
+	case uint16:
+		return int64(v)
+	case uint32:
+		return int64(v)
+	case uint64:
+		return normalizeUint(v)
+	case float32:
+		return float64(v)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		// keep every digit for comparisons with decimals
+		if r, ok := new(big.Rat).SetString(v.String()); ok {
+			return r
+		}
+		return v.String()
+	case *big.Int:
+		if v.IsInt64() {
+			return v.Int64()
+		}
+		return new(big.Rat).SetInt(v)
+	case *big.Float:
+		if r, _ := v.Rat(nil); r != nil {
+			return r
+		}
+		f, _ := v.Float64()
+		return f
+	}
+	return value
+}
+
+func normalizeUint(v uint64) interface{} {
+	if v > math.MaxInt64 {
+		return float64(v)
+	}
+	return int64(v)
+}
+
+func toSlice(value interface{}) []interface{} {
+	if items, ok := value.([]interface{}); ok {
+		return items
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil
+	}
+	items := make([]interface{}, rv.Len())
+	for i := range items {
+		items[i] = normalizeValue(rv.Index(i).Interface())
+	}
+	return items
+}
+
+func (e *Evaluator) containsValue(items []interface{}, value interface{}) bool {
+	for _, item := range items {
+		if e.equalValues(normalizeValue(item), value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Evaluator) equalValues(left, right interface{}) bool {
+	if left == nil || right == nil {
+		return left == nil && right == nil
+	}
+	c, ok := e.compareValues(left, right)
+	return ok && c == 0
+}
+
+// compareValues returns the ordering of two normalized values; ok is false
+// if the values are not comparable.
+func (e *Evaluator) compareValues(left, right interface{}) (int, bool) {
+	switch l := left.(type) {
+	case int64:
+		switch r := right.(type) {
+		case int64:
+			return compareInt64(l, r), true
+		case float64:
+			return compareFloat64(float64(l), r), true
+		case time.Duration:
+			return compareInt64(l, int64(r)), true
+		case *big.Rat:
+			return new(big.Rat).SetInt64(l).Cmp(r), true
+		}
+	case float64:
+		switch r := right.(type) {
+		case int64:
+			return compareFloat64(l, float64(r)), true
+		case float64:
+			return compareFloat64(l, r), true
+		case time.Duration:
+			return compareFloat64(l, float64(r)), true
+		case *big.Rat:
+			return compareFloat64(l, ratFloat64(r)), true
+		}
+	case *big.Rat:
+		// floats are inexact already, so they are compared as floats
+		switch r := right.(type) {
+		case int64:
+			return l.Cmp(new(big.Rat).SetInt64(r)), true
+		case float64:
+			return compareFloat64(ratFloat64(l), r), true
+		case *big.Rat:
+			return l.Cmp(r), true
+		}
+	case string:
+		switch r := right.(type) {
+		case string:
+			return strings.Compare(l, r), true
+		case time.Time:
+			if dt, err := dateparse.ParseAny(l); err == nil {
+				return compareTime(dt, r, e.timePrecision), true
+			}
+		case time.Duration:
+			if d, err := time.ParseDuration(l); err == nil {
+				return compareInt64(int64(d), int64(r)), true
+			}
+		}
+	case bool:
+		if r, ok := right.(bool); ok {
+			switch {
+			case l == r:
+				return 0, true
+			case r:
+				return -1, true
+			default:
+				return 1, true
+			}
+		}
+	case time.Time:
+		switch r := right.(type) {
+		case time.Time:
+			return compareTime(l, r, e.timePrecision), true
+		case string:
+			if dt, err := dateparse.ParseAny(r); err == nil {
+				return compareTime(l, dt, e.timePrecision), true
+			}
+		}
+	case time.Duration:
+		// numbers are durations in nanoseconds
+		switch r := right.(type) {
+		case time.Duration:
+			return compareInt64(int64(l), int64(r)), true
+		case int64:
+			return compareInt64(int64(l), r), true
+		case float64:
+			return compareFloat64(float64(l), r), true
+		case string:
+			if d, err := time.ParseDuration(r); err == nil {
+				return compareInt64(int64(l), int64(d)), true
+			}
+		}
+	}
+	return 0, false
+}
+
+func compareInt64(l, r int64) int {
+	switch {
+	case l < r:
+		return -1
+	case l > r:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareFloat64(l, r float64) int {
+	switch {
+	case l < r:
+		return -1
+	case l > r:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func ratFloat64(r *big.Rat) float64 {
+	f, _ := r.Float64()
+	return f
+}
+
+func compareTime(l, r time.Time, precision time.Duration) int {
+	if precision > 0 {
+		l, r = l.Truncate(precision), r.Truncate(precision)
+	}
+	switch {
+	case l.Before(r):
+		return -1
+	case l.After(r):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// calculate returns the result of an arithmetic operator on normalized
+// values. Integers stay integers, except for division, and turn into floats
+// on overflow; decimals stay exact unless mixed with floats. Numbers mixed
+// with durations are nanoseconds. The result is nil, like a missing param,
+// for operands the operator does not apply to and for division by zero.
+func calculate(op string, left, right interface{}) interface{} {
+	switch l := left.(type) {
+	case int64:
+		switch r := right.(type) {
+		case int64:
+			return calculateInt64(op, l, r)
+		case float64:
+			return calculateFloat64(op, float64(l), r)
+		case *big.Rat:
+			return calculateRat(op, new(big.Rat).SetInt64(l), r)
+		case time.Duration:
+			// a number cannot be divided by a duration
+			if op != "/" && op != "%" {
+				return calculateDuration(op, time.Duration(l), r, true)
+			}
+		}
+	case float64:
+		switch r := right.(type) {
+		case int64:
+			return calculateFloat64(op, l, float64(r))
+		case float64:
+			return calculateFloat64(op, l, r)
+		case *big.Rat:
+			return calculateFloat64(op, l, ratFloat64(r))
+		case time.Duration:
+			if op == "*" {
+				return time.Duration(l * float64(r))
+			}
+		}
+	case *big.Rat:
+		switch r := right.(type) {
+		case int64:
+			return calculateRat(op, l, new(big.Rat).SetInt64(r))
+		case float64:
+			return calculateFloat64(op, ratFloat64(l), r)
+		case *big.Rat:
+			return calculateRat(op, l, r)
+		}
+	case time.Duration:
+		switch r := right.(type) {
+		case time.Duration:
+			if op == "/" {
+				return calculateFloat64(op, float64(l), float64(r))
+			}
+			return calculateDuration(op, l, r, false)
+		case int64:
+			return calculateDuration(op, l, time.Duration(r), true)
+		case float64:
+			switch op {
+			case "*":
+				return time.Duration(float64(l) * r)
+			case "/":
+				if r != 0 {
+					return time.Duration(float64(l) / r)
+				}
+			}
+		case time.Time:
+			if op == "+" {
+				return r.Add(l)
+			}
+		}
+	case time.Time:
+		switch r := right.(type) {
+		case time.Time:
+			if op == "-" {
+				return l.Sub(r)
+			}
+		case time.Duration:
+			switch op {
+			case "+":
+				return l.Add(r)
+			case "-":
+				return l.Add(-r)
+			}
+		}
+	}
+	return nil
+}
+
+func calculateInt64(op string, l, r int64) interface{} {
+	switch op {
+	case "+":
+		if sum := l + r; (r > 0) == (sum > l) || r == 0 {
+			return sum
+		}
+	case "-":
+		if diff := l - r; (r > 0) == (diff < l) || r == 0 {
+			return diff
+		}
+	case "*":
+		if l == 0 || r == 0 {
+			return int64(0)
+		}
+		if product := l * r; product/r == l && !(l == -1 && r == math.MinInt64) && !(r == -1 && l == math.MinInt64) {
+			return product
+		}
+	case "/":
+		return calculateFloat64(op, float64(l), float64(r))
+	case "%":
+		if r == 0 {
+			return nil
+		}
+		return l % r
+	}
+	// the result does not fit into int64
+	return calculateFloat64(op, float64(l), float64(r))
+}
+
+func calculateFloat64(op string, l, r float64) interface{} {
+	switch op {
+	case "+":
+		return l + r
+	case "-":
+		return l - r
+	case "*":
+		return l * r
+	case "/":
+		if r != 0 {
+			return l / r
+		}
+	case "%":
+		if r != 0 {
+			return math.Mod(l, r)
+		}
+	}
+	return nil
+}
+
+func calculateRat(op string, l, r *big.Rat) interface{} {
+	switch op {
+	case "+":
+		return new(big.Rat).Add(l, r)
+	case "-":
+		return new(big.Rat).Sub(l, r)
+	case "*":
+		return new(big.Rat).Mul(l, r)
+	case "/":
+		if r.Sign() != 0 {
+			return new(big.Rat).Quo(l, r)
+		}
+	case "%":
+		if r.Sign() == 0 {
+			return nil
+		}
+		if l.IsInt() && r.IsInt() {
+			return new(big.Rat).SetInt(new(big.Int).Rem(l.Num(), r.Num()))
+		}
+		return calculateFloat64(op, ratFloat64(l), ratFloat64(r))
+	}
+	return nil
+}
+
+// calculateDuration adds, subtracts and takes the remainder of durations;
+// scalar is set when one of them is a number, which can also multiply or
+// divide the other.
+func calculateDuration(op string, l, r time.Duration, scalar bool) interface{} {
+	switch op {
+	case "+":
+		return l + r
+	case "-":
+		return l - r
+	case "%":
+		if r != 0 {
+			return l % r
+		}
+	case "*":
+		if scalar {
+			return l * r
+		}
+	case "/":
+		if scalar && r != 0 {
+			return l / r
+		}
+	}
+	return nil
+}
+
+func negate(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int64:
+		if v == math.MinInt64 {
+			return -float64(v)
+		}
+		return -v
+	case float64:
+		return -v
+	case *big.Rat:
+		return new(big.Rat).Neg(v)
+	case time.Duration:
+		return -v
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package lep
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestEvaluate(t *testing.T) {
+	data := map[string]interface{}{
+		"name":       "John Smith",
+		"nick":       "John",
+		"age":        42,
+		"score":      97.5,
+		"active":     true,
+		"deleted_at": nil,
+		"created_at": time.Date(2020, 3, 4, 10, 20, 30, 0, time.UTC),
+		"updated_at": "2021-01-02T03:04:05Z",
+		"roles":      []string{"admin", "editor"},
+		"ids":        []interface{}{1, 2, 3},
+		"address": map[string]interface{}{
+			"city": "Berlin",
+			"zip":  json.Number("10115"),
+		},
+		"dotted.key": "value",
+	}
+
+	type testEvaluate struct {
+		query  string
+		result bool
+	}
+	var tests = []testEvaluate{
+		{query: `name="John Smith"`, result: true},
+		{query: `name="John"`, result: false},
+		{query: `name!="John"`, result: true},
+		{query: `age=42 && age=42.0`, result: true},
+		{query: `age>41 && age>=42 && age<43 && age<=42`, result: true},
+		{query: `age>42 || age<42`, result: false},
+		{query: `score>97 && score<97.6`, result: true},
+		{query: `active=true && active!=false`, result: true},
+		{query: `deleted_at=null && missing=null && name!=null`, result: true},
+		{query: `deleted_at!=null || missing!=null`, result: false},
+		{query: `missing>1 || missing<1 || missing="x"`, result: false},
+		{query: `name>age || name<age`, result: false},
+		{query: `created_at>dt:"2020-01-01" && created_at<dt:"2020-03-05"`, result: true},
+		{query: `created_at=dt:"2020-03-04 10:20:30"`, result: true},
+		{query: `updated_at>dt:"2021-01-01" && updated_at<created_at`, result: false},
+		{query: `name starts_with "John" && name ends_with "Smith"`, result: true},
+		{query: `name starts_with nick`, result: true},
+		{query: `name ends_with nick || age starts_with "4"`, result: false},
+		{query: `name =~ /Smith/ && nick !~ /Smith/`, result: true},
+		{query: `name =~ /^john/i && name !~ /^john/`, result: true},
+		{query: `name =~ /\/|^john$/`, result: false},
+		{query: `age in [1,42,"x"] && name not_in ["John"]`, result: true},
+		{query: `age in [1] || name not_in ["John Smith"]`, result: false},
+		{query: `roles has "admin" && roles not_has "owner" && ids has 2`, result: true},
+		{query: `roles has_any ["owner","editor"] && roles has_all ["admin","editor"]`, result: true},
+		{query: `roles has_all ["admin","owner"] || name has_all ["a"] || missing has_any [1]`, result: false},
+		{query: `address.city="Berlin" && address.zip=10115 && dotted.key="value"`, result: true},
+		{query: `address.country=null && address.city.name=null`, result: true},
+		{query: `(age=1 || age=42) && (name="x" || active=true)`, result: true},
+		{query: `41 < age && 42 >= age && "admin" in roles && "owner" not_in roles`, result: true},
+		{query: `nick in roles || ["x","editor"] has_all roles || roles has_any ids`, result: false},
+		{query: `["admin","editor","x"] has_all roles && [1,5] has_any ids && [2] has 2`, result: true},
+		{query: `"John Smith" starts_with nick && 1=1.0 && "abc" =~ /b/ && "a" < "b"`, result: true},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query)
+		if assert.NoError(t, err) {
+			result, err := Evaluate(expr, data)
+			if assert.NoError(t, err, tt.query) {
+				assert.Equal(t, tt.result, result, tt.query)
+			}
+		}
+	}
+}
+
+func TestEvaluate_Clock(t *testing.T) {
+	clock := EvalClock(func() time.Time {
+		return time.Date(2021, 5, 12, 15, 4, 5, 0, time.UTC)
+	})
+	data := map[string]interface{}{
+		"created_at": time.Date(2021, 5, 10, 9, 0, 0, 0, time.UTC),
+		"updated_at": "2021-05-12T10:00:00Z",
+	}
+
+	type testEvaluateClock struct {
+		query  string
+		result bool
+	}
+	var tests = []testEvaluateClock{
+		{query: `created_at>now() - 7d && created_at<now() - 2d`, result: true},
+		{query: `created_at>=today()`, result: false},
+		{query: `updated_at>=today() && updated_at<now()`, result: true},
+		{query: `created_at=startOf("week") + 9h`, result: true},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query)
+		if assert.NoError(t, err) {
+			result, err := Evaluate(expr, data, clock)
+			if assert.NoError(t, err, tt.query) {
+				assert.Equal(t, tt.result, result, tt.query)
+			}
+		}
+	}
+}
+
+func TestEvaluate_TimePrecision(t *testing.T) {
+	data := map[string]interface{}{
+		"created_at": time.Date(2020, 3, 4, 10, 20, 30, 123456789, time.UTC),
+		"updated_at": "2020-03-04T12:20:30.5+02:00",
+	}
+
+	type testEvaluateTimePrecision struct {
+		query     string
+		precision time.Duration
+		result    bool
+	}
+	var tests = []testEvaluateTimePrecision{
+		{query: `created_at=dt:"2020-03-04 10:20:30"`, result: false},
+		{query: `created_at=dt:"2020-03-04 10:20:30"`, precision: time.Second, result: true},
+		{query: `created_at>dt:"2020-03-04 10:20:30"`, precision: time.Second, result: false},
+		{query: `created_at=dt:"2020-03-04 10:20:30.123"`, precision: time.Millisecond, result: true},
+		{query: `updated_at=dt:"2020-03-04 10:20:30"`, result: false},
+		{query: `updated_at=dt:"2020-03-04 10:20:30"`, precision: time.Second, result: true},
+		{query: `created_at in [dt:"2020-03-04 10:20:30"]`, precision: time.Second, result: true},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query)
+		if assert.NoError(t, err) {
+			result, err := Evaluate(expr, data, EvalTimePrecision(tt.precision))
+			if assert.NoError(t, err, tt.query) {
+				assert.Equal(t, tt.result, result, tt.query)
+			}
+		}
+	}
+}
+
+func TestEvaluate_Unsupported(t *testing.T) {
+	_, err := Evaluate(Param("a"), nil)
+	assert.EqualError(t, err, UnsupportedExpression("Evaluate", Param("a")).Error())
+}
+
+func TestEvaluator_Trace(t *testing.T) {
+	data := map[string]interface{}{"a": 1, "b": "foo"}
+	expr := Or(
+		And(Equals(Param("a"), Integer(2)), Equals(Param("b"), String("foo"))),
+		GreaterThan(Param("a"), Integer(0)),
+	)
+
+	trace, err := NewEvaluator().Trace(expr, data)
+	if assert.NoError(t, err) {
+		assert.Equal(t, &Trace{
+			Expression: expr,
+			Result:     true,
+			Children: []*Trace{
+				{
+					Expression: expr.Disjunctions[0],
+					Result:     false,
+					Children: []*Trace{
+						{Expression: Equals(Param("a"), Integer(2)), Result: false},
+						{Expression: Equals(Param("b"), String("foo")), Result: true},
+					},
+				},
+				{Expression: GreaterThan(Param("a"), Integer(0)), Result: true},
+			},
+		}, trace)
+	}
+
+	_, err = NewEvaluator().Trace(And(Param("a")), data)
+	assert.Error(t, err)
+}
@@ -0,0 +1,157 @@
+package lep
+
+import "reflect"
+
+// ExistsX matches a param which is in the data, even with a null value.
+type ExistsX struct {
+	Param *ParamX
+}
+
+var _ Expression = (*ExistsX)(nil)
+
+func Exists(param *ParamX) *ExistsX {
+	return &ExistsX{Param: param}
+}
+
+func (e ExistsX) Equals(other Expression) bool {
+	if expr, ok := other.(*ExistsX); ok {
+		return e.Param.Equals(expr.Param)
+	}
+	return false
+}
+
+func (e ExistsX) String() string {
+	return "exists(" + e.Param.String() + ")"
+}
+
+// IsNullX matches a param which is null or missing, like IS NULL in SQL.
+type IsNullX struct {
+	Param *ParamX
+}
+
+var _ Expression = (*IsNullX)(nil)
+
+func IsNull(param *ParamX) *IsNullX {
+	return &IsNullX{Param: param}
+}
+
+func (e IsNullX) Equals(other Expression) bool {
+	if expr, ok := other.(*IsNullX); ok {
+		return e.Param.Equals(expr.Param)
+	}
+	return false
+}
+
+func (e IsNullX) String() string {
+	return e.Param.String() + " is null"
+}
+
+type IsNotNullX struct {
+	Param *ParamX
+}
+
+var _ Expression = (*IsNotNullX)(nil)
+
+func IsNotNull(param *ParamX) *IsNotNullX {
+	return &IsNotNullX{Param: param}
+}
+
+func (e IsNotNullX) Equals(other Expression) bool {
+	if expr, ok := other.(*IsNotNullX); ok {
+		return e.Param.Equals(expr.Param)
+	}
+	return false
+}
+
+func (e IsNotNullX) String() string {
+	return e.Param.String() + " is not null"
+}
+
+// IsEmptyX matches an empty string, array or object. Neither IsEmptyX nor
+// IsNotEmptyX matches null, missing params or values of other types.
+type IsEmptyX struct {
+	Param *ParamX
+}
+
+var _ Expression = (*IsEmptyX)(nil)
+
+func IsEmpty(param *ParamX) *IsEmptyX {
+	return &IsEmptyX{Param: param}
+}
+
+func (e IsEmptyX) Equals(other Expression) bool {
+	if expr, ok := other.(*IsEmptyX); ok {
+		return e.Param.Equals(expr.Param)
+	}
+	return false
+}
+
+func (e IsEmptyX) String() string {
+	return e.Param.String() + " is empty"
+}
+
+type IsNotEmptyX struct {
+	Param *ParamX
+}
+
+var _ Expression = (*IsNotEmptyX)(nil)
+
+func IsNotEmpty(param *ParamX) *IsNotEmptyX {
+	return &IsNotEmptyX{Param: param}
+}
+
+func (e IsNotEmptyX) Equals(other Expression) bool {
+	if expr, ok := other.(*IsNotEmptyX); ok {
+		return e.Param.Equals(expr.Param)
+	}
+	return false
+}
+
+func (e IsNotEmptyX) String() string {
+	return e.Param.String() + " is not empty"
+}
+
+// isEmpty reports whether a string, array or object is empty; ok is false
+// for other values.
+func isEmpty(value interface{}) (empty bool, ok bool) {
+	if s, ok := value.(string); ok {
+		return s == "", true
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return rv.Len() == 0, true
+	}
+	return false, false
+}
+
+func parseIsOperator(not, what interface{}) (string, error) {
+	word, ok := what.([]byte)
+	if !ok {
+		return "", IncorrectType("parseIsOperator", []byte{}, what)
+	}
+	if not != nil {
+		return "is not " + string(word), nil
+	}
+	return "is " + string(word), nil
+}
+
+func parseCheck(param, op interface{}) (Expression, error) {
+	p, ok := param.(*ParamX)
+	if !ok {
+		return nil, IncorrectType("parseCheck", (*ParamX)(nil), param)
+	}
+	switch op {
+	case "exists":
+		return Exists(p), nil
+	case "is null":
+		return IsNull(p), nil
+	case "is not null":
+		return IsNotNull(p), nil
+	case "is empty":
+		return IsEmpty(p), nil
+	case "is not empty":
+		return IsNotEmpty(p), nil
+	}
+	return nil, IncorrectValue("parseCheck", "operator", op)
+}
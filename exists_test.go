@@ -0,0 +1,91 @@
+package lep
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseChecks(t *testing.T) {
+	var (
+		a = Param("a")
+		b = Param("b.c")
+	)
+
+	type testParseChecks struct {
+		query string
+		expr  Expression
+		str   string
+	}
+	var tests = []testParseChecks{
+		{query: `a is null`, expr: IsNull(a), str: `a is null`},
+		{query: `a  is  not  null`, expr: IsNotNull(a), str: `a is not null`},
+		{query: `a is empty`, expr: IsEmpty(a), str: `a is empty`},
+		{query: `a is not empty`, expr: IsNotEmpty(a), str: `a is not empty`},
+		{query: `exists(b.c)`, expr: Exists(b), str: `exists(b.c)`},
+		{query: `exists ( a )`, expr: Exists(a), str: `exists(a)`},
+		{query: `b.c exists`, expr: Exists(b), str: `exists(b.c)`},
+		{
+			query: `(a is null || exists(a)) && b.c is not empty`,
+			expr:  And(Or(IsNull(a), Exists(a)), IsNotEmpty(b)),
+			str:   `(a is null || exists(a)) && b.c is not empty`,
+		},
+		{query: `a = null && exists = 1`, expr: And(Equals(a, Null()), Equals(Param("exists"), Integer(1))), str: `a=null && exists=1`},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query)
+		if assert.NoError(t, err, tt.query) {
+			assert.True(t, tt.expr.Equals(expr), "%s: %s", tt.query, expr)
+			assert.Equal(t, tt.str, expr.String())
+
+			reparsed, err := ParseExpression(expr.String())
+			if assert.NoError(t, err, expr.String()) {
+				assert.True(t, expr.Equals(reparsed), expr.String())
+			}
+		}
+	}
+
+	for _, query := range []string{`a isnull`, `a is nul`, `a is not`, `a is notnull`, `exists(1)`, `exists(a, b)`, `1 is null`, `a exists b`} {
+		_, err := ParseExpression(query)
+		assert.Error(t, err, query)
+	}
+}
+
+func TestChecks_Evaluate(t *testing.T) {
+	data := map[string]interface{}{
+		"nil":     nil,
+		"name":    "",
+		"nick":    "joe",
+		"tags":    []interface{}{},
+		"roles":   []string{"admin"},
+		"meta":    map[string]interface{}{},
+		"address": map[string]interface{}{"city": nil},
+		"age":     0,
+	}
+
+	type testChecksEvaluate struct {
+		query  string
+		result bool
+	}
+	var tests = []testChecksEvaluate{
+		{query: `exists(nil) && nil is null && nil = null`, result: true},
+		{query: `exists(missing) || missing is not null`, result: false},
+		{query: `missing is null && missing = null`, result: true},
+		{query: `exists(address.city) && address.city is null && address.zip is null`, result: true},
+		{query: `exists(address.zip) || address.zip exists`, result: false},
+		{query: `name is empty && tags is empty && meta is empty && name is not null`, result: true},
+		{query: `nick is not empty && roles is not empty && age is not null`, result: true},
+		{query: `nil is empty || nil is not empty || missing is empty || missing is not empty`, result: false},
+		{query: `age is empty || age is not empty || nick is empty || roles is empty`, result: false},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query)
+		if assert.NoError(t, err, tt.query) {
+			result, err := Evaluate(expr, data)
+			if assert.NoError(t, err, tt.query) {
+				assert.Equal(t, tt.result, result, tt.query)
+			}
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package lep
+
+import "strings"
+
+// Function is a function which can be called in expressions. Args are the
+// types of its arguments; when Variadic is set the last one can be repeated.
+// Call gets the values of the arguments normalized like the values of
+// params: integers are int64, floats float64, arrays []interface{} and
+// missing params nil.
+type Function struct {
+	Args     []Type
+	Variadic bool
+	Returns  Type
+	Call     func(args []interface{}) (interface{}, error)
+}
+
+// Functions is a registry of functions by name.
+type Functions map[string]*Function
+
+// Builtins returns a new registry with the built-in functions, which can be
+// extended with functions of your own.
+func Builtins() Functions {
+	fns := make(Functions, len(builtinFunctions))
+	for name, fn := range builtinFunctions {
+		fns[name] = fn
+	}
+	return fns
+}
+
+// CheckArguments checks the number and the types of the arguments of a
+// call of the function. The arguments of a variadic function without Args
+// can be of any type.
+func (f *Function) CheckArguments(name string, args []Type) error {
+	if len(args) < len(f.Args) || (!f.Variadic && len(args) > len(f.Args)) {
+		return ArgumentCount(name, len(f.Args), f.Variadic, len(args))
+	}
+	for i, received := range args {
+		expected := TypeAny
+		if i < len(f.Args) {
+			expected = f.Args[i]
+		} else if len(f.Args) > 0 {
+			expected = f.Args[len(f.Args)-1]
+		}
+		if !expected.Accepts(received) {
+			return InvalidArgument(name, i+1, expected, received)
+		}
+	}
+	return nil
+}
+
+// FunctionCallX is a call of a function, like lower(email). Function is the
+// function of the registry the name was bound to when the call was parsed.
+type FunctionCallX struct {
+	Name     string
+	Args     []Value
+	Function *Function
+}
+
+var _ Stringify = (*FunctionCallX)(nil)
+
+// Call returns a call of the built-in function name; Function is nil if
+// there is no such function.
+func Call(name string, args ...Value) *FunctionCallX {
+	return &FunctionCallX{
+		Name:     name,
+		Args:     args,
+		Function: builtinFunctions[name],
+	}
+}
+
+func (f FunctionCallX) Equals(other Expression) bool {
+	expr, ok := other.(*FunctionCallX)
+	if !ok || f.Name != expr.Name || len(f.Args) != len(expr.Args) {
+		return false
+	}
+	for i, arg := range f.Args {
+		if !arg.Equals(expr.Args[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f FunctionCallX) String() string {
+	args := make([]string, len(f.Args))
+	for i, arg := range f.Args {
+		args[i] = arg.String()
+	}
+	return f.Name + "(" + strings.Join(args, ", ") + ")"
+}
+
+// Value returns the result of the call when every argument is a literal,
+// or nil.
+func (f FunctionCallX) Value() interface{} {
+	result, _ := NewEvaluator().resolve(&f, nil)
+	return result
+}
+
+// IsStringify reports whether the function may return a string, so the call
+// can be the prefix of starts_with or the suffix of ends_with.
+func (f FunctionCallX) IsStringify() bool {
+	return f.Function == nil || TypeString.Accepts(f.Function.Returns)
+}
+
+// parseReference returns the param, or the call of the function of that
+// name if it is followed by arguments.
+func parseReference(name, args interface{}, opts *parseOptions) (Value, error) {
+	param, ok := name.(*ParamX)
+	if !ok {
+		return nil, IncorrectType("parseReference", (*ParamX)(nil), name)
+	}
+	if args == nil {
+		return param, nil
+	}
+	values, ok := args.([]Value)
+	if !ok {
+		return nil, IncorrectType("parseReference", []Value{}, args)
+	}
+
+	fns := builtinFunctions
+	if opts != nil && opts.functions != nil {
+		fns = opts.functions
+	}
+	fn, ok := fns[param.Name]
+	if !ok {
+		return nil, UnknownFunction(param.Name)
+	}
+	if err := fn.CheckArguments(param.Name, typesOf(values)); err != nil {
+		return nil, err
+	}
+	return &FunctionCallX{Name: param.Name, Args: values, Function: fn}, nil
+}
+
+func typesOf(values []Value) []Type {
+	types := make([]Type, len(values))
+	for i, value := range values {
+		types[i] = TypeOf(value)
+	}
+	return types
+}
+
+func parseArguments(args interface{}) ([]Value, error) {
+	if args == nil {
+		return []Value{}, nil
+	}
+	values, ok := args.([]Value)
+	if !ok {
+		return nil, IncorrectType("parseArguments", []Value{}, args)
+	}
+	return values, nil
+}
+
+func parseArgumentList(first, rest interface{}) ([]Value, error) {
+	value, ok := first.(Value)
+	if !ok {
+		return nil, IncorrectType("parseArgumentList", (*Value)(nil), first)
+	}
+	values := []Value{value}
+	items, ok := rest.([]interface{})
+	if !ok {
+		return nil, IncorrectType("parseArgumentList", []interface{}{}, rest)
+	}
+	for _, item := range items {
+		parts, ok := item.([]interface{})
+		if !ok || len(parts) != 4 {
+			return nil, IncorrectType("parseArgumentList", []interface{}{}, item)
+		}
+		value, ok := parts[3].(Value)
+		if !ok {
+			return nil, IncorrectType("parseArgumentList", (*Value)(nil), parts[3])
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
@@ -0,0 +1,175 @@
+package lep
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParseFunctionCall(t *testing.T) {
+	var (
+		email = Param("email")
+		tags  = Param("tags")
+	)
+
+	type testParseFunctionCall struct {
+		query string
+		expr  Expression
+		str   string
+	}
+	var tests = []testParseFunctionCall{
+		{
+			query: `lower(email) = "x@y.com"`,
+			expr:  Compare(Call("lower", email), "=", String("x@y.com")),
+			str:   `lower(email)="x@y.com"`,
+		},
+		{query: `len(tags) > 3`, expr: Compare(Call("len", tags), ">", Integer(3)), str: `len(tags)>3`},
+		{query: `3 < len(tags)`, expr: Compare(Integer(3), "<", Call("len", tags)), str: `3<len(tags)`},
+		{query: `email = lower( email )`, expr: Equals(email, Call("lower", email)), str: `email=lower(email)`},
+		{
+			query: `email starts_with lower(trim(email))`,
+			expr:  StartsWith(email, Call("lower", Call("trim", email))),
+			str:   `email starts_with lower(trim(email))`,
+		},
+		{
+			query: `max(a, b + 1, -2.5) >= abs(c)`,
+			expr:  Compare(Call("max", Param("a"), Arithmetic(Param("b"), "+", Integer(1)), Float(-2.5)), ">=", Call("abs", Param("c"))),
+			str:   `max(a, b + 1, -2.5)>=abs(c)`,
+		},
+		{query: `sum([1,2.5]) = 3.5`, expr: Compare(Call("sum", Slice(Integer(1), Float(2.5))), "=", Float(3.5)), str: `sum([1,2.5])=3.5`},
+		{query: `"a" in lower(email)`, expr: Compare(String("a"), "in", Call("lower", email)), str: `"a" in lower(email)`},
+		{query: `email in lower(email)`, expr: Compare(email, "in", Call("lower", email)), str: `email in lower(email)`},
+		{query: `year(created_at) = 2020`, expr: Compare(Call("year", Param("created_at")), "=", Integer(2020)), str: `year(created_at)=2020`},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query)
+		if assert.NoError(t, err, tt.query) {
+			assert.True(t, tt.expr.Equals(expr), "%s: %s", tt.query, expr)
+			assert.Equal(t, tt.str, expr.String())
+
+			reparsed, err := ParseExpression(expr.String())
+			if assert.NoError(t, err, expr.String()) {
+				assert.True(t, expr.Equals(reparsed), expr.String())
+			}
+		}
+	}
+
+	type testParseFunctionCallErrors struct {
+		query string
+		err   error
+	}
+	var errTests = []testParseFunctionCallErrors{
+		{query: `foo(a) = 1`, err: UnknownFunction("foo")},
+		{query: `lower(a, b) = 1`, err: ArgumentCount("lower", 1, false, 2)},
+		{query: `lower() = 1`, err: ArgumentCount("lower", 1, false, 0)},
+		{query: `max(a) = 1`, err: ArgumentCount("max", 2, true, 1)},
+		{query: `lower(1) = "1"`, err: InvalidArgument("lower", 1, TypeString, TypeInteger)},
+		{query: `max(1, "2") = 1`, err: InvalidArgument("max", 2, TypeFloat, TypeString)},
+		{query: `sum(len(a)) = 1`, err: InvalidArgument("sum", 1, TypeArray, TypeInteger)},
+		{query: `a = -lower(1)`, err: InvalidArgument("lower", 1, TypeString, TypeInteger)},
+		{query: `lower(1) * 2 - 1 > 1`, err: InvalidArgument("lower", 1, TypeString, TypeInteger)},
+		{query: `a > 1 + lower(1) && b = 1`, err: InvalidArgument("lower", 1, TypeString, TypeInteger)},
+	}
+
+	for _, tt := range errTests {
+		_, err := ParseExpression(tt.query)
+		if assert.Error(t, err, tt.query) {
+			assert.Contains(t, err.Error(), tt.err.Error(), tt.query)
+			// the enclosing rules do not report the failed call again
+			assert.Len(t, SyntaxErrors(err), 1, tt.query)
+		}
+	}
+
+	_, err := ParseExpression(`a = lower (b)`)
+	assert.Error(t, err)
+}
+
+func TestFunctionsOption(t *testing.T) {
+	fns := Builtins()
+	fns["geo_distance"] = &Function{
+		Args:    []Type{TypeAny, TypeFloat, TypeFloat},
+		Returns: TypeFloat,
+		Call: func(args []interface{}) (interface{}, error) {
+			loc, ok := args[0].([]interface{})
+			if !ok || len(loc) != 2 {
+				return nil, errors.New("geo_distance: loc is not a point")
+			}
+			lat, _ := loc[0].(float64)
+			lon, _ := loc[1].(float64)
+			return math.Hypot(lat-args[1].(float64), lon-args[2].(float64)), nil
+		},
+	}
+
+	query := `geo_distance(loc, 52.5, 13.4) < 1 && lower(name) = "x"`
+	_, err := ParseExpression(query)
+	assert.Equal(t, "1:1 (0): unknown function: geo_distance", SyntaxErrors(err)[0].Error())
+
+	expr, err := ParseExpression(query, FunctionsOption(fns))
+	if assert.NoError(t, err) {
+		result, err := Evaluate(expr, map[string]interface{}{"loc": []float64{52.6, 13.4}, "name": "X"})
+		assert.NoError(t, err)
+		assert.True(t, result)
+
+		_, err = Evaluate(expr, map[string]interface{}{"loc": "Berlin"})
+		assert.EqualError(t, err, "geo_distance: loc is not a point")
+
+		errs := Schema{"loc": TypeArray, "name": TypeInteger}.Check(expr)
+		assert.Equal(t, []error{InvalidArgument("lower", 1, TypeString, TypeInteger)}, errs)
+	}
+
+	fns["coalesce"] = &Function{Variadic: true, Returns: TypeAny}
+	_, err = ParseExpression(`coalesce() = 1 && coalesce(a, "b", [1]) = 1`, FunctionsOption(fns))
+	assert.NoError(t, err)
+	assert.NoError(t, fns["coalesce"].CheckArguments("coalesce", []Type{TypeInteger, TypeString}))
+
+	_, err = ParseExpression(`lower(a) = "x"`, FunctionsOption(Functions{}))
+	assert.Error(t, err)
+}
+
+func TestFunctionCall_Evaluate(t *testing.T) {
+	data := map[string]interface{}{
+		"email":      " X@Y.com ",
+		"tags":       []string{"a", "b", "c", "d"},
+		"delta":      -3,
+		"price":      2.45,
+		"prices":     []interface{}{1, 2.5, 3},
+		"created_at": time.Date(2021, 5, 16, 15, 4, 5, 0, time.UTC),
+		"birthday":   "2000-02-29",
+		"nick":       "joe",
+	}
+
+	type testFunctionCallEvaluate struct {
+		query  string
+		result bool
+	}
+	var tests = []testFunctionCallEvaluate{
+		{query: `lower(trim(email)) = "x@y.com" && upper(nick) = "JOE"`, result: true},
+		{query: `len(tags) > 3 && len(nick) = 3 && len(missing) = null`, result: true},
+		{query: `abs(delta) < 5 && abs(price) = 2.45 && abs(-9223372036854775808) > 0`, result: true},
+		{query: `round(price) = 2 && floor(price) = 2 && ceil(price) = 3 && round(delta) = -3`, result: true},
+		{query: `min(delta, price, 0) = -3 && max(delta, price) = 2.45`, result: true},
+		{query: `sum(prices) = 6.5 && sum([1,2]) = 3 && sum(nick) = null`, result: true},
+		{query: `concat(nick, "@", "x") = "joe@x" && concat(nick, delta) = null`, result: true},
+		{query: `year(created_at) = 2021 && month(created_at) = 5 && day(created_at) = 16`, result: true},
+		{query: `hour(created_at) = 15 && weekday(created_at) = 7 && year(birthday) = 2000`, result: true},
+		{query: `nick starts_with lower("J") && lower(nick) in ["joe","jim"]`, result: true},
+		{query: `lower(missing) = "x" || year(nick) > 0`, result: false},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query)
+		if assert.NoError(t, err, tt.query) {
+			result, err := Evaluate(expr, data)
+			if assert.NoError(t, err, tt.query) {
+				assert.Equal(t, tt.result, result, tt.query)
+			}
+		}
+	}
+
+	assert.Equal(t, "x", Call("lower", String("X")).Value())
+	_, err := Evaluate(Compare(&FunctionCallX{Name: "foo"}, "=", Integer(1)), data)
+	assert.Equal(t, UnknownFunction("foo"), err)
+}
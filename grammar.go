@@ -31,16 +31,24 @@ var g = &grammar{
 				expr: &seqExpr{
 					pos: position{line: 7, col: 10, offset: 60},
 					exprs: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 7, col: 10, offset: 60},
+							name: "_",
+						},
 						&labeledExpr{
-							pos:   position{line: 7, col: 10, offset: 60},
+							pos:   position{line: 7, col: 12, offset: 62},
 							label: "expr",
 							expr: &ruleRefExpr{
-								pos:  position{line: 7, col: 15, offset: 65},
+								pos:  position{line: 7, col: 17, offset: 67},
 								name: "Expr",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 7, col: 20, offset: 70},
+							pos:  position{line: 7, col: 22, offset: 72},
+							name: "_",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 7, col: 24, offset: 74},
 							name: "EOF",
 						},
 					},
@@ -49,24 +57,24 @@ var g = &grammar{
 		},
 		{
 			name: "Expr",
-			pos:  position{line: 8, col: 1, offset: 95},
+			pos:  position{line: 8, col: 1, offset: 114},
 			expr: &choiceExpr{
-				pos: position{line: 8, col: 10, offset: 104},
+				pos: position{line: 8, col: 10, offset: 123},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 8, col: 10, offset: 104},
+						pos:  position{line: 8, col: 10, offset: 123},
 						name: "Or",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 8, col: 15, offset: 109},
+						pos:  position{line: 8, col: 15, offset: 128},
 						name: "And",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 8, col: 21, offset: 115},
+						pos:  position{line: 8, col: 21, offset: 134},
 						name: "Bracket",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 8, col: 31, offset: 125},
+						pos:  position{line: 8, col: 31, offset: 144},
 						name: "Statements",
 					},
 				},
@@ -74,76 +82,80 @@ var g = &grammar{
 		},
 		{
 			name: "Statements",
-			pos:  position{line: 9, col: 1, offset: 137},
+			pos:  position{line: 9, col: 1, offset: 156},
 			expr: &choiceExpr{
-				pos: position{line: 9, col: 16, offset: 152},
+				pos: position{line: 9, col: 16, offset: 171},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 9, col: 16, offset: 152},
-						name: "Comparators",
+						pos:  position{line: 9, col: 16, offset: 171},
+						name: "SliceStatement",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 9, col: 33, offset: 188},
+						name: "Quantifier",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 9, col: 30, offset: 166},
-						name: "StringOps",
+						pos:  position{line: 9, col: 46, offset: 201},
+						name: "Element",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 9, col: 42, offset: 178},
-						name: "SliceOps",
+						pos:  position{line: 9, col: 56, offset: 211},
+						name: "Exists",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 9, col: 53, offset: 189},
-						name: "ContainOps",
+						pos:  position{line: 9, col: 65, offset: 220},
+						name: "Statement",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 9, col: 66, offset: 202},
-						name: "RegexpOps",
+						pos:  position{line: 9, col: 77, offset: 232},
+						name: "Check",
 					},
 				},
 			},
 		},
 		{
 			name: "Bracket",
-			pos:  position{line: 10, col: 1, offset: 213},
+			pos:  position{line: 10, col: 1, offset: 239},
 			expr: &actionExpr{
-				pos: position{line: 10, col: 12, offset: 224},
+				pos: position{line: 10, col: 12, offset: 250},
 				run: (*parser).callonBracket1,
 				expr: &seqExpr{
-					pos: position{line: 10, col: 12, offset: 224},
+					pos: position{line: 10, col: 12, offset: 250},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 10, col: 12, offset: 224},
+							pos:  position{line: 10, col: 12, offset: 250},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 10, col: 14, offset: 226},
+							pos:        position{line: 10, col: 14, offset: 252},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 10, col: 18, offset: 230},
+							pos:  position{line: 10, col: 18, offset: 256},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 10, col: 20, offset: 232},
+							pos:   position{line: 10, col: 20, offset: 258},
 							label: "expr",
 							expr: &ruleRefExpr{
-								pos:  position{line: 10, col: 25, offset: 237},
+								pos:  position{line: 10, col: 25, offset: 263},
 								name: "Expr",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 10, col: 30, offset: 242},
+							pos:  position{line: 10, col: 30, offset: 268},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 10, col: 32, offset: 244},
+							pos:        position{line: 10, col: 32, offset: 270},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 10, col: 36, offset: 248},
+							pos:  position{line: 10, col: 36, offset: 274},
 							name: "_",
 						},
 					},
@@ -152,29 +164,39 @@ var g = &grammar{
 		},
 		{
 			name: "Param",
-			pos:  position{line: 11, col: 1, offset: 271},
+			pos:  position{line: 11, col: 1, offset: 297},
 			expr: &actionExpr{
-				pos: position{line: 11, col: 10, offset: 280},
+				pos: position{line: 11, col: 10, offset: 306},
 				run: (*parser).callonParam1,
 				expr: &seqExpr{
-					pos: position{line: 11, col: 10, offset: 280},
+					pos: position{line: 11, col: 10, offset: 306},
 					exprs: []interface{}{
-						&charClassMatcher{
-							pos:        position{line: 11, col: 10, offset: 280},
-							val:        "[a-zA-Z]",
-							ranges:     []rune{'a', 'z', 'A', 'Z'},
-							ignoreCase: false,
-							inverted:   false,
+						&labeledExpr{
+							pos:   position{line: 11, col: 10, offset: 306},
+							label: "first",
+							expr: &choiceExpr{
+								pos: position{line: 11, col: 17, offset: 313},
+								alternatives: []interface{}{
+									&ruleRefExpr{
+										pos:  position{line: 11, col: 17, offset: 313},
+										name: "QuotedField",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 11, col: 31, offset: 327},
+										name: "PlainField",
+									},
+								},
+							},
 						},
-						&zeroOrMoreExpr{
-							pos: position{line: 11, col: 19, offset: 289},
-							expr: &charClassMatcher{
-								pos:        position{line: 11, col: 19, offset: 289},
-								val:        "[a-zA-Z0-9_.]",
-								chars:      []rune{'_', '.'},
-								ranges:     []rune{'a', 'z', 'A', 'Z', '0', '9'},
-								ignoreCase: false,
-								inverted:   false,
+						&labeledExpr{
+							pos:   position{line: 11, col: 43, offset: 339},
+							label: "rest",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 11, col: 48, offset: 344},
+								expr: &ruleRefExpr{
+									pos:  position{line: 11, col: 49, offset: 345},
+									name: "Segment",
+								},
 							},
 						},
 					},
@@ -182,120 +204,48 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "Values",
-			pos:  position{line: 14, col: 1, offset: 345},
-			expr: &choiceExpr{
-				pos: position{line: 14, col: 12, offset: 356},
-				alternatives: []interface{}{
-					&ruleRefExpr{
-						pos:  position{line: 14, col: 12, offset: 356},
-						name: "Null",
-					},
-					&ruleRefExpr{
-						pos:  position{line: 14, col: 19, offset: 363},
-						name: "Boolean",
-					},
-					&ruleRefExpr{
-						pos:  position{line: 14, col: 29, offset: 373},
-						name: "Float",
-					},
-					&ruleRefExpr{
-						pos:  position{line: 14, col: 37, offset: 381},
-						name: "Integer",
-					},
-					&ruleRefExpr{
-						pos:  position{line: 14, col: 47, offset: 391},
-						name: "DateTime",
-					},
-					&ruleRefExpr{
-						pos:  position{line: 14, col: 58, offset: 402},
-						name: "String",
-					},
-				},
-			},
-		},
-		{
-			name: "Null",
-			pos:  position{line: 15, col: 1, offset: 410},
-			expr: &actionExpr{
-				pos: position{line: 15, col: 9, offset: 418},
-				run: (*parser).callonNull1,
-				expr: &litMatcher{
-					pos:        position{line: 15, col: 9, offset: 418},
-					val:        "null",
-					ignoreCase: false,
-					want:       "\"null\"",
-				},
-			},
-		},
-		{
-			name: "Boolean",
-			pos:  position{line: 16, col: 1, offset: 448},
+			name: "PlainField",
+			pos:  position{line: 12, col: 1, offset: 390},
 			expr: &actionExpr{
-				pos: position{line: 16, col: 12, offset: 459},
-				run: (*parser).callonBoolean1,
-				expr: &choiceExpr{
-					pos: position{line: 16, col: 13, offset: 460},
-					alternatives: []interface{}{
-						&litMatcher{
-							pos:        position{line: 16, col: 13, offset: 460},
-							val:        "true",
-							ignoreCase: false,
-							want:       "\"true\"",
+				pos: position{line: 12, col: 15, offset: 404},
+				run: (*parser).callonPlainField1,
+				expr: &seqExpr{
+					pos: position{line: 12, col: 15, offset: 404},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 12, col: 15, offset: 404},
+							label: "name",
+							expr: &ruleRefExpr{
+								pos:  position{line: 12, col: 21, offset: 410},
+								name: "Identifier",
+							},
 						},
-						&litMatcher{
-							pos:        position{line: 16, col: 22, offset: 469},
-							val:        "false",
-							ignoreCase: false,
-							want:       "\"false\"",
+						&notCodeExpr{
+							pos: position{line: 12, col: 33, offset: 422},
+							run: (*parser).callonPlainField5,
 						},
 					},
 				},
 			},
 		},
 		{
-			name: "Float",
-			pos:  position{line: 17, col: 1, offset: 510},
+			name: "Identifier",
+			pos:  position{line: 13, col: 1, offset: 489},
 			expr: &actionExpr{
-				pos: position{line: 17, col: 10, offset: 519},
-				run: (*parser).callonFloat1,
+				pos: position{line: 13, col: 15, offset: 503},
+				run: (*parser).callonIdentifier1,
 				expr: &seqExpr{
-					pos: position{line: 17, col: 10, offset: 519},
+					pos: position{line: 13, col: 15, offset: 503},
 					exprs: []interface{}{
-						&zeroOrOneExpr{
-							pos: position{line: 17, col: 10, offset: 519},
-							expr: &litMatcher{
-								pos:        position{line: 17, col: 10, offset: 519},
-								val:        "-",
-								ignoreCase: false,
-								want:       "\"-\"",
-							},
-						},
-						&oneOrMoreExpr{
-							pos: position{line: 17, col: 15, offset: 524},
-							expr: &charClassMatcher{
-								pos:        position{line: 17, col: 15, offset: 524},
-								val:        "[0-9]",
-								ranges:     []rune{'0', '9'},
-								ignoreCase: false,
-								inverted:   false,
-							},
-						},
-						&charClassMatcher{
-							pos:        position{line: 17, col: 21, offset: 530},
-							val:        "[.]",
-							chars:      []rune{'.'},
-							ignoreCase: false,
-							inverted:   false,
+						&ruleRefExpr{
+							pos:  position{line: 13, col: 15, offset: 503},
+							name: "IdentifierStart",
 						},
-						&oneOrMoreExpr{
-							pos: position{line: 17, col: 24, offset: 533},
-							expr: &charClassMatcher{
-								pos:        position{line: 17, col: 24, offset: 533},
-								val:        "[0-9]",
-								ranges:     []rune{'0', '9'},
-								ignoreCase: false,
-								inverted:   false,
+						&zeroOrMoreExpr{
+							pos: position{line: 13, col: 31, offset: 519},
+							expr: &ruleRefExpr{
+								pos:  position{line: 13, col: 31, offset: 519},
+								name: "IdentifierPart",
 							},
 						},
 					},
@@ -303,31 +253,35 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "Integer",
-			pos:  position{line: 18, col: 1, offset: 570},
-			expr: &actionExpr{
-				pos: position{line: 18, col: 12, offset: 581},
-				run: (*parser).callonInteger1,
-				expr: &seqExpr{
-					pos: position{line: 18, col: 12, offset: 581},
-					exprs: []interface{}{
-						&zeroOrOneExpr{
-							pos: position{line: 18, col: 12, offset: 581},
-							expr: &litMatcher{
-								pos:        position{line: 18, col: 12, offset: 581},
-								val:        "-",
-								ignoreCase: false,
-								want:       "\"-\"",
+			name: "IdentifierStart",
+			pos:  position{line: 14, col: 1, offset: 566},
+			expr: &choiceExpr{
+				pos: position{line: 14, col: 20, offset: 585},
+				alternatives: []interface{}{
+					&charClassMatcher{
+						pos:        position{line: 14, col: 20, offset: 585},
+						val:        "[a-zA-Z]",
+						ranges:     []rune{'a', 'z', 'A', 'Z'},
+						ignoreCase: false,
+						inverted:   false,
+					},
+					&seqExpr{
+						pos: position{line: 14, col: 31, offset: 596},
+						exprs: []interface{}{
+							&andCodeExpr{
+								pos: position{line: 14, col: 31, offset: 596},
+								run: (*parser).callonIdentifierStart4,
 							},
-						},
-						&oneOrMoreExpr{
-							pos: position{line: 18, col: 17, offset: 586},
-							expr: &charClassMatcher{
-								pos:        position{line: 18, col: 17, offset: 586},
-								val:        "[0-9]",
-								ranges:     []rune{'0', '9'},
-								ignoreCase: false,
-								inverted:   false,
+							&labeledExpr{
+								pos:   position{line: 14, col: 82, offset: 647},
+								label: "ch",
+								expr: &anyMatcher{
+									line: 14, col: 85, offset: 650,
+								},
+							},
+							&andCodeExpr{
+								pos: position{line: 14, col: 87, offset: 652},
+								run: (*parser).callonIdentifierStart7,
 							},
 						},
 					},
@@ -335,144 +289,137 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "String",
-			pos:  position{line: 19, col: 1, offset: 625},
-			expr: &actionExpr{
-				pos: position{line: 19, col: 11, offset: 635},
-				run: (*parser).callonString1,
-				expr: &seqExpr{
-					pos: position{line: 19, col: 11, offset: 635},
-					exprs: []interface{}{
-						&litMatcher{
-							pos:        position{line: 19, col: 11, offset: 635},
-							val:        "\"",
-							ignoreCase: false,
-							want:       "\"\\\"\"",
-						},
-						&zeroOrMoreExpr{
-							pos: position{line: 19, col: 15, offset: 639},
-							expr: &charClassMatcher{
-								pos:        position{line: 19, col: 15, offset: 639},
-								val:        "[^\"]",
-								chars:      []rune{'"'},
-								ignoreCase: false,
-								inverted:   true,
+			name: "IdentifierPart",
+			pos:  position{line: 15, col: 1, offset: 710},
+			expr: &choiceExpr{
+				pos: position{line: 15, col: 19, offset: 728},
+				alternatives: []interface{}{
+					&charClassMatcher{
+						pos:        position{line: 15, col: 19, offset: 728},
+						val:        "[a-zA-Z0-9_]",
+						chars:      []rune{'_'},
+						ranges:     []rune{'a', 'z', 'A', 'Z', '0', '9'},
+						ignoreCase: false,
+						inverted:   false,
+					},
+					&seqExpr{
+						pos: position{line: 15, col: 34, offset: 743},
+						exprs: []interface{}{
+							&andCodeExpr{
+								pos: position{line: 15, col: 34, offset: 743},
+								run: (*parser).callonIdentifierPart4,
+							},
+							&labeledExpr{
+								pos:   position{line: 15, col: 85, offset: 794},
+								label: "ch",
+								expr: &anyMatcher{
+									line: 15, col: 88, offset: 797,
+								},
+							},
+							&andCodeExpr{
+								pos: position{line: 15, col: 90, offset: 799},
+								run: (*parser).callonIdentifierPart7,
 							},
-						},
-						&litMatcher{
-							pos:        position{line: 19, col: 21, offset: 645},
-							val:        "\"",
-							ignoreCase: false,
-							want:       "\"\\\"\"",
 						},
 					},
 				},
 			},
 		},
 		{
-			name: "DateTime",
-			pos:  position{line: 20, col: 1, offset: 680},
+			name: "QuotedField",
+			pos:  position{line: 16, col: 1, offset: 856},
 			expr: &actionExpr{
-				pos: position{line: 20, col: 13, offset: 692},
-				run: (*parser).callonDateTime1,
+				pos: position{line: 16, col: 16, offset: 871},
+				run: (*parser).callonQuotedField1,
 				expr: &seqExpr{
-					pos: position{line: 20, col: 13, offset: 692},
+					pos: position{line: 16, col: 16, offset: 871},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 20, col: 13, offset: 692},
-							val:        "dt:",
+							pos:        position{line: 16, col: 16, offset: 871},
+							val:        "`",
 							ignoreCase: false,
-							want:       "\"dt:\"",
+							want:       "\"`\"",
 						},
-						&labeledExpr{
-							pos:   position{line: 20, col: 19, offset: 698},
-							label: "val",
-							expr: &ruleRefExpr{
-								pos:  position{line: 20, col: 24, offset: 703},
-								name: "String",
+						&oneOrMoreExpr{
+							pos: position{line: 16, col: 20, offset: 875},
+							expr: &choiceExpr{
+								pos: position{line: 16, col: 21, offset: 876},
+								alternatives: []interface{}{
+									&litMatcher{
+										pos:        position{line: 16, col: 21, offset: 876},
+										val:        "``",
+										ignoreCase: false,
+										want:       "\"``\"",
+									},
+									&charClassMatcher{
+										pos:        position{line: 16, col: 28, offset: 883},
+										val:        "[^`]",
+										chars:      []rune{'`'},
+										ignoreCase: false,
+										inverted:   true,
+									},
+								},
 							},
 						},
+						&litMatcher{
+							pos:        position{line: 16, col: 35, offset: 890},
+							val:        "`",
+							ignoreCase: false,
+							want:       "\"`\"",
+						},
 					},
 				},
 			},
 		},
 		{
-			name: "Comparators",
-			pos:  position{line: 23, col: 1, offset: 757},
+			name: "Segment",
+			pos:  position{line: 17, col: 1, offset: 930},
 			expr: &choiceExpr{
-				pos: position{line: 23, col: 17, offset: 773},
+				pos: position{line: 17, col: 13, offset: 942},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 23, col: 17, offset: 773},
-						name: "NotEqual",
-					},
-					&ruleRefExpr{
-						pos:  position{line: 23, col: 28, offset: 784},
-						name: "Equal",
-					},
-					&ruleRefExpr{
-						pos:  position{line: 23, col: 36, offset: 792},
-						name: "GreaterThanEqual",
-					},
-					&ruleRefExpr{
-						pos:  position{line: 23, col: 55, offset: 811},
-						name: "GreaterThan",
+						pos:  position{line: 17, col: 13, offset: 942},
+						name: "FieldSegment",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 23, col: 69, offset: 825},
-						name: "LessThanEqual",
+						pos:  position{line: 17, col: 28, offset: 957},
+						name: "IndexSegment",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 23, col: 85, offset: 841},
-						name: "LessThan",
+						pos:  position{line: 17, col: 43, offset: 972},
+						name: "KeySegment",
 					},
 				},
 			},
 		},
 		{
-			name: "Equal",
-			pos:  position{line: 24, col: 1, offset: 851},
+			name: "FieldSegment",
+			pos:  position{line: 18, col: 1, offset: 984},
 			expr: &actionExpr{
-				pos: position{line: 24, col: 10, offset: 860},
-				run: (*parser).callonEqual1,
+				pos: position{line: 18, col: 17, offset: 1000},
+				run: (*parser).callonFieldSegment1,
 				expr: &seqExpr{
-					pos: position{line: 24, col: 10, offset: 860},
+					pos: position{line: 18, col: 17, offset: 1000},
 					exprs: []interface{}{
-						&labeledExpr{
-							pos:   position{line: 24, col: 10, offset: 860},
-							label: "left",
-							expr: &ruleRefExpr{
-								pos:  position{line: 24, col: 16, offset: 866},
-								name: "Param",
-							},
-						},
-						&ruleRefExpr{
-							pos:  position{line: 24, col: 23, offset: 873},
-							name: "_",
-						},
 						&litMatcher{
-							pos:        position{line: 24, col: 25, offset: 875},
-							val:        "=",
+							pos:        position{line: 18, col: 17, offset: 1000},
+							val:        ".",
 							ignoreCase: false,
-							want:       "\"=\"",
-						},
-						&ruleRefExpr{
-							pos:  position{line: 24, col: 29, offset: 879},
-							name: "_",
+							want:       "\".\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 24, col: 31, offset: 881},
-							label: "right",
+							pos:   position{line: 18, col: 21, offset: 1004},
+							label: "name",
 							expr: &choiceExpr{
-								pos: position{line: 24, col: 38, offset: 888},
+								pos: position{line: 18, col: 27, offset: 1010},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 24, col: 38, offset: 888},
-										name: "Values",
+										pos:  position{line: 18, col: 27, offset: 1010},
+										name: "QuotedField",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 24, col: 47, offset: 897},
-										name: "Param",
+										pos:  position{line: 18, col: 41, offset: 1024},
+										name: "FieldName",
 									},
 								},
 							},
@@ -482,51 +429,87 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "NotEqual",
-			pos:  position{line: 25, col: 1, offset: 940},
+			name: "FieldName",
+			pos:  position{line: 19, col: 1, offset: 1079},
+			expr: &actionExpr{
+				pos: position{line: 19, col: 14, offset: 1092},
+				run: (*parser).callonFieldName1,
+				expr: &oneOrMoreExpr{
+					pos: position{line: 19, col: 14, offset: 1092},
+					expr: &ruleRefExpr{
+						pos:  position{line: 19, col: 14, offset: 1092},
+						name: "IdentifierPart",
+					},
+				},
+			},
+		},
+		{
+			name: "IndexSegment",
+			pos:  position{line: 20, col: 1, offset: 1139},
 			expr: &actionExpr{
-				pos: position{line: 25, col: 13, offset: 952},
-				run: (*parser).callonNotEqual1,
+				pos: position{line: 20, col: 17, offset: 1155},
+				run: (*parser).callonIndexSegment1,
 				expr: &seqExpr{
-					pos: position{line: 25, col: 13, offset: 952},
+					pos: position{line: 20, col: 17, offset: 1155},
 					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 20, col: 17, offset: 1155},
+							val:        "[",
+							ignoreCase: false,
+							want:       "\"[\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 20, col: 21, offset: 1159},
+							name: "_",
+						},
 						&labeledExpr{
-							pos:   position{line: 25, col: 13, offset: 952},
-							label: "left",
+							pos:   position{line: 20, col: 23, offset: 1161},
+							label: "index",
 							expr: &ruleRefExpr{
-								pos:  position{line: 25, col: 19, offset: 958},
-								name: "Param",
+								pos:  position{line: 20, col: 30, offset: 1168},
+								name: "Index",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 25, col: 26, offset: 965},
+							pos:  position{line: 20, col: 37, offset: 1175},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 25, col: 28, offset: 967},
-							val:        "!=",
+							pos:        position{line: 20, col: 39, offset: 1177},
+							val:        "]",
 							ignoreCase: false,
-							want:       "\"!=\"",
+							want:       "\"]\"",
 						},
-						&ruleRefExpr{
-							pos:  position{line: 25, col: 33, offset: 972},
-							name: "_",
+					},
+				},
+			},
+		},
+		{
+			name: "Index",
+			pos:  position{line: 21, col: 1, offset: 1226},
+			expr: &actionExpr{
+				pos: position{line: 21, col: 10, offset: 1235},
+				run: (*parser).callonIndex1,
+				expr: &seqExpr{
+					pos: position{line: 21, col: 10, offset: 1235},
+					exprs: []interface{}{
+						&zeroOrOneExpr{
+							pos: position{line: 21, col: 10, offset: 1235},
+							expr: &litMatcher{
+								pos:        position{line: 21, col: 10, offset: 1235},
+								val:        "-",
+								ignoreCase: false,
+								want:       "\"-\"",
+							},
 						},
-						&labeledExpr{
-							pos:   position{line: 25, col: 35, offset: 974},
-							label: "right",
-							expr: &choiceExpr{
-								pos: position{line: 25, col: 42, offset: 981},
-								alternatives: []interface{}{
-									&ruleRefExpr{
-										pos:  position{line: 25, col: 42, offset: 981},
-										name: "Values",
-									},
-									&ruleRefExpr{
-										pos:  position{line: 25, col: 51, offset: 990},
-										name: "Param",
-									},
-								},
+						&oneOrMoreExpr{
+							pos: position{line: 21, col: 15, offset: 1240},
+							expr: &charClassMatcher{
+								pos:        position{line: 21, col: 15, offset: 1240},
+								val:        "[0-9]",
+								ranges:     []rune{'0', '9'},
+								ignoreCase: false,
+								inverted:   false,
 							},
 						},
 					},
@@ -534,49 +517,1483 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "LessThan",
-			pos:  position{line: 26, col: 1, offset: 1036},
+			name: "KeySegment",
+			pos:  position{line: 22, col: 1, offset: 1278},
 			expr: &actionExpr{
-				pos: position{line: 26, col: 13, offset: 1048},
-				run: (*parser).callonLessThan1,
+				pos: position{line: 22, col: 15, offset: 1292},
+				run: (*parser).callonKeySegment1,
 				expr: &seqExpr{
-					pos: position{line: 26, col: 13, offset: 1048},
+					pos: position{line: 22, col: 15, offset: 1292},
 					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 22, col: 15, offset: 1292},
+							val:        "[",
+							ignoreCase: false,
+							want:       "\"[\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 22, col: 19, offset: 1296},
+							name: "_",
+						},
 						&labeledExpr{
-							pos:   position{line: 26, col: 13, offset: 1048},
-							label: "left",
+							pos:   position{line: 22, col: 21, offset: 1298},
+							label: "key",
 							expr: &ruleRefExpr{
-								pos:  position{line: 26, col: 19, offset: 1054},
-								name: "Param",
+								pos:  position{line: 22, col: 26, offset: 1303},
+								name: "String",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 26, col: 26, offset: 1061},
+							pos:  position{line: 22, col: 34, offset: 1311},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 26, col: 28, offset: 1063},
-							val:        "<",
+							pos:        position{line: 22, col: 36, offset: 1313},
+							val:        "]",
 							ignoreCase: false,
-							want:       "\"<\"",
-						},
-						&ruleRefExpr{
-							pos:  position{line: 26, col: 32, offset: 1067},
-							name: "_",
+							want:       "\"]\"",
 						},
-						&labeledExpr{
-							pos:   position{line: 26, col: 34, offset: 1069},
-							label: "right",
-							expr: &choiceExpr{
-								pos: position{line: 26, col: 41, offset: 1076},
-								alternatives: []interface{}{
-									&ruleRefExpr{
-										pos:  position{line: 26, col: 41, offset: 1076},
-										name: "Values",
+					},
+				},
+			},
+		},
+		{
+			name: "Operand",
+			pos:  position{line: 23, col: 1, offset: 1358},
+			expr: &ruleRefExpr{
+				pos:  position{line: 23, col: 13, offset: 1370},
+				name: "Sum",
+			},
+		},
+		{
+			name: "Values",
+			pos:  position{line: 26, col: 1, offset: 1386},
+			expr: &choiceExpr{
+				pos: position{line: 26, col: 12, offset: 1397},
+				alternatives: []interface{}{
+					&ruleRefExpr{
+						pos:  position{line: 26, col: 12, offset: 1397},
+						name: "RelativeDateTime",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 26, col: 31, offset: 1416},
+						name: "Null",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 26, col: 38, offset: 1423},
+						name: "Boolean",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 26, col: 48, offset: 1433},
+						name: "Duration",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 26, col: 59, offset: 1444},
+						name: "Decimal",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 26, col: 69, offset: 1454},
+						name: "Float",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 26, col: 77, offset: 1462},
+						name: "Integer",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 26, col: 87, offset: 1472},
+						name: "DateTime",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 26, col: 98, offset: 1483},
+						name: "String",
+					},
+				},
+			},
+		},
+		{
+			name: "Null",
+			pos:  position{line: 27, col: 1, offset: 1491},
+			expr: &actionExpr{
+				pos: position{line: 27, col: 9, offset: 1499},
+				run: (*parser).callonNull1,
+				expr: &seqExpr{
+					pos: position{line: 27, col: 9, offset: 1499},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 27, col: 9, offset: 1499},
+							val:        "null",
+							ignoreCase: false,
+							want:       "\"null\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 27, col: 16, offset: 1506},
+							name: "EndOfWord",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Boolean",
+			pos:  position{line: 28, col: 1, offset: 1539},
+			expr: &actionExpr{
+				pos: position{line: 28, col: 12, offset: 1550},
+				run: (*parser).callonBoolean1,
+				expr: &seqExpr{
+					pos: position{line: 28, col: 12, offset: 1550},
+					exprs: []interface{}{
+						&choiceExpr{
+							pos: position{line: 28, col: 13, offset: 1551},
+							alternatives: []interface{}{
+								&litMatcher{
+									pos:        position{line: 28, col: 13, offset: 1551},
+									val:        "true",
+									ignoreCase: false,
+									want:       "\"true\"",
+								},
+								&litMatcher{
+									pos:        position{line: 28, col: 22, offset: 1560},
+									val:        "false",
+									ignoreCase: false,
+									want:       "\"false\"",
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 28, col: 31, offset: 1569},
+							name: "EndOfWord",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Decimal",
+			pos:  position{line: 29, col: 1, offset: 1611},
+			expr: &actionExpr{
+				pos: position{line: 29, col: 12, offset: 1622},
+				run: (*parser).callonDecimal1,
+				expr: &seqExpr{
+					pos: position{line: 29, col: 12, offset: 1622},
+					exprs: []interface{}{
+						&andCodeExpr{
+							pos: position{line: 29, col: 12, offset: 1622},
+							run: (*parser).callonDecimal3,
+						},
+						&choiceExpr{
+							pos: position{line: 29, col: 79, offset: 1689},
+							alternatives: []interface{}{
+								&ruleRefExpr{
+									pos:  position{line: 29, col: 79, offset: 1689},
+									name: "FloatNumber",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 29, col: 93, offset: 1703},
+									name: "IntegerNumber",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Float",
+			pos:  position{line: 30, col: 1, offset: 1750},
+			expr: &actionExpr{
+				pos: position{line: 30, col: 10, offset: 1759},
+				run: (*parser).callonFloat1,
+				expr: &ruleRefExpr{
+					pos:  position{line: 30, col: 10, offset: 1759},
+					name: "FloatNumber",
+				},
+			},
+		},
+		{
+			name: "Integer",
+			pos:  position{line: 31, col: 1, offset: 1801},
+			expr: &actionExpr{
+				pos: position{line: 31, col: 12, offset: 1812},
+				run: (*parser).callonInteger1,
+				expr: &ruleRefExpr{
+					pos:  position{line: 31, col: 12, offset: 1812},
+					name: "IntegerNumber",
+				},
+			},
+		},
+		{
+			name: "FloatNumber",
+			pos:  position{line: 32, col: 1, offset: 1858},
+			expr: &seqExpr{
+				pos: position{line: 32, col: 16, offset: 1873},
+				exprs: []interface{}{
+					&zeroOrOneExpr{
+						pos: position{line: 32, col: 16, offset: 1873},
+						expr: &charClassMatcher{
+							pos:        position{line: 32, col: 16, offset: 1873},
+							val:        "[+-]",
+							chars:      []rune{'+', '-'},
+							ignoreCase: false,
+							inverted:   false,
+						},
+					},
+					&choiceExpr{
+						pos: position{line: 32, col: 23, offset: 1880},
+						alternatives: []interface{}{
+							&seqExpr{
+								pos: position{line: 32, col: 23, offset: 1880},
+								exprs: []interface{}{
+									&zeroOrOneExpr{
+										pos: position{line: 32, col: 23, offset: 1880},
+										expr: &ruleRefExpr{
+											pos:  position{line: 32, col: 23, offset: 1880},
+											name: "Digits",
+										},
+									},
+									&litMatcher{
+										pos:        position{line: 32, col: 31, offset: 1888},
+										val:        ".",
+										ignoreCase: false,
+										want:       "\".\"",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 32, col: 35, offset: 1892},
+										name: "Digits",
+									},
+									&zeroOrOneExpr{
+										pos: position{line: 32, col: 42, offset: 1899},
+										expr: &ruleRefExpr{
+											pos:  position{line: 32, col: 42, offset: 1899},
+											name: "Exponent",
+										},
+									},
+								},
+							},
+							&seqExpr{
+								pos: position{line: 32, col: 54, offset: 1911},
+								exprs: []interface{}{
+									&ruleRefExpr{
+										pos:  position{line: 32, col: 54, offset: 1911},
+										name: "Digits",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 32, col: 61, offset: 1918},
+										name: "Exponent",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "IntegerNumber",
+			pos:  position{line: 33, col: 1, offset: 1928},
+			expr: &seqExpr{
+				pos: position{line: 33, col: 18, offset: 1945},
+				exprs: []interface{}{
+					&zeroOrOneExpr{
+						pos: position{line: 33, col: 18, offset: 1945},
+						expr: &charClassMatcher{
+							pos:        position{line: 33, col: 18, offset: 1945},
+							val:        "[+-]",
+							chars:      []rune{'+', '-'},
+							ignoreCase: false,
+							inverted:   false,
+						},
+					},
+					&choiceExpr{
+						pos: position{line: 33, col: 25, offset: 1952},
+						alternatives: []interface{}{
+							&seqExpr{
+								pos: position{line: 33, col: 25, offset: 1952},
+								exprs: []interface{}{
+									&litMatcher{
+										pos:        position{line: 33, col: 25, offset: 1952},
+										val:        "0",
+										ignoreCase: false,
+										want:       "\"0\"",
+									},
+									&charClassMatcher{
+										pos:        position{line: 33, col: 29, offset: 1956},
+										val:        "[xX]",
+										chars:      []rune{'x', 'X'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+									&ruleRefExpr{
+										pos:  position{line: 33, col: 34, offset: 1961},
+										name: "HexDigits",
+									},
+								},
+							},
+							&ruleRefExpr{
+								pos:  position{line: 33, col: 46, offset: 1973},
+								name: "Digits",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Digits",
+			pos:  position{line: 34, col: 1, offset: 1981},
+			expr: &seqExpr{
+				pos: position{line: 34, col: 11, offset: 1991},
+				exprs: []interface{}{
+					&oneOrMoreExpr{
+						pos: position{line: 34, col: 11, offset: 1991},
+						expr: &charClassMatcher{
+							pos:        position{line: 34, col: 11, offset: 1991},
+							val:        "[0-9]",
+							ranges:     []rune{'0', '9'},
+							ignoreCase: false,
+							inverted:   false,
+						},
+					},
+					&zeroOrMoreExpr{
+						pos: position{line: 34, col: 18, offset: 1998},
+						expr: &seqExpr{
+							pos: position{line: 34, col: 19, offset: 1999},
+							exprs: []interface{}{
+								&litMatcher{
+									pos:        position{line: 34, col: 19, offset: 1999},
+									val:        "_",
+									ignoreCase: false,
+									want:       "\"_\"",
+								},
+								&oneOrMoreExpr{
+									pos: position{line: 34, col: 23, offset: 2003},
+									expr: &charClassMatcher{
+										pos:        position{line: 34, col: 23, offset: 2003},
+										val:        "[0-9]",
+										ranges:     []rune{'0', '9'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "HexDigits",
+			pos:  position{line: 35, col: 1, offset: 2012},
+			expr: &seqExpr{
+				pos: position{line: 35, col: 14, offset: 2025},
+				exprs: []interface{}{
+					&oneOrMoreExpr{
+						pos: position{line: 35, col: 14, offset: 2025},
+						expr: &charClassMatcher{
+							pos:        position{line: 35, col: 14, offset: 2025},
+							val:        "[0-9a-fA-F]",
+							ranges:     []rune{'0', '9', 'a', 'f', 'A', 'F'},
+							ignoreCase: false,
+							inverted:   false,
+						},
+					},
+					&zeroOrMoreExpr{
+						pos: position{line: 35, col: 27, offset: 2038},
+						expr: &seqExpr{
+							pos: position{line: 35, col: 28, offset: 2039},
+							exprs: []interface{}{
+								&litMatcher{
+									pos:        position{line: 35, col: 28, offset: 2039},
+									val:        "_",
+									ignoreCase: false,
+									want:       "\"_\"",
+								},
+								&oneOrMoreExpr{
+									pos: position{line: 35, col: 32, offset: 2043},
+									expr: &charClassMatcher{
+										pos:        position{line: 35, col: 32, offset: 2043},
+										val:        "[0-9a-fA-F]",
+										ranges:     []rune{'0', '9', 'a', 'f', 'A', 'F'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Exponent",
+			pos:  position{line: 36, col: 1, offset: 2058},
+			expr: &seqExpr{
+				pos: position{line: 36, col: 13, offset: 2070},
+				exprs: []interface{}{
+					&charClassMatcher{
+						pos:        position{line: 36, col: 13, offset: 2070},
+						val:        "[eE]",
+						chars:      []rune{'e', 'E'},
+						ignoreCase: false,
+						inverted:   false,
+					},
+					&zeroOrOneExpr{
+						pos: position{line: 36, col: 18, offset: 2075},
+						expr: &charClassMatcher{
+							pos:        position{line: 36, col: 18, offset: 2075},
+							val:        "[+-]",
+							chars:      []rune{'+', '-'},
+							ignoreCase: false,
+							inverted:   false,
+						},
+					},
+					&oneOrMoreExpr{
+						pos: position{line: 36, col: 24, offset: 2081},
+						expr: &charClassMatcher{
+							pos:        position{line: 36, col: 24, offset: 2081},
+							val:        "[0-9]",
+							ranges:     []rune{'0', '9'},
+							ignoreCase: false,
+							inverted:   false,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "String",
+			pos:  position{line: 37, col: 1, offset: 2088},
+			expr: &actionExpr{
+				pos: position{line: 37, col: 11, offset: 2098},
+				run: (*parser).callonString1,
+				expr: &seqExpr{
+					pos: position{line: 37, col: 11, offset: 2098},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 37, col: 11, offset: 2098},
+							val:        "\"",
+							ignoreCase: false,
+							want:       "\"\\\"\"",
+						},
+						&zeroOrMoreExpr{
+							pos: position{line: 37, col: 15, offset: 2102},
+							expr: &choiceExpr{
+								pos: position{line: 37, col: 16, offset: 2103},
+								alternatives: []interface{}{
+									&seqExpr{
+										pos: position{line: 37, col: 16, offset: 2103},
+										exprs: []interface{}{
+											&litMatcher{
+												pos:        position{line: 37, col: 16, offset: 2103},
+												val:        "\\",
+												ignoreCase: false,
+												want:       "\"\\\\\"",
+											},
+											&anyMatcher{
+												line: 37, col: 21, offset: 2108,
+											},
+										},
+									},
+									&charClassMatcher{
+										pos:        position{line: 37, col: 25, offset: 2112},
+										val:        "[^\"\\\\]",
+										chars:      []rune{'"', '\\'},
+										ignoreCase: false,
+										inverted:   true,
+									},
+								},
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 37, col: 34, offset: 2121},
+							val:        "\"",
+							ignoreCase: false,
+							want:       "\"\\\"\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "DateTime",
+			pos:  position{line: 38, col: 1, offset: 2156},
+			expr: &actionExpr{
+				pos: position{line: 38, col: 13, offset: 2168},
+				run: (*parser).callonDateTime1,
+				expr: &seqExpr{
+					pos: position{line: 38, col: 13, offset: 2168},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 38, col: 13, offset: 2168},
+							val:        "dt:",
+							ignoreCase: false,
+							want:       "\"dt:\"",
+						},
+						&labeledExpr{
+							pos:   position{line: 38, col: 19, offset: 2174},
+							label: "val",
+							expr: &ruleRefExpr{
+								pos:  position{line: 38, col: 24, offset: 2179},
+								name: "String",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Duration",
+			pos:  position{line: 39, col: 1, offset: 2230},
+			expr: &actionExpr{
+				pos: position{line: 39, col: 13, offset: 2242},
+				run: (*parser).callonDuration1,
+				expr: &seqExpr{
+					pos: position{line: 39, col: 13, offset: 2242},
+					exprs: []interface{}{
+						&zeroOrOneExpr{
+							pos: position{line: 39, col: 13, offset: 2242},
+							expr: &litMatcher{
+								pos:        position{line: 39, col: 13, offset: 2242},
+								val:        "-",
+								ignoreCase: false,
+								want:       "\"-\"",
+							},
+						},
+						&oneOrMoreExpr{
+							pos: position{line: 39, col: 18, offset: 2247},
+							expr: &seqExpr{
+								pos: position{line: 39, col: 19, offset: 2248},
+								exprs: []interface{}{
+									&oneOrMoreExpr{
+										pos: position{line: 39, col: 19, offset: 2248},
+										expr: &charClassMatcher{
+											pos:        position{line: 39, col: 19, offset: 2248},
+											val:        "[0-9]",
+											ranges:     []rune{'0', '9'},
+											ignoreCase: false,
+											inverted:   false,
+										},
+									},
+									&choiceExpr{
+										pos: position{line: 39, col: 27, offset: 2256},
+										alternatives: []interface{}{
+											&litMatcher{
+												pos:        position{line: 39, col: 27, offset: 2256},
+												val:        "ms",
+												ignoreCase: false,
+												want:       "\"ms\"",
+											},
+											&litMatcher{
+												pos:        position{line: 39, col: 34, offset: 2263},
+												val:        "us",
+												ignoreCase: false,
+												want:       "\"us\"",
+											},
+											&litMatcher{
+												pos:        position{line: 39, col: 41, offset: 2270},
+												val:        "ns",
+												ignoreCase: false,
+												want:       "\"ns\"",
+											},
+											&litMatcher{
+												pos:        position{line: 39, col: 48, offset: 2277},
+												val:        "w",
+												ignoreCase: false,
+												want:       "\"w\"",
+											},
+											&litMatcher{
+												pos:        position{line: 39, col: 54, offset: 2283},
+												val:        "d",
+												ignoreCase: false,
+												want:       "\"d\"",
+											},
+											&litMatcher{
+												pos:        position{line: 39, col: 60, offset: 2289},
+												val:        "h",
+												ignoreCase: false,
+												want:       "\"h\"",
+											},
+											&litMatcher{
+												pos:        position{line: 39, col: 66, offset: 2295},
+												val:        "m",
+												ignoreCase: false,
+												want:       "\"m\"",
+											},
+											&litMatcher{
+												pos:        position{line: 39, col: 72, offset: 2301},
+												val:        "s",
+												ignoreCase: false,
+												want:       "\"s\"",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "RelativeDateTime",
+			pos:  position{line: 42, col: 1, offset: 2364},
+			expr: &actionExpr{
+				pos: position{line: 42, col: 21, offset: 2384},
+				run: (*parser).callonRelativeDateTime1,
+				expr: &seqExpr{
+					pos: position{line: 42, col: 21, offset: 2384},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 42, col: 21, offset: 2384},
+							label: "base",
+							expr: &choiceExpr{
+								pos: position{line: 42, col: 27, offset: 2390},
+								alternatives: []interface{}{
+									&ruleRefExpr{
+										pos:  position{line: 42, col: 27, offset: 2390},
+										name: "Now",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 42, col: 33, offset: 2396},
+										name: "Today",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 42, col: 41, offset: 2404},
+										name: "StartOf",
+									},
+								},
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 42, col: 50, offset: 2413},
+							label: "offsets",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 42, col: 58, offset: 2421},
+								expr: &ruleRefExpr{
+									pos:  position{line: 42, col: 59, offset: 2422},
+									name: "Offset",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "RelativeBase",
+			pos:  position{line: 43, col: 1, offset: 2479},
+			expr: &actionExpr{
+				pos: position{line: 43, col: 17, offset: 2495},
+				run: (*parser).callonRelativeBase1,
+				expr: &labeledExpr{
+					pos:   position{line: 43, col: 17, offset: 2495},
+					label: "base",
+					expr: &choiceExpr{
+						pos: position{line: 43, col: 23, offset: 2501},
+						alternatives: []interface{}{
+							&ruleRefExpr{
+								pos:  position{line: 43, col: 23, offset: 2501},
+								name: "Now",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 43, col: 29, offset: 2507},
+								name: "Today",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 43, col: 37, offset: 2515},
+								name: "StartOf",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Now",
+			pos:  position{line: 44, col: 1, offset: 2563},
+			expr: &actionExpr{
+				pos: position{line: 44, col: 8, offset: 2570},
+				run: (*parser).callonNow1,
+				expr: &seqExpr{
+					pos: position{line: 44, col: 8, offset: 2570},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 44, col: 8, offset: 2570},
+							val:        "now",
+							ignoreCase: false,
+							want:       "\"now\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 44, col: 14, offset: 2576},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 44, col: 16, offset: 2578},
+							val:        "(",
+							ignoreCase: false,
+							want:       "\"(\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 44, col: 20, offset: 2582},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 44, col: 22, offset: 2584},
+							val:        ")",
+							ignoreCase: false,
+							want:       "\")\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Today",
+			pos:  position{line: 45, col: 1, offset: 2610},
+			expr: &actionExpr{
+				pos: position{line: 45, col: 10, offset: 2619},
+				run: (*parser).callonToday1,
+				expr: &seqExpr{
+					pos: position{line: 45, col: 10, offset: 2619},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 45, col: 10, offset: 2619},
+							val:        "today",
+							ignoreCase: false,
+							want:       "\"today\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 45, col: 18, offset: 2627},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 45, col: 20, offset: 2629},
+							val:        "(",
+							ignoreCase: false,
+							want:       "\"(\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 45, col: 24, offset: 2633},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 45, col: 26, offset: 2635},
+							val:        ")",
+							ignoreCase: false,
+							want:       "\")\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "StartOf",
+			pos:  position{line: 46, col: 1, offset: 2663},
+			expr: &actionExpr{
+				pos: position{line: 46, col: 12, offset: 2674},
+				run: (*parser).callonStartOf1,
+				expr: &seqExpr{
+					pos: position{line: 46, col: 12, offset: 2674},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 46, col: 12, offset: 2674},
+							val:        "startOf",
+							ignoreCase: false,
+							want:       "\"startOf\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 46, col: 22, offset: 2684},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 46, col: 24, offset: 2686},
+							val:        "(",
+							ignoreCase: false,
+							want:       "\"(\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 46, col: 28, offset: 2690},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 46, col: 30, offset: 2692},
+							label: "unit",
+							expr: &ruleRefExpr{
+								pos:  position{line: 46, col: 36, offset: 2698},
+								name: "String",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 46, col: 44, offset: 2706},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 46, col: 46, offset: 2708},
+							val:        ")",
+							ignoreCase: false,
+							want:       "\")\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Offset",
+			pos:  position{line: 47, col: 1, offset: 2742},
+			expr: &actionExpr{
+				pos: position{line: 47, col: 11, offset: 2752},
+				run: (*parser).callonOffset1,
+				expr: &seqExpr{
+					pos: position{line: 47, col: 11, offset: 2752},
+					exprs: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 47, col: 11, offset: 2752},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 47, col: 13, offset: 2754},
+							label: "sign",
+							expr: &choiceExpr{
+								pos: position{line: 47, col: 19, offset: 2760},
+								alternatives: []interface{}{
+									&litMatcher{
+										pos:        position{line: 47, col: 19, offset: 2760},
+										val:        "+",
+										ignoreCase: false,
+										want:       "\"+\"",
+									},
+									&litMatcher{
+										pos:        position{line: 47, col: 25, offset: 2766},
+										val:        "-",
+										ignoreCase: false,
+										want:       "\"-\"",
+									},
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 47, col: 30, offset: 2771},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 47, col: 32, offset: 2773},
+							label: "duration",
+							expr: &ruleRefExpr{
+								pos:  position{line: 47, col: 42, offset: 2783},
+								name: "Duration",
+							},
+						},
+						&notExpr{
+							pos: position{line: 47, col: 52, offset: 2793},
+							expr: &seqExpr{
+								pos: position{line: 47, col: 54, offset: 2795},
+								exprs: []interface{}{
+									&ruleRefExpr{
+										pos:  position{line: 47, col: 54, offset: 2795},
+										name: "_",
+									},
+									&charClassMatcher{
+										pos:        position{line: 47, col: 56, offset: 2797},
+										val:        "[*/%]",
+										chars:      []rune{'*', '/', '%'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Sum",
+			pos:  position{line: 50, col: 1, offset: 2867},
+			expr: &actionExpr{
+				pos: position{line: 50, col: 8, offset: 2874},
+				run: (*parser).callonSum1,
+				expr: &seqExpr{
+					pos: position{line: 50, col: 8, offset: 2874},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 50, col: 8, offset: 2874},
+							label: "first",
+							expr: &choiceExpr{
+								pos: position{line: 50, col: 15, offset: 2881},
+								alternatives: []interface{}{
+									&ruleRefExpr{
+										pos:  position{line: 50, col: 15, offset: 2881},
+										name: "LeadingDateTime",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 50, col: 33, offset: 2899},
+										name: "Product",
+									},
+								},
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 50, col: 42, offset: 2908},
+							label: "rest",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 50, col: 47, offset: 2913},
+								expr: &seqExpr{
+									pos: position{line: 50, col: 48, offset: 2914},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 50, col: 48, offset: 2914},
+											name: "_",
+										},
+										&choiceExpr{
+											pos: position{line: 50, col: 51, offset: 2917},
+											alternatives: []interface{}{
+												&litMatcher{
+													pos:        position{line: 50, col: 51, offset: 2917},
+													val:        "+",
+													ignoreCase: false,
+													want:       "\"+\"",
+												},
+												&litMatcher{
+													pos:        position{line: 50, col: 57, offset: 2923},
+													val:        "-",
+													ignoreCase: false,
+													want:       "\"-\"",
+												},
+											},
+										},
+										&ruleRefExpr{
+											pos:  position{line: 50, col: 62, offset: 2928},
+											name: "_",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 50, col: 64, offset: 2930},
+											name: "Product",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Product",
+			pos:  position{line: 51, col: 1, offset: 2980},
+			expr: &actionExpr{
+				pos: position{line: 51, col: 12, offset: 2991},
+				run: (*parser).callonProduct1,
+				expr: &seqExpr{
+					pos: position{line: 51, col: 12, offset: 2991},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 51, col: 12, offset: 2991},
+							label: "first",
+							expr: &ruleRefExpr{
+								pos:  position{line: 51, col: 19, offset: 2998},
+								name: "Unary",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 51, col: 26, offset: 3005},
+							label: "rest",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 51, col: 31, offset: 3010},
+								expr: &seqExpr{
+									pos: position{line: 51, col: 32, offset: 3011},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 51, col: 32, offset: 3011},
+											name: "_",
+										},
+										&choiceExpr{
+											pos: position{line: 51, col: 35, offset: 3014},
+											alternatives: []interface{}{
+												&litMatcher{
+													pos:        position{line: 51, col: 35, offset: 3014},
+													val:        "*",
+													ignoreCase: false,
+													want:       "\"*\"",
+												},
+												&litMatcher{
+													pos:        position{line: 51, col: 41, offset: 3020},
+													val:        "/",
+													ignoreCase: false,
+													want:       "\"/\"",
+												},
+												&litMatcher{
+													pos:        position{line: 51, col: 47, offset: 3026},
+													val:        "%",
+													ignoreCase: false,
+													want:       "\"%\"",
+												},
+											},
+										},
+										&ruleRefExpr{
+											pos:  position{line: 51, col: 52, offset: 3031},
+											name: "_",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 51, col: 54, offset: 3033},
+											name: "Unary",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Unary",
+			pos:  position{line: 52, col: 1, offset: 3081},
+			expr: &choiceExpr{
+				pos: position{line: 52, col: 11, offset: 3091},
+				alternatives: []interface{}{
+					&ruleRefExpr{
+						pos:  position{line: 52, col: 11, offset: 3091},
+						name: "Term",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 52, col: 18, offset: 3098},
+						name: "Negation",
+					},
+				},
+			},
+		},
+		{
+			name: "Negation",
+			pos:  position{line: 53, col: 1, offset: 3108},
+			expr: &actionExpr{
+				pos: position{line: 53, col: 13, offset: 3120},
+				run: (*parser).callonNegation1,
+				expr: &seqExpr{
+					pos: position{line: 53, col: 13, offset: 3120},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 53, col: 13, offset: 3120},
+							val:        "-",
+							ignoreCase: false,
+							want:       "\"-\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 53, col: 17, offset: 3124},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 53, col: 19, offset: 3126},
+							label: "value",
+							expr: &ruleRefExpr{
+								pos:  position{line: 53, col: 26, offset: 3133},
+								name: "Unary",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "LeadingDateTime",
+			pos:  position{line: 54, col: 1, offset: 3172},
+			expr: &actionExpr{
+				pos: position{line: 54, col: 20, offset: 3191},
+				run: (*parser).callonLeadingDateTime1,
+				expr: &seqExpr{
+					pos: position{line: 54, col: 20, offset: 3191},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 54, col: 20, offset: 3191},
+							label: "value",
+							expr: &ruleRefExpr{
+								pos:  position{line: 54, col: 27, offset: 3198},
+								name: "RelativeDateTime",
+							},
+						},
+						&notExpr{
+							pos: position{line: 54, col: 45, offset: 3216},
+							expr: &seqExpr{
+								pos: position{line: 54, col: 47, offset: 3218},
+								exprs: []interface{}{
+									&ruleRefExpr{
+										pos:  position{line: 54, col: 47, offset: 3218},
+										name: "_",
+									},
+									&charClassMatcher{
+										pos:        position{line: 54, col: 49, offset: 3220},
+										val:        "[*/%]",
+										chars:      []rune{'*', '/', '%'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Term",
+			pos:  position{line: 55, col: 1, offset: 3249},
+			expr: &choiceExpr{
+				pos: position{line: 55, col: 10, offset: 3258},
+				alternatives: []interface{}{
+					&ruleRefExpr{
+						pos:  position{line: 55, col: 10, offset: 3258},
+						name: "RelativeBase",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 55, col: 25, offset: 3273},
+						name: "Values",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 55, col: 34, offset: 3282},
+						name: "Placeholder",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 55, col: 48, offset: 3296},
+						name: "Reference",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 55, col: 60, offset: 3308},
+						name: "Group",
+					},
+				},
+			},
+		},
+		{
+			name: "Group",
+			pos:  position{line: 56, col: 1, offset: 3315},
+			expr: &actionExpr{
+				pos: position{line: 56, col: 10, offset: 3324},
+				run: (*parser).callonGroup1,
+				expr: &seqExpr{
+					pos: position{line: 56, col: 10, offset: 3324},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 56, col: 10, offset: 3324},
+							val:        "(",
+							ignoreCase: false,
+							want:       "\"(\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 56, col: 14, offset: 3328},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 56, col: 16, offset: 3330},
+							label: "value",
+							expr: &ruleRefExpr{
+								pos:  position{line: 56, col: 23, offset: 3337},
+								name: "Sum",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 56, col: 28, offset: 3342},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 56, col: 30, offset: 3344},
+							val:        ")",
+							ignoreCase: false,
+							want:       "\")\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Placeholder",
+			pos:  position{line: 59, col: 1, offset: 3387},
+			expr: &choiceExpr{
+				pos: position{line: 59, col: 17, offset: 3403},
+				alternatives: []interface{}{
+					&ruleRefExpr{
+						pos:  position{line: 59, col: 17, offset: 3403},
+						name: "NamedPlaceholder",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 59, col: 36, offset: 3422},
+						name: "PositionalPlaceholder",
+					},
+				},
+			},
+		},
+		{
+			name: "NamedPlaceholder",
+			pos:  position{line: 60, col: 1, offset: 3445},
+			expr: &actionExpr{
+				pos: position{line: 60, col: 21, offset: 3465},
+				run: (*parser).callonNamedPlaceholder1,
+				expr: &seqExpr{
+					pos: position{line: 60, col: 21, offset: 3465},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 60, col: 21, offset: 3465},
+							val:        ":",
+							ignoreCase: false,
+							want:       "\":\"",
+						},
+						&labeledExpr{
+							pos:   position{line: 60, col: 25, offset: 3469},
+							label: "name",
+							expr: &ruleRefExpr{
+								pos:  position{line: 60, col: 31, offset: 3475},
+								name: "PlaceholderName",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "PlaceholderName",
+			pos:  position{line: 61, col: 1, offset: 3526},
+			expr: &actionExpr{
+				pos: position{line: 61, col: 20, offset: 3545},
+				run: (*parser).callonPlaceholderName1,
+				expr: &seqExpr{
+					pos: position{line: 61, col: 20, offset: 3545},
+					exprs: []interface{}{
+						&charClassMatcher{
+							pos:        position{line: 61, col: 20, offset: 3545},
+							val:        "[a-zA-Z_]",
+							chars:      []rune{'_'},
+							ranges:     []rune{'a', 'z', 'A', 'Z'},
+							ignoreCase: false,
+							inverted:   false,
+						},
+						&zeroOrMoreExpr{
+							pos: position{line: 61, col: 30, offset: 3555},
+							expr: &charClassMatcher{
+								pos:        position{line: 61, col: 30, offset: 3555},
+								val:        "[a-zA-Z0-9_]",
+								chars:      []rune{'_'},
+								ranges:     []rune{'a', 'z', 'A', 'Z', '0', '9'},
+								ignoreCase: false,
+								inverted:   false,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "PositionalPlaceholder",
+			pos:  position{line: 62, col: 1, offset: 3600},
+			expr: &actionExpr{
+				pos: position{line: 62, col: 26, offset: 3625},
+				run: (*parser).callonPositionalPlaceholder1,
+				expr: &litMatcher{
+					pos:        position{line: 62, col: 26, offset: 3625},
+					val:        "?",
+					ignoreCase: false,
+					want:       "\"?\"",
+				},
+			},
+		},
+		{
+			name: "Reference",
+			pos:  position{line: 65, col: 1, offset: 3676},
+			expr: &actionExpr{
+				pos: position{line: 65, col: 14, offset: 3689},
+				run: (*parser).callonReference1,
+				expr: &seqExpr{
+					pos: position{line: 65, col: 14, offset: 3689},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 65, col: 14, offset: 3689},
+							label: "name",
+							expr: &ruleRefExpr{
+								pos:  position{line: 65, col: 20, offset: 3695},
+								name: "Param",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 65, col: 27, offset: 3702},
+							label: "args",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 65, col: 32, offset: 3707},
+								expr: &ruleRefExpr{
+									pos:  position{line: 65, col: 33, offset: 3708},
+									name: "Arguments",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Arguments",
+			pos:  position{line: 66, col: 1, offset: 3771},
+			expr: &actionExpr{
+				pos: position{line: 66, col: 14, offset: 3784},
+				run: (*parser).callonArguments1,
+				expr: &seqExpr{
+					pos: position{line: 66, col: 14, offset: 3784},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 66, col: 14, offset: 3784},
+							val:        "(",
+							ignoreCase: false,
+							want:       "\"(\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 66, col: 18, offset: 3788},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 66, col: 20, offset: 3790},
+							label: "args",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 66, col: 25, offset: 3795},
+								expr: &ruleRefExpr{
+									pos:  position{line: 66, col: 26, offset: 3796},
+									name: "ArgumentList",
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 66, col: 41, offset: 3811},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 66, col: 43, offset: 3813},
+							val:        ")",
+							ignoreCase: false,
+							want:       "\")\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "ArgumentList",
+			pos:  position{line: 67, col: 1, offset: 3849},
+			expr: &actionExpr{
+				pos: position{line: 67, col: 17, offset: 3865},
+				run: (*parser).callonArgumentList1,
+				expr: &seqExpr{
+					pos: position{line: 67, col: 17, offset: 3865},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 67, col: 17, offset: 3865},
+							label: "first",
+							expr: &ruleRefExpr{
+								pos:  position{line: 67, col: 24, offset: 3872},
+								name: "Argument",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 67, col: 34, offset: 3882},
+							label: "rest",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 67, col: 39, offset: 3887},
+								expr: &seqExpr{
+									pos: position{line: 67, col: 40, offset: 3888},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 67, col: 40, offset: 3888},
+											name: "_",
+										},
+										&litMatcher{
+											pos:        position{line: 67, col: 42, offset: 3890},
+											val:        ",",
+											ignoreCase: false,
+											want:       "\",\"",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 67, col: 46, offset: 3894},
+											name: "_",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 67, col: 48, offset: 3896},
+											name: "Argument",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Argument",
+			pos:  position{line: 68, col: 1, offset: 3949},
+			expr: &choiceExpr{
+				pos: position{line: 68, col: 14, offset: 3962},
+				alternatives: []interface{}{
+					&ruleRefExpr{
+						pos:  position{line: 68, col: 14, offset: 3962},
+						name: "Slice",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 68, col: 22, offset: 3970},
+						name: "Sum",
+					},
+				},
+			},
+		},
+		{
+			name: "Statement",
+			pos:  position{line: 71, col: 1, offset: 3990},
+			expr: &actionExpr{
+				pos: position{line: 71, col: 14, offset: 4003},
+				run: (*parser).callonStatement1,
+				expr: &seqExpr{
+					pos: position{line: 71, col: 14, offset: 4003},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 71, col: 14, offset: 4003},
+							label: "left",
+							expr: &ruleRefExpr{
+								pos:  position{line: 71, col: 20, offset: 4009},
+								name: "Operand",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 71, col: 29, offset: 4018},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 71, col: 31, offset: 4020},
+							label: "op",
+							expr: &choiceExpr{
+								pos: position{line: 71, col: 35, offset: 4024},
+								alternatives: []interface{}{
+									&ruleRefExpr{
+										pos:  position{line: 71, col: 35, offset: 4024},
+										name: "IEqualsOp",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 71, col: 47, offset: 4036},
+										name: "Comparator",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 71, col: 60, offset: 4049},
+										name: "StringOp",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 71, col: 71, offset: 4060},
+										name: "BetweenOp",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 71, col: 83, offset: 4072},
+										name: "IntervalOp",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 71, col: 96, offset: 4085},
+										name: "SliceOp",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 71, col: 106, offset: 4095},
+										name: "ContainOp",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 26, col: 50, offset: 1085},
-										name: "Param",
+										pos:  position{line: 71, col: 118, offset: 4107},
+										name: "RegexpOp",
 									},
 								},
 							},
@@ -586,49 +2003,211 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "LessThanEqual",
-			pos:  position{line: 27, col: 1, offset: 1130},
+			name: "SliceStatement",
+			pos:  position{line: 72, col: 1, offset: 4153},
 			expr: &actionExpr{
-				pos: position{line: 27, col: 18, offset: 1147},
-				run: (*parser).callonLessThanEqual1,
+				pos: position{line: 72, col: 19, offset: 4171},
+				run: (*parser).callonSliceStatement1,
 				expr: &seqExpr{
-					pos: position{line: 27, col: 18, offset: 1147},
+					pos: position{line: 72, col: 19, offset: 4171},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 27, col: 18, offset: 1147},
+							pos:   position{line: 72, col: 19, offset: 4171},
 							label: "left",
 							expr: &ruleRefExpr{
-								pos:  position{line: 27, col: 24, offset: 1153},
-								name: "Param",
+								pos:  position{line: 72, col: 25, offset: 4177},
+								name: "Slice",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 27, col: 31, offset: 1160},
+							pos:  position{line: 72, col: 32, offset: 4184},
 							name: "_",
 						},
-						&litMatcher{
-							pos:        position{line: 27, col: 33, offset: 1162},
-							val:        "<=",
-							ignoreCase: false,
-							want:       "\"<=\"",
+						&labeledExpr{
+							pos:   position{line: 72, col: 34, offset: 4186},
+							label: "op",
+							expr: &ruleRefExpr{
+								pos:  position{line: 72, col: 38, offset: 4190},
+								name: "ContainOp",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Comparator",
+			pos:  position{line: 75, col: 1, offset: 4253},
+			expr: &actionExpr{
+				pos: position{line: 75, col: 15, offset: 4267},
+				run: (*parser).callonComparator1,
+				expr: &seqExpr{
+					pos: position{line: 75, col: 15, offset: 4267},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 75, col: 15, offset: 4267},
+							label: "op",
+							expr: &choiceExpr{
+								pos: position{line: 75, col: 19, offset: 4271},
+								alternatives: []interface{}{
+									&litMatcher{
+										pos:        position{line: 75, col: 19, offset: 4271},
+										val:        "!=",
+										ignoreCase: false,
+										want:       "\"!=\"",
+									},
+									&litMatcher{
+										pos:        position{line: 75, col: 26, offset: 4278},
+										val:        "=",
+										ignoreCase: false,
+										want:       "\"=\"",
+									},
+									&litMatcher{
+										pos:        position{line: 75, col: 32, offset: 4284},
+										val:        ">=",
+										ignoreCase: false,
+										want:       "\">=\"",
+									},
+									&litMatcher{
+										pos:        position{line: 75, col: 39, offset: 4291},
+										val:        ">",
+										ignoreCase: false,
+										want:       "\">\"",
+									},
+									&litMatcher{
+										pos:        position{line: 75, col: 45, offset: 4297},
+										val:        "<=",
+										ignoreCase: false,
+										want:       "\"<=\"",
+									},
+									&litMatcher{
+										pos:        position{line: 75, col: 52, offset: 4304},
+										val:        "<",
+										ignoreCase: false,
+										want:       "\"<\"",
+									},
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 75, col: 57, offset: 4309},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 75, col: 59, offset: 4311},
+							label: "right",
+							expr: &ruleRefExpr{
+								pos:  position{line: 75, col: 66, offset: 4318},
+								name: "Operand",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "StringOp",
+			pos:  position{line: 78, col: 1, offset: 4383},
+			expr: &actionExpr{
+				pos: position{line: 78, col: 13, offset: 4395},
+				run: (*parser).callonStringOp1,
+				expr: &seqExpr{
+					pos: position{line: 78, col: 13, offset: 4395},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 78, col: 13, offset: 4395},
+							label: "op",
+							expr: &choiceExpr{
+								pos: position{line: 78, col: 17, offset: 4399},
+								alternatives: []interface{}{
+									&litMatcher{
+										pos:        position{line: 78, col: 17, offset: 4399},
+										val:        "starts_with",
+										ignoreCase: false,
+										want:       "\"starts_with\"",
+									},
+									&litMatcher{
+										pos:        position{line: 78, col: 33, offset: 4415},
+										val:        "ends_with",
+										ignoreCase: false,
+										want:       "\"ends_with\"",
+									},
+									&litMatcher{
+										pos:        position{line: 78, col: 47, offset: 4429},
+										val:        "istarts_with",
+										ignoreCase: false,
+										want:       "\"istarts_with\"",
+									},
+									&litMatcher{
+										pos:        position{line: 78, col: 64, offset: 4446},
+										val:        "iends_with",
+										ignoreCase: false,
+										want:       "\"iends_with\"",
+									},
+									&litMatcher{
+										pos:        position{line: 78, col: 79, offset: 4461},
+										val:        "contains",
+										ignoreCase: false,
+										want:       "\"contains\"",
+									},
+									&litMatcher{
+										pos:        position{line: 78, col: 92, offset: 4474},
+										val:        "not_contains",
+										ignoreCase: false,
+										want:       "\"not_contains\"",
+									},
+									&litMatcher{
+										pos:        position{line: 78, col: 109, offset: 4491},
+										val:        "like",
+										ignoreCase: false,
+										want:       "\"like\"",
+									},
+									&litMatcher{
+										pos:        position{line: 78, col: 118, offset: 4500},
+										val:        "not_like",
+										ignoreCase: false,
+										want:       "\"not_like\"",
+									},
+									&litMatcher{
+										pos:        position{line: 78, col: 131, offset: 4513},
+										val:        "ilike",
+										ignoreCase: false,
+										want:       "\"ilike\"",
+									},
+									&litMatcher{
+										pos:        position{line: 78, col: 141, offset: 4523},
+										val:        "glob",
+										ignoreCase: false,
+										want:       "\"glob\"",
+									},
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 78, col: 149, offset: 4531},
+							name: "EndOfWord",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 27, col: 38, offset: 1167},
+							pos:  position{line: 78, col: 159, offset: 4541},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 27, col: 40, offset: 1169},
+							pos:   position{line: 78, col: 161, offset: 4543},
 							label: "right",
 							expr: &choiceExpr{
-								pos: position{line: 27, col: 47, offset: 1176},
+								pos: position{line: 78, col: 168, offset: 4550},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 27, col: 47, offset: 1176},
-										name: "Values",
+										pos:  position{line: 78, col: 168, offset: 4550},
+										name: "String",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 78, col: 177, offset: 4559},
+										name: "Placeholder",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 27, col: 56, offset: 1185},
-										name: "Param",
+										pos:  position{line: 78, col: 191, offset: 4573},
+										name: "Reference",
 									},
 								},
 							},
@@ -638,49 +2217,171 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "GreaterThan",
-			pos:  position{line: 28, col: 1, offset: 1235},
+			name: "IEqualsOp",
+			pos:  position{line: 79, col: 1, offset: 4628},
 			expr: &actionExpr{
-				pos: position{line: 28, col: 16, offset: 1250},
-				run: (*parser).callonGreaterThan1,
+				pos: position{line: 79, col: 14, offset: 4641},
+				run: (*parser).callonIEqualsOp1,
 				expr: &seqExpr{
-					pos: position{line: 28, col: 16, offset: 1250},
+					pos: position{line: 79, col: 14, offset: 4641},
 					exprs: []interface{}{
-						&labeledExpr{
-							pos:   position{line: 28, col: 16, offset: 1250},
-							label: "left",
-							expr: &ruleRefExpr{
-								pos:  position{line: 28, col: 22, offset: 1256},
-								name: "Param",
+						&choiceExpr{
+							pos: position{line: 79, col: 15, offset: 4642},
+							alternatives: []interface{}{
+								&litMatcher{
+									pos:        position{line: 79, col: 15, offset: 4642},
+									val:        "=*",
+									ignoreCase: false,
+									want:       "\"=*\"",
+								},
+								&seqExpr{
+									pos: position{line: 79, col: 22, offset: 4649},
+									exprs: []interface{}{
+										&litMatcher{
+											pos:        position{line: 79, col: 22, offset: 4649},
+											val:        "ieq",
+											ignoreCase: false,
+											want:       "\"ieq\"",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 79, col: 28, offset: 4655},
+											name: "EndOfWord",
+										},
+									},
+								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 28, col: 29, offset: 1263},
+							pos:  position{line: 79, col: 39, offset: 4666},
 							name: "_",
 						},
+						&labeledExpr{
+							pos:   position{line: 79, col: 41, offset: 4668},
+							label: "right",
+							expr: &choiceExpr{
+								pos: position{line: 79, col: 48, offset: 4675},
+								alternatives: []interface{}{
+									&ruleRefExpr{
+										pos:  position{line: 79, col: 48, offset: 4675},
+										name: "String",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 79, col: 57, offset: 4684},
+										name: "Placeholder",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 79, col: 71, offset: 4698},
+										name: "Reference",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Slice",
+			pos:  position{line: 82, col: 1, offset: 4765},
+			expr: &actionExpr{
+				pos: position{line: 82, col: 10, offset: 4774},
+				run: (*parser).callonSlice1,
+				expr: &seqExpr{
+					pos: position{line: 82, col: 10, offset: 4774},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 82, col: 10, offset: 4774},
+							val:        "[",
+							ignoreCase: false,
+							want:       "\"[\"",
+						},
+						&labeledExpr{
+							pos:   position{line: 82, col: 14, offset: 4778},
+							label: "elements",
+							expr: &oneOrMoreExpr{
+								pos: position{line: 82, col: 23, offset: 4787},
+								expr: &choiceExpr{
+									pos: position{line: 82, col: 24, offset: 4788},
+									alternatives: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 82, col: 24, offset: 4788},
+											name: "Values",
+										},
+										&litMatcher{
+											pos:        position{line: 82, col: 33, offset: 4797},
+											val:        ",",
+											ignoreCase: false,
+											want:       "\",\"",
+										},
+									},
+								},
+							},
+						},
 						&litMatcher{
-							pos:        position{line: 28, col: 31, offset: 1265},
-							val:        ">",
+							pos:        position{line: 82, col: 39, offset: 4803},
+							val:        "]",
 							ignoreCase: false,
-							want:       "\">\"",
+							want:       "\"]\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "SliceOp",
+			pos:  position{line: 83, col: 1, offset: 4839},
+			expr: &actionExpr{
+				pos: position{line: 83, col: 12, offset: 4850},
+				run: (*parser).callonSliceOp1,
+				expr: &seqExpr{
+					pos: position{line: 83, col: 12, offset: 4850},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 83, col: 12, offset: 4850},
+							label: "op",
+							expr: &choiceExpr{
+								pos: position{line: 83, col: 16, offset: 4854},
+								alternatives: []interface{}{
+									&litMatcher{
+										pos:        position{line: 83, col: 16, offset: 4854},
+										val:        "not_in",
+										ignoreCase: false,
+										want:       "\"not_in\"",
+									},
+									&litMatcher{
+										pos:        position{line: 83, col: 27, offset: 4865},
+										val:        "in",
+										ignoreCase: false,
+										want:       "\"in\"",
+									},
+								},
+							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 28, col: 35, offset: 1269},
+							pos:  position{line: 83, col: 33, offset: 4871},
+							name: "EndOfWord",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 83, col: 43, offset: 4881},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 28, col: 37, offset: 1271},
+							pos:   position{line: 83, col: 45, offset: 4883},
 							label: "right",
 							expr: &choiceExpr{
-								pos: position{line: 28, col: 44, offset: 1278},
+								pos: position{line: 83, col: 52, offset: 4890},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 28, col: 44, offset: 1278},
-										name: "Values",
+										pos:  position{line: 83, col: 52, offset: 4890},
+										name: "Slice",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 83, col: 60, offset: 4898},
+										name: "Placeholder",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 28, col: 53, offset: 1287},
-										name: "Param",
+										pos:  position{line: 83, col: 74, offset: 4912},
+										name: "Reference",
 									},
 								},
 							},
@@ -690,51 +2391,75 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "GreaterThanEqual",
-			pos:  position{line: 29, col: 1, offset: 1335},
+			name: "BetweenOp",
+			pos:  position{line: 86, col: 1, offset: 4978},
 			expr: &actionExpr{
-				pos: position{line: 29, col: 21, offset: 1355},
-				run: (*parser).callonGreaterThanEqual1,
+				pos: position{line: 86, col: 14, offset: 4991},
+				run: (*parser).callonBetweenOp1,
 				expr: &seqExpr{
-					pos: position{line: 29, col: 21, offset: 1355},
+					pos: position{line: 86, col: 14, offset: 4991},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 29, col: 21, offset: 1355},
-							label: "left",
+							pos:   position{line: 86, col: 14, offset: 4991},
+							label: "op",
+							expr: &choiceExpr{
+								pos: position{line: 86, col: 18, offset: 4995},
+								alternatives: []interface{}{
+									&litMatcher{
+										pos:        position{line: 86, col: 18, offset: 4995},
+										val:        "not_between",
+										ignoreCase: false,
+										want:       "\"not_between\"",
+									},
+									&litMatcher{
+										pos:        position{line: 86, col: 34, offset: 5011},
+										val:        "between",
+										ignoreCase: false,
+										want:       "\"between\"",
+									},
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 86, col: 45, offset: 5022},
+							name: "EndOfWord",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 86, col: 55, offset: 5032},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 86, col: 57, offset: 5034},
+							label: "from",
 							expr: &ruleRefExpr{
-								pos:  position{line: 29, col: 27, offset: 1361},
-								name: "Param",
+								pos:  position{line: 86, col: 63, offset: 5040},
+								name: "Operand",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 29, col: 34, offset: 1368},
+							pos:  position{line: 86, col: 72, offset: 5049},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 29, col: 36, offset: 1370},
-							val:        ">=",
+							pos:        position{line: 86, col: 74, offset: 5051},
+							val:        "and",
 							ignoreCase: false,
-							want:       "\">=\"",
+							want:       "\"and\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 86, col: 80, offset: 5057},
+							name: "EndOfWord",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 29, col: 41, offset: 1375},
+							pos:  position{line: 86, col: 90, offset: 5067},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 29, col: 43, offset: 1377},
-							label: "right",
-							expr: &choiceExpr{
-								pos: position{line: 29, col: 50, offset: 1384},
-								alternatives: []interface{}{
-									&ruleRefExpr{
-										pos:  position{line: 29, col: 50, offset: 1384},
-										name: "Values",
-									},
-									&ruleRefExpr{
-										pos:  position{line: 29, col: 59, offset: 1393},
-										name: "Param",
-									},
-								},
+							pos:   position{line: 86, col: 92, offset: 5069},
+							label: "to",
+							expr: &ruleRefExpr{
+								pos:  position{line: 86, col: 96, offset: 5073},
+								name: "Operand",
 							},
 						},
 					},
@@ -742,118 +2467,212 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "StringOps",
-			pos:  position{line: 32, col: 1, offset: 1458},
-			expr: &choiceExpr{
-				pos: position{line: 32, col: 15, offset: 1472},
-				alternatives: []interface{}{
-					&ruleRefExpr{
-						pos:  position{line: 32, col: 15, offset: 1472},
-						name: "StartsWith",
-					},
-					&ruleRefExpr{
-						pos:  position{line: 32, col: 28, offset: 1485},
-						name: "EndsWith",
+			name: "IntervalOp",
+			pos:  position{line: 87, col: 1, offset: 5127},
+			expr: &actionExpr{
+				pos: position{line: 87, col: 15, offset: 5141},
+				run: (*parser).callonIntervalOp1,
+				expr: &seqExpr{
+					pos: position{line: 87, col: 15, offset: 5141},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 87, col: 15, offset: 5141},
+							label: "op",
+							expr: &choiceExpr{
+								pos: position{line: 87, col: 19, offset: 5145},
+								alternatives: []interface{}{
+									&litMatcher{
+										pos:        position{line: 87, col: 19, offset: 5145},
+										val:        "not_in",
+										ignoreCase: false,
+										want:       "\"not_in\"",
+									},
+									&litMatcher{
+										pos:        position{line: 87, col: 30, offset: 5156},
+										val:        "in",
+										ignoreCase: false,
+										want:       "\"in\"",
+									},
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 87, col: 36, offset: 5162},
+							name: "EndOfWord",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 87, col: 46, offset: 5172},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 87, col: 48, offset: 5174},
+							label: "lower",
+							expr: &choiceExpr{
+								pos: position{line: 87, col: 55, offset: 5181},
+								alternatives: []interface{}{
+									&litMatcher{
+										pos:        position{line: 87, col: 55, offset: 5181},
+										val:        "[",
+										ignoreCase: false,
+										want:       "\"[\"",
+									},
+									&litMatcher{
+										pos:        position{line: 87, col: 61, offset: 5187},
+										val:        "(",
+										ignoreCase: false,
+										want:       "\"(\"",
+									},
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 87, col: 66, offset: 5192},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 87, col: 68, offset: 5194},
+							label: "from",
+							expr: &ruleRefExpr{
+								pos:  position{line: 87, col: 74, offset: 5200},
+								name: "Operand",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 87, col: 83, offset: 5209},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 87, col: 85, offset: 5211},
+							val:        "..",
+							ignoreCase: false,
+							want:       "\"..\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 87, col: 90, offset: 5216},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 87, col: 92, offset: 5218},
+							label: "to",
+							expr: &ruleRefExpr{
+								pos:  position{line: 87, col: 96, offset: 5222},
+								name: "Operand",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 87, col: 105, offset: 5231},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 87, col: 107, offset: 5233},
+							label: "upper",
+							expr: &choiceExpr{
+								pos: position{line: 87, col: 114, offset: 5240},
+								alternatives: []interface{}{
+									&litMatcher{
+										pos:        position{line: 87, col: 114, offset: 5240},
+										val:        "]",
+										ignoreCase: false,
+										want:       "\"]\"",
+									},
+									&litMatcher{
+										pos:        position{line: 87, col: 120, offset: 5246},
+										val:        ")",
+										ignoreCase: false,
+										want:       "\")\"",
+									},
+								},
+							},
+						},
 					},
 				},
 			},
 		},
 		{
-			name: "StartsWith",
-			pos:  position{line: 33, col: 1, offset: 1495},
+			name: "Exists",
+			pos:  position{line: 90, col: 1, offset: 5322},
 			expr: &actionExpr{
-				pos: position{line: 33, col: 15, offset: 1509},
-				run: (*parser).callonStartsWith1,
+				pos: position{line: 90, col: 11, offset: 5332},
+				run: (*parser).callonExists1,
 				expr: &seqExpr{
-					pos: position{line: 33, col: 15, offset: 1509},
+					pos: position{line: 90, col: 11, offset: 5332},
 					exprs: []interface{}{
-						&labeledExpr{
-							pos:   position{line: 33, col: 15, offset: 1509},
-							label: "left",
-							expr: &ruleRefExpr{
-								pos:  position{line: 33, col: 21, offset: 1515},
-								name: "Param",
-							},
+						&litMatcher{
+							pos:        position{line: 90, col: 11, offset: 5332},
+							val:        "exists",
+							ignoreCase: false,
+							want:       "\"exists\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 33, col: 28, offset: 1522},
+							pos:  position{line: 90, col: 20, offset: 5341},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 33, col: 30, offset: 1524},
-							val:        "starts_with",
+							pos:        position{line: 90, col: 22, offset: 5343},
+							val:        "(",
 							ignoreCase: false,
-							want:       "\"starts_with\"",
+							want:       "\"(\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 33, col: 44, offset: 1538},
+							pos:  position{line: 90, col: 26, offset: 5347},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 33, col: 46, offset: 1540},
-							label: "right",
-							expr: &choiceExpr{
-								pos: position{line: 33, col: 53, offset: 1547},
-								alternatives: []interface{}{
-									&ruleRefExpr{
-										pos:  position{line: 33, col: 53, offset: 1547},
-										name: "String",
-									},
-									&ruleRefExpr{
-										pos:  position{line: 33, col: 62, offset: 1556},
-										name: "Param",
-									},
-								},
+							pos:   position{line: 90, col: 28, offset: 5349},
+							label: "param",
+							expr: &ruleRefExpr{
+								pos:  position{line: 90, col: 35, offset: 5356},
+								name: "Param",
 							},
 						},
+						&ruleRefExpr{
+							pos:  position{line: 90, col: 42, offset: 5363},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 90, col: 44, offset: 5365},
+							val:        ")",
+							ignoreCase: false,
+							want:       "\")\"",
+						},
 					},
 				},
 			},
 		},
 		{
-			name: "EndsWith",
-			pos:  position{line: 34, col: 1, offset: 1603},
+			name: "Check",
+			pos:  position{line: 91, col: 1, offset: 5408},
 			expr: &actionExpr{
-				pos: position{line: 34, col: 13, offset: 1615},
-				run: (*parser).callonEndsWith1,
+				pos: position{line: 91, col: 10, offset: 5417},
+				run: (*parser).callonCheck1,
 				expr: &seqExpr{
-					pos: position{line: 34, col: 13, offset: 1615},
+					pos: position{line: 91, col: 10, offset: 5417},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 34, col: 13, offset: 1615},
-							label: "left",
+							pos:   position{line: 91, col: 10, offset: 5417},
+							label: "param",
 							expr: &ruleRefExpr{
-								pos:  position{line: 34, col: 19, offset: 1621},
+								pos:  position{line: 91, col: 17, offset: 5424},
 								name: "Param",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 34, col: 26, offset: 1628},
-							name: "_",
-						},
-						&litMatcher{
-							pos:        position{line: 34, col: 28, offset: 1630},
-							val:        "ends_with",
-							ignoreCase: false,
-							want:       "\"ends_with\"",
-						},
-						&ruleRefExpr{
-							pos:  position{line: 34, col: 40, offset: 1642},
+							pos:  position{line: 91, col: 24, offset: 5431},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 34, col: 42, offset: 1644},
-							label: "right",
+							pos:   position{line: 91, col: 26, offset: 5433},
+							label: "op",
 							expr: &choiceExpr{
-								pos: position{line: 34, col: 49, offset: 1651},
+								pos: position{line: 91, col: 30, offset: 5437},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 34, col: 49, offset: 1651},
-										name: "String",
+										pos:  position{line: 91, col: 30, offset: 5437},
+										name: "IsOp",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 34, col: 58, offset: 1660},
-										name: "Param",
+										pos:  position{line: 91, col: 37, offset: 5444},
+										name: "ExistsOp",
 									},
 								},
 							},
@@ -863,260 +2682,236 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "SliceOps",
-			pos:  position{line: 37, col: 1, offset: 1716},
-			expr: &choiceExpr{
-				pos: position{line: 37, col: 14, offset: 1729},
-				alternatives: []interface{}{
-					&ruleRefExpr{
-						pos:  position{line: 37, col: 14, offset: 1729},
-						name: "InSlice",
-					},
-					&ruleRefExpr{
-						pos:  position{line: 37, col: 24, offset: 1739},
-						name: "NotInSlice",
-					},
-				},
-			},
-		},
-		{
-			name: "Slice",
-			pos:  position{line: 38, col: 1, offset: 1751},
+			name: "IsOp",
+			pos:  position{line: 92, col: 1, offset: 5487},
 			expr: &actionExpr{
-				pos: position{line: 38, col: 10, offset: 1760},
-				run: (*parser).callonSlice1,
+				pos: position{line: 92, col: 9, offset: 5495},
+				run: (*parser).callonIsOp1,
 				expr: &seqExpr{
-					pos: position{line: 38, col: 10, offset: 1760},
+					pos: position{line: 92, col: 9, offset: 5495},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 38, col: 10, offset: 1760},
-							val:        "[",
+							pos:        position{line: 92, col: 9, offset: 5495},
+							val:        "is",
 							ignoreCase: false,
-							want:       "\"[\"",
+							want:       "\"is\"",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 92, col: 14, offset: 5500},
+							name: "EndOfWord",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 92, col: 24, offset: 5510},
+							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 38, col: 14, offset: 1764},
-							label: "elements",
-							expr: &oneOrMoreExpr{
-								pos: position{line: 38, col: 23, offset: 1773},
-								expr: &choiceExpr{
-									pos: position{line: 38, col: 24, offset: 1774},
-									alternatives: []interface{}{
-										&ruleRefExpr{
-											pos:  position{line: 38, col: 24, offset: 1774},
-											name: "Values",
-										},
+							pos:   position{line: 92, col: 26, offset: 5512},
+							label: "not",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 92, col: 30, offset: 5516},
+								expr: &seqExpr{
+									pos: position{line: 92, col: 31, offset: 5517},
+									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 38, col: 33, offset: 1783},
-											val:        ",",
+											pos:        position{line: 92, col: 31, offset: 5517},
+											val:        "not",
 											ignoreCase: false,
-											want:       "\",\"",
+											want:       "\"not\"",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 92, col: 37, offset: 5523},
+											name: "EndOfWord",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 92, col: 47, offset: 5533},
+											name: "_",
 										},
 									},
 								},
 							},
 						},
-						&litMatcher{
-							pos:        position{line: 38, col: 39, offset: 1789},
-							val:        "]",
-							ignoreCase: false,
-							want:       "\"]\"",
+						&labeledExpr{
+							pos:   position{line: 92, col: 51, offset: 5537},
+							label: "what",
+							expr: &choiceExpr{
+								pos: position{line: 92, col: 57, offset: 5543},
+								alternatives: []interface{}{
+									&litMatcher{
+										pos:        position{line: 92, col: 57, offset: 5543},
+										val:        "null",
+										ignoreCase: false,
+										want:       "\"null\"",
+									},
+									&litMatcher{
+										pos:        position{line: 92, col: 66, offset: 5552},
+										val:        "empty",
+										ignoreCase: false,
+										want:       "\"empty\"",
+									},
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 92, col: 75, offset: 5561},
+							name: "EndOfWord",
 						},
 					},
 				},
 			},
 		},
 		{
-			name: "InSlice",
-			pos:  position{line: 39, col: 1, offset: 1825},
+			name: "ExistsOp",
+			pos:  position{line: 93, col: 1, offset: 5609},
 			expr: &actionExpr{
-				pos: position{line: 39, col: 12, offset: 1836},
-				run: (*parser).callonInSlice1,
+				pos: position{line: 93, col: 13, offset: 5621},
+				run: (*parser).callonExistsOp1,
 				expr: &seqExpr{
-					pos: position{line: 39, col: 12, offset: 1836},
+					pos: position{line: 93, col: 13, offset: 5621},
 					exprs: []interface{}{
-						&labeledExpr{
-							pos:   position{line: 39, col: 12, offset: 1836},
-							label: "left",
-							expr: &ruleRefExpr{
-								pos:  position{line: 39, col: 18, offset: 1842},
-								name: "Param",
-							},
-						},
-						&ruleRefExpr{
-							pos:  position{line: 39, col: 25, offset: 1849},
-							name: "_",
-						},
 						&litMatcher{
-							pos:        position{line: 39, col: 27, offset: 1851},
-							val:        "in",
+							pos:        position{line: 93, col: 13, offset: 5621},
+							val:        "exists",
 							ignoreCase: false,
-							want:       "\"in\"",
+							want:       "\"exists\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 39, col: 32, offset: 1856},
-							name: "_",
-						},
-						&labeledExpr{
-							pos:   position{line: 39, col: 34, offset: 1858},
-							label: "right",
-							expr: &ruleRefExpr{
-								pos:  position{line: 39, col: 41, offset: 1865},
-								name: "Slice",
-							},
+							pos:  position{line: 93, col: 22, offset: 5630},
+							name: "EndOfWord",
 						},
 					},
 				},
 			},
 		},
 		{
-			name: "NotInSlice",
-			pos:  position{line: 40, col: 1, offset: 1909},
+			name: "Quantifier",
+			pos:  position{line: 96, col: 1, offset: 5681},
 			expr: &actionExpr{
-				pos: position{line: 40, col: 15, offset: 1923},
-				run: (*parser).callonNotInSlice1,
+				pos: position{line: 96, col: 15, offset: 5695},
+				run: (*parser).callonQuantifier1,
 				expr: &seqExpr{
-					pos: position{line: 40, col: 15, offset: 1923},
+					pos: position{line: 96, col: 15, offset: 5695},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 40, col: 15, offset: 1923},
-							label: "left",
-							expr: &ruleRefExpr{
-								pos:  position{line: 40, col: 21, offset: 1929},
-								name: "Param",
+							pos:   position{line: 96, col: 15, offset: 5695},
+							label: "q",
+							expr: &choiceExpr{
+								pos: position{line: 96, col: 18, offset: 5698},
+								alternatives: []interface{}{
+									&litMatcher{
+										pos:        position{line: 96, col: 18, offset: 5698},
+										val:        "any",
+										ignoreCase: false,
+										want:       "\"any\"",
+									},
+									&litMatcher{
+										pos:        position{line: 96, col: 26, offset: 5706},
+										val:        "all",
+										ignoreCase: false,
+										want:       "\"all\"",
+									},
+								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 40, col: 28, offset: 1936},
+							pos:  position{line: 96, col: 33, offset: 5713},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 40, col: 30, offset: 1938},
-							val:        "not_in",
+							pos:        position{line: 96, col: 35, offset: 5715},
+							val:        "(",
 							ignoreCase: false,
-							want:       "\"not_in\"",
+							want:       "\"(\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 40, col: 39, offset: 1947},
+							pos:  position{line: 96, col: 39, offset: 5719},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 40, col: 41, offset: 1949},
-							label: "right",
-							expr: &ruleRefExpr{
-								pos:  position{line: 40, col: 48, offset: 1956},
-								name: "Slice",
-							},
-						},
-					},
-				},
-			},
-		},
-		{
-			name: "ContainOps",
-			pos:  position{line: 43, col: 1, offset: 2016},
-			expr: &choiceExpr{
-				pos: position{line: 43, col: 16, offset: 2031},
-				alternatives: []interface{}{
-					&ruleRefExpr{
-						pos:  position{line: 43, col: 16, offset: 2031},
-						name: "Has",
-					},
-					&ruleRefExpr{
-						pos:  position{line: 43, col: 22, offset: 2037},
-						name: "NotHas",
-					},
-					&ruleRefExpr{
-						pos:  position{line: 43, col: 31, offset: 2046},
-						name: "HasAny",
-					},
-					&ruleRefExpr{
-						pos:  position{line: 43, col: 40, offset: 2055},
-						name: "HasAll",
-					},
-				},
-			},
-		},
-		{
-			name: "Has",
-			pos:  position{line: 44, col: 1, offset: 2063},
-			expr: &actionExpr{
-				pos: position{line: 44, col: 8, offset: 2070},
-				run: (*parser).callonHas1,
-				expr: &seqExpr{
-					pos: position{line: 44, col: 8, offset: 2070},
-					exprs: []interface{}{
-						&labeledExpr{
-							pos:   position{line: 44, col: 8, offset: 2070},
-							label: "left",
+							pos:   position{line: 96, col: 41, offset: 5721},
+							label: "param",
 							expr: &ruleRefExpr{
-								pos:  position{line: 44, col: 14, offset: 2076},
+								pos:  position{line: 96, col: 48, offset: 5728},
 								name: "Param",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 44, col: 21, offset: 2083},
+							pos:  position{line: 96, col: 55, offset: 5735},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 44, col: 23, offset: 2085},
-							val:        "has",
+							pos:        position{line: 96, col: 57, offset: 5737},
+							val:        ",",
 							ignoreCase: false,
-							want:       "\"has\"",
+							want:       "\",\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 44, col: 29, offset: 2091},
+							pos:  position{line: 96, col: 61, offset: 5741},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 44, col: 31, offset: 2093},
-							label: "right",
+							pos:   position{line: 96, col: 63, offset: 5743},
+							label: "expr",
 							expr: &ruleRefExpr{
-								pos:  position{line: 44, col: 38, offset: 2100},
-								name: "Values",
+								pos:  position{line: 96, col: 69, offset: 5749},
+								name: "Expr",
 							},
 						},
+						&ruleRefExpr{
+							pos:  position{line: 96, col: 75, offset: 5755},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 96, col: 77, offset: 5757},
+							val:        ")",
+							ignoreCase: false,
+							want:       "\")\"",
+						},
 					},
 				},
 			},
 		},
 		{
-			name: "NotHas",
-			pos:  position{line: 45, col: 1, offset: 2141},
+			name: "Element",
+			pos:  position{line: 97, col: 1, offset: 5804},
 			expr: &actionExpr{
-				pos: position{line: 45, col: 11, offset: 2151},
-				run: (*parser).callonNotHas1,
+				pos: position{line: 97, col: 12, offset: 5815},
+				run: (*parser).callonElement1,
 				expr: &seqExpr{
-					pos: position{line: 45, col: 11, offset: 2151},
+					pos: position{line: 97, col: 12, offset: 5815},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 45, col: 11, offset: 2151},
-							label: "left",
+							pos:   position{line: 97, col: 12, offset: 5815},
+							label: "param",
 							expr: &ruleRefExpr{
-								pos:  position{line: 45, col: 17, offset: 2157},
+								pos:  position{line: 97, col: 19, offset: 5822},
 								name: "Param",
 							},
 						},
-						&ruleRefExpr{
-							pos:  position{line: 45, col: 24, offset: 2164},
-							name: "_",
-						},
 						&litMatcher{
-							pos:        position{line: 45, col: 26, offset: 2166},
-							val:        "not_has",
+							pos:        position{line: 97, col: 26, offset: 5829},
+							val:        "[*].",
 							ignoreCase: false,
-							want:       "\"not_has\"",
-						},
-						&ruleRefExpr{
-							pos:  position{line: 45, col: 36, offset: 2176},
-							name: "_",
+							want:       "\"[*].\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 45, col: 38, offset: 2178},
-							label: "right",
-							expr: &ruleRefExpr{
-								pos:  position{line: 45, col: 45, offset: 2185},
-								name: "Values",
+							pos:   position{line: 97, col: 33, offset: 5836},
+							label: "expr",
+							expr: &choiceExpr{
+								pos: position{line: 97, col: 39, offset: 5842},
+								alternatives: []interface{}{
+									&ruleRefExpr{
+										pos:  position{line: 97, col: 39, offset: 5842},
+										name: "Element",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 97, col: 49, offset: 5852},
+										name: "Statement",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 97, col: 61, offset: 5864},
+										name: "Check",
+									},
+								},
 							},
 						},
 					},
@@ -1124,42 +2919,79 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "HasAny",
-			pos:  position{line: 46, col: 1, offset: 2229},
+			name: "ContainOp",
+			pos:  position{line: 100, col: 1, offset: 5939},
+			expr: &choiceExpr{
+				pos: position{line: 100, col: 15, offset: 5953},
+				alternatives: []interface{}{
+					&ruleRefExpr{
+						pos:  position{line: 100, col: 15, offset: 5953},
+						name: "HasSliceOp",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 100, col: 28, offset: 5966},
+						name: "HasOp",
+					},
+				},
+			},
+		},
+		{
+			name: "HasSliceOp",
+			pos:  position{line: 101, col: 1, offset: 5973},
 			expr: &actionExpr{
-				pos: position{line: 46, col: 11, offset: 2239},
-				run: (*parser).callonHasAny1,
+				pos: position{line: 101, col: 15, offset: 5987},
+				run: (*parser).callonHasSliceOp1,
 				expr: &seqExpr{
-					pos: position{line: 46, col: 11, offset: 2239},
+					pos: position{line: 101, col: 15, offset: 5987},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 46, col: 11, offset: 2239},
-							label: "left",
-							expr: &ruleRefExpr{
-								pos:  position{line: 46, col: 17, offset: 2245},
-								name: "Param",
+							pos:   position{line: 101, col: 15, offset: 5987},
+							label: "op",
+							expr: &choiceExpr{
+								pos: position{line: 101, col: 19, offset: 5991},
+								alternatives: []interface{}{
+									&litMatcher{
+										pos:        position{line: 101, col: 19, offset: 5991},
+										val:        "has_any",
+										ignoreCase: false,
+										want:       "\"has_any\"",
+									},
+									&litMatcher{
+										pos:        position{line: 101, col: 31, offset: 6003},
+										val:        "has_all",
+										ignoreCase: false,
+										want:       "\"has_all\"",
+									},
+								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 46, col: 24, offset: 2252},
-							name: "_",
-						},
-						&litMatcher{
-							pos:        position{line: 46, col: 26, offset: 2254},
-							val:        "has_any",
-							ignoreCase: false,
-							want:       "\"has_any\"",
+							pos:  position{line: 101, col: 42, offset: 6014},
+							name: "EndOfWord",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 46, col: 36, offset: 2264},
+							pos:  position{line: 101, col: 52, offset: 6024},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 46, col: 38, offset: 2266},
+							pos:   position{line: 101, col: 54, offset: 6026},
 							label: "right",
-							expr: &ruleRefExpr{
-								pos:  position{line: 46, col: 45, offset: 2273},
-								name: "Slice",
+							expr: &choiceExpr{
+								pos: position{line: 101, col: 61, offset: 6033},
+								alternatives: []interface{}{
+									&ruleRefExpr{
+										pos:  position{line: 101, col: 61, offset: 6033},
+										name: "Slice",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 101, col: 69, offset: 6041},
+										name: "Placeholder",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 101, col: 83, offset: 6055},
+										name: "Reference",
+									},
+								},
 							},
 						},
 					},
@@ -1167,147 +2999,113 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "HasAll",
-			pos:  position{line: 47, col: 1, offset: 2316},
+			name: "HasOp",
+			pos:  position{line: 102, col: 1, offset: 6110},
 			expr: &actionExpr{
-				pos: position{line: 47, col: 11, offset: 2326},
-				run: (*parser).callonHasAll1,
+				pos: position{line: 102, col: 10, offset: 6119},
+				run: (*parser).callonHasOp1,
 				expr: &seqExpr{
-					pos: position{line: 47, col: 11, offset: 2326},
+					pos: position{line: 102, col: 10, offset: 6119},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 47, col: 11, offset: 2326},
-							label: "left",
-							expr: &ruleRefExpr{
-								pos:  position{line: 47, col: 17, offset: 2332},
-								name: "Param",
+							pos:   position{line: 102, col: 10, offset: 6119},
+							label: "op",
+							expr: &choiceExpr{
+								pos: position{line: 102, col: 14, offset: 6123},
+								alternatives: []interface{}{
+									&litMatcher{
+										pos:        position{line: 102, col: 14, offset: 6123},
+										val:        "not_has",
+										ignoreCase: false,
+										want:       "\"not_has\"",
+									},
+									&litMatcher{
+										pos:        position{line: 102, col: 26, offset: 6135},
+										val:        "has",
+										ignoreCase: false,
+										want:       "\"has\"",
+									},
+								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 47, col: 24, offset: 2339},
-							name: "_",
-						},
-						&litMatcher{
-							pos:        position{line: 47, col: 26, offset: 2341},
-							val:        "has_all",
-							ignoreCase: false,
-							want:       "\"has_all\"",
+							pos:  position{line: 102, col: 33, offset: 6142},
+							name: "EndOfWord",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 47, col: 36, offset: 2351},
+							pos:  position{line: 102, col: 43, offset: 6152},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 47, col: 38, offset: 2353},
+							pos:   position{line: 102, col: 45, offset: 6154},
 							label: "right",
 							expr: &ruleRefExpr{
-								pos:  position{line: 47, col: 45, offset: 2360},
-								name: "Slice",
+								pos:  position{line: 102, col: 52, offset: 6161},
+								name: "Operand",
 							},
 						},
 					},
 				},
 			},
 		},
-		{
-			name: "RegexpOps",
-			pos:  position{line: 50, col: 1, offset: 2426},
-			expr: &choiceExpr{
-				pos: position{line: 50, col: 15, offset: 2440},
-				alternatives: []interface{}{
-					&ruleRefExpr{
-						pos:  position{line: 50, col: 15, offset: 2440},
-						name: "MatchRegexp",
-					},
-					&ruleRefExpr{
-						pos:  position{line: 50, col: 29, offset: 2454},
-						name: "NotMatchRegexp",
-					},
-				},
-			},
-		},
 		{
 			name: "Regexp",
-			pos:  position{line: 51, col: 1, offset: 2470},
+			pos:  position{line: 105, col: 1, offset: 6237},
 			expr: &actionExpr{
-				pos: position{line: 51, col: 11, offset: 2480},
+				pos: position{line: 105, col: 11, offset: 6247},
 				run: (*parser).callonRegexp1,
 				expr: &seqExpr{
-					pos: position{line: 51, col: 11, offset: 2480},
+					pos: position{line: 105, col: 11, offset: 6247},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 51, col: 11, offset: 2480},
+							pos:        position{line: 105, col: 11, offset: 6247},
 							val:        "/",
 							ignoreCase: false,
 							want:       "\"/\"",
 						},
 						&oneOrMoreExpr{
-							pos: position{line: 51, col: 15, offset: 2484},
-							expr: &charClassMatcher{
-								pos:        position{line: 51, col: 15, offset: 2484},
-								val:        "[^/]",
-								chars:      []rune{'/'},
-								ignoreCase: false,
-								inverted:   true,
-							},
-						},
-						&litMatcher{
-							pos:        position{line: 51, col: 21, offset: 2490},
-							val:        "/",
-							ignoreCase: false,
-							want:       "\"/\"",
-						},
-						&zeroOrMoreExpr{
-							pos: position{line: 51, col: 25, offset: 2494},
-							expr: &charClassMatcher{
-								pos:        position{line: 51, col: 25, offset: 2494},
-								val:        "[g|m|D|i|x|s|u|U|A|J]",
-								chars:      []rune{'g', '|', 'm', '|', 'D', '|', 'i', '|', 'x', '|', 's', '|', 'u', '|', 'U', '|', 'A', '|', 'J'},
-								ignoreCase: false,
-								inverted:   false,
-							},
-						},
-					},
-				},
-			},
-		},
-		{
-			name: "MatchRegexp",
-			pos:  position{line: 52, col: 1, offset: 2548},
-			expr: &actionExpr{
-				pos: position{line: 52, col: 16, offset: 2563},
-				run: (*parser).callonMatchRegexp1,
-				expr: &seqExpr{
-					pos: position{line: 52, col: 16, offset: 2563},
-					exprs: []interface{}{
-						&labeledExpr{
-							pos:   position{line: 52, col: 16, offset: 2563},
-							label: "left",
-							expr: &ruleRefExpr{
-								pos:  position{line: 52, col: 22, offset: 2569},
-								name: "Param",
+							pos: position{line: 105, col: 15, offset: 6251},
+							expr: &choiceExpr{
+								pos: position{line: 105, col: 16, offset: 6252},
+								alternatives: []interface{}{
+									&seqExpr{
+										pos: position{line: 105, col: 16, offset: 6252},
+										exprs: []interface{}{
+											&litMatcher{
+												pos:        position{line: 105, col: 16, offset: 6252},
+												val:        "\\",
+												ignoreCase: false,
+												want:       "\"\\\\\"",
+											},
+											&anyMatcher{
+												line: 105, col: 21, offset: 6257,
+											},
+										},
+									},
+									&charClassMatcher{
+										pos:        position{line: 105, col: 25, offset: 6261},
+										val:        "[^/\\\\]",
+										chars:      []rune{'/', '\\'},
+										ignoreCase: false,
+										inverted:   true,
+									},
+								},
 							},
 						},
-						&ruleRefExpr{
-							pos:  position{line: 52, col: 29, offset: 2576},
-							name: "_",
-						},
 						&litMatcher{
-							pos:        position{line: 52, col: 31, offset: 2578},
-							val:        "=~",
+							pos:        position{line: 105, col: 34, offset: 6270},
+							val:        "/",
 							ignoreCase: false,
-							want:       "\"=~\"",
-						},
-						&ruleRefExpr{
-							pos:  position{line: 52, col: 36, offset: 2583},
-							name: "_",
+							want:       "\"/\"",
 						},
-						&labeledExpr{
-							pos:   position{line: 52, col: 38, offset: 2585},
-							label: "right",
-							expr: &ruleRefExpr{
-								pos:  position{line: 52, col: 45, offset: 2592},
-								name: "Regexp",
+						&zeroOrMoreExpr{
+							pos: position{line: 105, col: 38, offset: 6274},
+							expr: &charClassMatcher{
+								pos:        position{line: 105, col: 38, offset: 6274},
+								val:        "[a-zA-Z]",
+								ranges:     []rune{'a', 'z', 'A', 'Z'},
+								ignoreCase: false,
+								inverted:   false,
 							},
 						},
 					},
@@ -1315,41 +3113,44 @@ var g = &grammar{
 			},
 		},
 		{
-			name: "NotMatchRegexp",
-			pos:  position{line: 53, col: 1, offset: 2641},
+			name: "RegexpOp",
+			pos:  position{line: 106, col: 1, offset: 6328},
 			expr: &actionExpr{
-				pos: position{line: 53, col: 19, offset: 2659},
-				run: (*parser).callonNotMatchRegexp1,
+				pos: position{line: 106, col: 13, offset: 6340},
+				run: (*parser).callonRegexpOp1,
 				expr: &seqExpr{
-					pos: position{line: 53, col: 19, offset: 2659},
+					pos: position{line: 106, col: 13, offset: 6340},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 53, col: 19, offset: 2659},
-							label: "left",
-							expr: &ruleRefExpr{
-								pos:  position{line: 53, col: 25, offset: 2665},
-								name: "Param",
+							pos:   position{line: 106, col: 13, offset: 6340},
+							label: "op",
+							expr: &choiceExpr{
+								pos: position{line: 106, col: 17, offset: 6344},
+								alternatives: []interface{}{
+									&litMatcher{
+										pos:        position{line: 106, col: 17, offset: 6344},
+										val:        "=~",
+										ignoreCase: false,
+										want:       "\"=~\"",
+									},
+									&litMatcher{
+										pos:        position{line: 106, col: 24, offset: 6351},
+										val:        "!~",
+										ignoreCase: false,
+										want:       "\"!~\"",
+									},
+								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 53, col: 32, offset: 2672},
-							name: "_",
-						},
-						&litMatcher{
-							pos:        position{line: 53, col: 34, offset: 2674},
-							val:        "!~",
-							ignoreCase: false,
-							want:       "\"!~\"",
-						},
-						&ruleRefExpr{
-							pos:  position{line: 53, col: 39, offset: 2679},
+							pos:  position{line: 106, col: 30, offset: 6357},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 53, col: 41, offset: 2681},
+							pos:   position{line: 106, col: 32, offset: 6359},
 							label: "right",
 							expr: &ruleRefExpr{
-								pos:  position{line: 53, col: 48, offset: 2688},
+								pos:  position{line: 106, col: 39, offset: 6366},
 								name: "Regexp",
 							},
 						},
@@ -1359,61 +3160,61 @@ var g = &grammar{
 		},
 		{
 			name: "And",
-			pos:  position{line: 56, col: 1, offset: 2750},
+			pos:  position{line: 109, col: 1, offset: 6428},
 			expr: &actionExpr{
-				pos: position{line: 56, col: 8, offset: 2757},
+				pos: position{line: 109, col: 8, offset: 6435},
 				run: (*parser).callonAnd1,
 				expr: &seqExpr{
-					pos: position{line: 56, col: 8, offset: 2757},
+					pos: position{line: 109, col: 8, offset: 6435},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 56, col: 8, offset: 2757},
+							pos:   position{line: 109, col: 8, offset: 6435},
 							label: "first",
 							expr: &choiceExpr{
-								pos: position{line: 56, col: 15, offset: 2764},
+								pos: position{line: 109, col: 15, offset: 6442},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 56, col: 15, offset: 2764},
+										pos:  position{line: 109, col: 15, offset: 6442},
 										name: "Bracket",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 56, col: 25, offset: 2774},
+										pos:  position{line: 109, col: 25, offset: 6452},
 										name: "Statements",
 									},
 								},
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 56, col: 37, offset: 2786},
+							pos:   position{line: 109, col: 37, offset: 6464},
 							label: "rest",
 							expr: &oneOrMoreExpr{
-								pos: position{line: 56, col: 42, offset: 2791},
+								pos: position{line: 109, col: 42, offset: 6469},
 								expr: &seqExpr{
-									pos: position{line: 56, col: 43, offset: 2792},
+									pos: position{line: 109, col: 43, offset: 6470},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 56, col: 43, offset: 2792},
+											pos:  position{line: 109, col: 43, offset: 6470},
 											name: "_",
 										},
 										&litMatcher{
-											pos:        position{line: 56, col: 45, offset: 2794},
+											pos:        position{line: 109, col: 45, offset: 6472},
 											val:        "&&",
 											ignoreCase: false,
 											want:       "\"&&\"",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 56, col: 50, offset: 2799},
+											pos:  position{line: 109, col: 50, offset: 6477},
 											name: "_",
 										},
 										&choiceExpr{
-											pos: position{line: 56, col: 53, offset: 2802},
+											pos: position{line: 109, col: 53, offset: 6480},
 											alternatives: []interface{}{
 												&ruleRefExpr{
-													pos:  position{line: 56, col: 53, offset: 2802},
+													pos:  position{line: 109, col: 53, offset: 6480},
 													name: "Bracket",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 56, col: 63, offset: 2812},
+													pos:  position{line: 109, col: 63, offset: 6490},
 													name: "Statements",
 												},
 											},
@@ -1428,69 +3229,69 @@ var g = &grammar{
 		},
 		{
 			name: "Or",
-			pos:  position{line: 57, col: 1, offset: 2859},
+			pos:  position{line: 110, col: 1, offset: 6537},
 			expr: &actionExpr{
-				pos: position{line: 57, col: 7, offset: 2865},
+				pos: position{line: 110, col: 7, offset: 6543},
 				run: (*parser).callonOr1,
 				expr: &seqExpr{
-					pos: position{line: 57, col: 7, offset: 2865},
+					pos: position{line: 110, col: 7, offset: 6543},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 57, col: 7, offset: 2865},
+							pos:   position{line: 110, col: 7, offset: 6543},
 							label: "first",
 							expr: &choiceExpr{
-								pos: position{line: 57, col: 14, offset: 2872},
+								pos: position{line: 110, col: 14, offset: 6550},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 57, col: 14, offset: 2872},
+										pos:  position{line: 110, col: 14, offset: 6550},
 										name: "And",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 57, col: 20, offset: 2878},
+										pos:  position{line: 110, col: 20, offset: 6556},
 										name: "Bracket",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 57, col: 30, offset: 2888},
+										pos:  position{line: 110, col: 30, offset: 6566},
 										name: "Statements",
 									},
 								},
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 57, col: 42, offset: 2900},
+							pos:   position{line: 110, col: 42, offset: 6578},
 							label: "rest",
 							expr: &oneOrMoreExpr{
-								pos: position{line: 57, col: 47, offset: 2905},
+								pos: position{line: 110, col: 47, offset: 6583},
 								expr: &seqExpr{
-									pos: position{line: 57, col: 48, offset: 2906},
+									pos: position{line: 110, col: 48, offset: 6584},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 57, col: 48, offset: 2906},
+											pos:  position{line: 110, col: 48, offset: 6584},
 											name: "_",
 										},
 										&litMatcher{
-											pos:        position{line: 57, col: 50, offset: 2908},
+											pos:        position{line: 110, col: 50, offset: 6586},
 											val:        "||",
 											ignoreCase: false,
 											want:       "\"||\"",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 57, col: 55, offset: 2913},
+											pos:  position{line: 110, col: 55, offset: 6591},
 											name: "_",
 										},
 										&choiceExpr{
-											pos: position{line: 57, col: 58, offset: 2916},
+											pos: position{line: 110, col: 58, offset: 6594},
 											alternatives: []interface{}{
 												&ruleRefExpr{
-													pos:  position{line: 57, col: 58, offset: 2916},
+													pos:  position{line: 110, col: 58, offset: 6594},
 													name: "And",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 57, col: 64, offset: 2922},
+													pos:  position{line: 110, col: 64, offset: 6600},
 													name: "Bracket",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 57, col: 74, offset: 2932},
+													pos:  position{line: 110, col: 74, offset: 6610},
 													name: "Statements",
 												},
 											},
@@ -1503,14 +3304,29 @@ var g = &grammar{
 				},
 			},
 		},
+		{
+			name: "EndOfWord",
+			pos:  position{line: 112, col: 1, offset: 6657},
+			expr: &notExpr{
+				pos: position{line: 112, col: 14, offset: 6670},
+				expr: &charClassMatcher{
+					pos:        position{line: 112, col: 15, offset: 6671},
+					val:        "[a-zA-Z0-9_.]",
+					chars:      []rune{'_', '.'},
+					ranges:     []rune{'a', 'z', 'A', 'Z', '0', '9'},
+					ignoreCase: false,
+					inverted:   false,
+				},
+			},
+		},
 		{
 			name:        "_",
 			displayName: "\"whitespace\"",
-			pos:         position{line: 59, col: 1, offset: 2979},
+			pos:         position{line: 113, col: 1, offset: 6685},
 			expr: &zeroOrMoreExpr{
-				pos: position{line: 59, col: 19, offset: 2997},
+				pos: position{line: 113, col: 19, offset: 6703},
 				expr: &charClassMatcher{
-					pos:        position{line: 59, col: 19, offset: 2997},
+					pos:        position{line: 113, col: 19, offset: 6703},
 					val:        "[ \\n\\t\\r]",
 					chars:      []rune{' ', '\n', '\t', '\r'},
 					ignoreCase: false,
@@ -1520,11 +3336,11 @@ var g = &grammar{
 		},
 		{
 			name: "EOF",
-			pos:  position{line: 60, col: 1, offset: 3008},
+			pos:  position{line: 114, col: 1, offset: 6714},
 			expr: &notExpr{
-				pos: position{line: 60, col: 8, offset: 3015},
+				pos: position{line: 114, col: 8, offset: 6721},
 				expr: &anyMatcher{
-					line: 60, col: 9, offset: 3016,
+					line: 114, col: 9, offset: 6722,
 				},
 			},
 		},
@@ -1532,7 +3348,7 @@ var g = &grammar{
 }
 
 func (c *current) onInput1(expr interface{}) (interface{}, error) {
-	return expr, nil
+	return parseInput(c.text, expr)
 }
 
 func (p *parser) callonInput1() (interface{}, error) {
@@ -1551,14 +3367,144 @@ func (p *parser) callonBracket1() (interface{}, error) {
 	return p.cur.onBracket1(stack["expr"])
 }
 
-func (c *current) onParam1() (interface{}, error) {
-	return parseParam(c.text)
+func (c *current) onParam1(first, rest interface{}) (interface{}, error) {
+	return parseParam(first, rest)
 }
 
 func (p *parser) callonParam1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onParam1()
+	return p.cur.onParam1(stack["first"], stack["rest"])
+}
+
+func (c *current) onPlainField5(name interface{}) (bool, error) {
+	return reservedWords[name.(string)], nil
+}
+
+func (p *parser) callonPlainField5() (bool, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPlainField5(stack["name"])
+}
+
+func (c *current) onPlainField1(name interface{}) (interface{}, error) {
+	return name, nil
+}
+
+func (p *parser) callonPlainField1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPlainField1(stack["name"])
+}
+
+func (c *current) onIdentifier1() (interface{}, error) {
+	return string(c.text), nil
+}
+
+func (p *parser) callonIdentifier1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onIdentifier1()
+}
+
+func (c *current) onIdentifierStart4() (bool, error) {
+	return c.options().extendedIdentifiers(), nil
+}
+
+func (p *parser) callonIdentifierStart4() (bool, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onIdentifierStart4()
+}
+
+func (c *current) onIdentifierStart7(ch interface{}) (bool, error) {
+	return c.options().identifierStart(ch.([]byte)), nil
+}
+
+func (p *parser) callonIdentifierStart7() (bool, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onIdentifierStart7(stack["ch"])
+}
+
+func (c *current) onIdentifierPart4() (bool, error) {
+	return c.options().extendedIdentifiers(), nil
+}
+
+func (p *parser) callonIdentifierPart4() (bool, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onIdentifierPart4()
+}
+
+func (c *current) onIdentifierPart7(ch interface{}) (bool, error) {
+	return c.options().identifierPart(ch.([]byte)), nil
+}
+
+func (p *parser) callonIdentifierPart7() (bool, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onIdentifierPart7(stack["ch"])
+}
+
+func (c *current) onQuotedField1() (interface{}, error) {
+	return parseQuotedField(c.text)
+}
+
+func (p *parser) callonQuotedField1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onQuotedField1()
+}
+
+func (c *current) onFieldSegment1(name interface{}) (interface{}, error) {
+	return parseSegment(SegmentField, name)
+}
+
+func (p *parser) callonFieldSegment1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onFieldSegment1(stack["name"])
+}
+
+func (c *current) onFieldName1() (interface{}, error) {
+	return string(c.text), nil
+}
+
+func (p *parser) callonFieldName1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onFieldName1()
+}
+
+func (c *current) onIndexSegment1(index interface{}) (interface{}, error) {
+	return parseSegment(SegmentIndex, index)
+}
+
+func (p *parser) callonIndexSegment1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onIndexSegment1(stack["index"])
+}
+
+func (c *current) onIndex1() (interface{}, error) {
+	return string(c.text), nil
+}
+
+func (p *parser) callonIndex1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onIndex1()
+}
+
+func (c *current) onKeySegment1(key interface{}) (interface{}, error) {
+	return parseSegment(SegmentKey, key)
+}
+
+func (p *parser) callonKeySegment1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onKeySegment1(stack["key"])
 }
 
 func (c *current) onNull1() (interface{}, error) {
@@ -1581,6 +3527,26 @@ func (p *parser) callonBoolean1() (interface{}, error) {
 	return p.cur.onBoolean1()
 }
 
+func (c *current) onDecimal3() (bool, error) {
+	return c.options() != nil && c.options().decimalNumbers, nil
+}
+
+func (p *parser) callonDecimal3() (bool, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onDecimal3()
+}
+
+func (c *current) onDecimal1() (interface{}, error) {
+	return parseDecimal(c.text)
+}
+
+func (p *parser) callonDecimal1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onDecimal1()
+}
+
 func (c *current) onFloat1() (interface{}, error) {
 	return parseFloat(c.text)
 }
@@ -1612,7 +3578,7 @@ func (p *parser) callonString1() (interface{}, error) {
 }
 
 func (c *current) onDateTime1(val interface{}) (interface{}, error) {
-	return parseDateTime(val)
+	return parseDateTime(val, c.options())
 }
 
 func (p *parser) callonDateTime1() (interface{}, error) {
@@ -1621,84 +3587,234 @@ func (p *parser) callonDateTime1() (interface{}, error) {
 	return p.cur.onDateTime1(stack["val"])
 }
 
-func (c *current) onEqual1(left, right interface{}) (interface{}, error) {
-	return parseEquals(left, right)
+func (c *current) onDuration1() (interface{}, error) {
+	return parseDuration(c.text)
+}
+
+func (p *parser) callonDuration1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onDuration1()
+}
+
+func (c *current) onRelativeDateTime1(base, offsets interface{}) (interface{}, error) {
+	return parseRelativeDateTime(base, offsets)
+}
+
+func (p *parser) callonRelativeDateTime1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onRelativeDateTime1(stack["base"], stack["offsets"])
+}
+
+func (c *current) onRelativeBase1(base interface{}) (interface{}, error) {
+	return parseRelativeDateTime(base)
+}
+
+func (p *parser) callonRelativeBase1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onRelativeBase1(stack["base"])
+}
+
+func (c *current) onNow1() (interface{}, error) {
+	return Now(), nil
+}
+
+func (p *parser) callonNow1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onNow1()
+}
+
+func (c *current) onToday1() (interface{}, error) {
+	return Today(), nil
+}
+
+func (p *parser) callonToday1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onToday1()
+}
+
+func (c *current) onStartOf1(unit interface{}) (interface{}, error) {
+	return parseStartOf(unit)
+}
+
+func (p *parser) callonStartOf1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onStartOf1(stack["unit"])
+}
+
+func (c *current) onOffset1(sign, duration interface{}) (interface{}, error) {
+	return parseOffset(sign.([]byte), duration)
+}
+
+func (p *parser) callonOffset1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onOffset1(stack["sign"], stack["duration"])
+}
+
+func (c *current) onSum1(first, rest interface{}) (interface{}, error) {
+	return parseArithmetic(first, rest)
+}
+
+func (p *parser) callonSum1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onSum1(stack["first"], stack["rest"])
+}
+
+func (c *current) onProduct1(first, rest interface{}) (interface{}, error) {
+	return parseArithmetic(first, rest)
+}
+
+func (p *parser) callonProduct1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onProduct1(stack["first"], stack["rest"])
+}
+
+func (c *current) onNegation1(value interface{}) (interface{}, error) {
+	return parseNegation(value)
+}
+
+func (p *parser) callonNegation1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onNegation1(stack["value"])
+}
+
+func (c *current) onLeadingDateTime1(value interface{}) (interface{}, error) {
+	return value, nil
+}
+
+func (p *parser) callonLeadingDateTime1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onLeadingDateTime1(stack["value"])
+}
+
+func (c *current) onGroup1(value interface{}) (interface{}, error) {
+	return value, nil
+}
+
+func (p *parser) callonGroup1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onGroup1(stack["value"])
+}
+
+func (c *current) onNamedPlaceholder1(name interface{}) (interface{}, error) {
+	return parsePlaceholder(name)
+}
+
+func (p *parser) callonNamedPlaceholder1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onNamedPlaceholder1(stack["name"])
+}
+
+func (c *current) onPlaceholderName1() (interface{}, error) {
+	return string(c.text), nil
+}
+
+func (p *parser) callonPlaceholderName1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPlaceholderName1()
+}
+
+func (c *current) onPositionalPlaceholder1() (interface{}, error) {
+	return parsePlaceholder(nil)
+}
+
+func (p *parser) callonPositionalPlaceholder1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPositionalPlaceholder1()
 }
 
-func (p *parser) callonEqual1() (interface{}, error) {
+func (c *current) onReference1(name, args interface{}) (interface{}, error) {
+	return parseReference(name, args, c.options())
+}
+
+func (p *parser) callonReference1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onEqual1(stack["left"], stack["right"])
+	return p.cur.onReference1(stack["name"], stack["args"])
 }
 
-func (c *current) onNotEqual1(left, right interface{}) (interface{}, error) {
-	return parseNotEquals(left, right)
+func (c *current) onArguments1(args interface{}) (interface{}, error) {
+	return parseArguments(args)
 }
 
-func (p *parser) callonNotEqual1() (interface{}, error) {
+func (p *parser) callonArguments1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNotEqual1(stack["left"], stack["right"])
+	return p.cur.onArguments1(stack["args"])
 }
 
-func (c *current) onLessThan1(left, right interface{}) (interface{}, error) {
-	return parseLessThan(left, right)
+func (c *current) onArgumentList1(first, rest interface{}) (interface{}, error) {
+	return parseArgumentList(first, rest)
 }
 
-func (p *parser) callonLessThan1() (interface{}, error) {
+func (p *parser) callonArgumentList1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onLessThan1(stack["left"], stack["right"])
+	return p.cur.onArgumentList1(stack["first"], stack["rest"])
 }
 
-func (c *current) onLessThanEqual1(left, right interface{}) (interface{}, error) {
-	return parseLessThanEqual(left, right)
+func (c *current) onStatement1(left, op interface{}) (interface{}, error) {
+	return parseOperation(left, op)
 }
 
-func (p *parser) callonLessThanEqual1() (interface{}, error) {
+func (p *parser) callonStatement1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onLessThanEqual1(stack["left"], stack["right"])
+	return p.cur.onStatement1(stack["left"], stack["op"])
 }
 
-func (c *current) onGreaterThan1(left, right interface{}) (interface{}, error) {
-	return parseGreaterThan(left, right)
+func (c *current) onSliceStatement1(left, op interface{}) (interface{}, error) {
+	return parseOperation(left, op)
 }
 
-func (p *parser) callonGreaterThan1() (interface{}, error) {
+func (p *parser) callonSliceStatement1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onGreaterThan1(stack["left"], stack["right"])
+	return p.cur.onSliceStatement1(stack["left"], stack["op"])
 }
 
-func (c *current) onGreaterThanEqual1(left, right interface{}) (interface{}, error) {
-	return parseGreaterThanEqual(left, right)
+func (c *current) onComparator1(op, right interface{}) (interface{}, error) {
+	return newOperation(op.([]byte), right)
 }
 
-func (p *parser) callonGreaterThanEqual1() (interface{}, error) {
+func (p *parser) callonComparator1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onGreaterThanEqual1(stack["left"], stack["right"])
+	return p.cur.onComparator1(stack["op"], stack["right"])
 }
 
-func (c *current) onStartsWith1(left, right interface{}) (interface{}, error) {
-	return parseStartsWith(left, right)
+func (c *current) onStringOp1(op, right interface{}) (interface{}, error) {
+	return newOperation(op.([]byte), right)
 }
 
-func (p *parser) callonStartsWith1() (interface{}, error) {
+func (p *parser) callonStringOp1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onStartsWith1(stack["left"], stack["right"])
+	return p.cur.onStringOp1(stack["op"], stack["right"])
 }
 
-func (c *current) onEndsWith1(left, right interface{}) (interface{}, error) {
-	return parseEndsWith(left, right)
+func (c *current) onIEqualsOp1(right interface{}) (interface{}, error) {
+	return newOperation([]byte("=*"), right)
 }
 
-func (p *parser) callonEndsWith1() (interface{}, error) {
+func (p *parser) callonIEqualsOp1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onEndsWith1(stack["left"], stack["right"])
+	return p.cur.onIEqualsOp1(stack["right"])
 }
 
 func (c *current) onSlice1(elements interface{}) (interface{}, error) {
@@ -1711,94 +3827,134 @@ func (p *parser) callonSlice1() (interface{}, error) {
 	return p.cur.onSlice1(stack["elements"])
 }
 
-func (c *current) onInSlice1(left, right interface{}) (interface{}, error) {
-	return parseInSlice(left, right)
+func (c *current) onSliceOp1(op, right interface{}) (interface{}, error) {
+	return newOperation(op.([]byte), right)
 }
 
-func (p *parser) callonInSlice1() (interface{}, error) {
+func (p *parser) callonSliceOp1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onInSlice1(stack["left"], stack["right"])
+	return p.cur.onSliceOp1(stack["op"], stack["right"])
 }
 
-func (c *current) onNotInSlice1(left, right interface{}) (interface{}, error) {
-	return parseNotInSlice(left, right)
+func (c *current) onBetweenOp1(op, from, to interface{}) (interface{}, error) {
+	return newBetweenOperation(op, from, to)
 }
 
-func (p *parser) callonNotInSlice1() (interface{}, error) {
+func (p *parser) callonBetweenOp1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNotInSlice1(stack["left"], stack["right"])
+	return p.cur.onBetweenOp1(stack["op"], stack["from"], stack["to"])
 }
 
-func (c *current) onHas1(left, right interface{}) (interface{}, error) {
-	return parseHas(left, right)
+func (c *current) onIntervalOp1(op, lower, from, to, upper interface{}) (interface{}, error) {
+	return newIntervalOperation(op, lower, from, to, upper)
 }
 
-func (p *parser) callonHas1() (interface{}, error) {
+func (p *parser) callonIntervalOp1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onHas1(stack["left"], stack["right"])
+	return p.cur.onIntervalOp1(stack["op"], stack["lower"], stack["from"], stack["to"], stack["upper"])
 }
 
-func (c *current) onNotHas1(left, right interface{}) (interface{}, error) {
-	return parseNotHas(left, right)
+func (c *current) onExists1(param interface{}) (interface{}, error) {
+	return parseCheck(param, "exists")
 }
 
-func (p *parser) callonNotHas1() (interface{}, error) {
+func (p *parser) callonExists1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNotHas1(stack["left"], stack["right"])
+	return p.cur.onExists1(stack["param"])
 }
 
-func (c *current) onHasAny1(left, right interface{}) (interface{}, error) {
-	return parseHasAny(left, right)
+func (c *current) onCheck1(param, op interface{}) (interface{}, error) {
+	return parseCheck(param, op)
 }
 
-func (p *parser) callonHasAny1() (interface{}, error) {
+func (p *parser) callonCheck1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onHasAny1(stack["left"], stack["right"])
+	return p.cur.onCheck1(stack["param"], stack["op"])
 }
 
-func (c *current) onHasAll1(left, right interface{}) (interface{}, error) {
-	return parseHasAll(left, right)
+func (c *current) onIsOp1(not, what interface{}) (interface{}, error) {
+	return parseIsOperator(not, what)
 }
 
-func (p *parser) callonHasAll1() (interface{}, error) {
+func (p *parser) callonIsOp1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onHasAll1(stack["left"], stack["right"])
+	return p.cur.onIsOp1(stack["not"], stack["what"])
 }
 
-func (c *current) onRegexp1() (interface{}, error) {
-	return parseRegexp(c.text)
+func (c *current) onExistsOp1() (interface{}, error) {
+	return "exists", nil
 }
 
-func (p *parser) callonRegexp1() (interface{}, error) {
+func (p *parser) callonExistsOp1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onRegexp1()
+	return p.cur.onExistsOp1()
+}
+
+func (c *current) onQuantifier1(q, param, expr interface{}) (interface{}, error) {
+	return parseQuantifier(q, param, expr)
+}
+
+func (p *parser) callonQuantifier1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onQuantifier1(stack["q"], stack["param"], stack["expr"])
+}
+
+func (c *current) onElement1(param, expr interface{}) (interface{}, error) {
+	return parseQuantifier([]byte("any"), param, expr)
+}
+
+func (p *parser) callonElement1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onElement1(stack["param"], stack["expr"])
+}
+
+func (c *current) onHasSliceOp1(op, right interface{}) (interface{}, error) {
+	return newOperation(op.([]byte), right)
+}
+
+func (p *parser) callonHasSliceOp1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onHasSliceOp1(stack["op"], stack["right"])
+}
+
+func (c *current) onHasOp1(op, right interface{}) (interface{}, error) {
+	return newOperation(op.([]byte), right)
+}
+
+func (p *parser) callonHasOp1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onHasOp1(stack["op"], stack["right"])
 }
 
-func (c *current) onMatchRegexp1(left, right interface{}) (interface{}, error) {
-	return parseMatchRegexp(left, right)
+func (c *current) onRegexp1() (interface{}, error) {
+	return parseRegexp(c.text, c.options())
 }
 
-func (p *parser) callonMatchRegexp1() (interface{}, error) {
+func (p *parser) callonRegexp1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onMatchRegexp1(stack["left"], stack["right"])
+	return p.cur.onRegexp1()
 }
 
-func (c *current) onNotMatchRegexp1(left, right interface{}) (interface{}, error) {
-	return parseNotMatchRegexp(left, right)
+func (c *current) onRegexpOp1(op, right interface{}) (interface{}, error) {
+	return newOperation(op.([]byte), right)
 }
 
-func (p *parser) callonNotMatchRegexp1() (interface{}, error) {
+func (p *parser) callonRegexpOp1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNotMatchRegexp1(stack["left"], stack["right"])
+	return p.cur.onRegexpOp1(stack["op"], stack["right"])
 }
 
 func (c *current) onAnd1(first, rest interface{}) (interface{}, error) {
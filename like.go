@@ -0,0 +1,362 @@
+package lep
+
+// LikeX matches strings with a SQL LIKE pattern: % stands for any sequence of
+// characters, _ for one character and \ escapes the next character.
+type LikeX struct {
+	Param *ParamX
+	Value Stringify
+}
+
+var _ Expression = (*LikeX)(nil)
+var _ Statement = (*LikeX)(nil)
+
+func Like(param *ParamX, value Stringify) *LikeX {
+	return &LikeX{
+		Param: param,
+		Value: value,
+	}
+}
+
+func (e LikeX) GetParam() *ParamX {
+	return e.Param
+}
+
+func (e LikeX) GetValue() Value {
+	return e.Value
+}
+
+func (e LikeX) Equals(other Expression) bool {
+	if expr, ok := other.(*LikeX); ok {
+		return e.Param.Equals(expr.Param) && e.Value.Equals(expr.Value)
+	}
+	return false
+}
+
+func (e LikeX) String() string {
+	return e.Param.String() + " like " + e.Value.String()
+}
+
+func parseLike(left, right interface{}) (*LikeX, error) {
+	param, value, err := parseStatement(left, right)
+	if err != nil {
+		return nil, err
+	}
+	stringify, ok := value.(Stringify)
+	if !ok {
+		return nil, IncorrectType("parseLike", (Stringify)(nil), right)
+	}
+	return Like(param, stringify), nil
+}
+
+type NotLikeX struct {
+	Param *ParamX
+	Value Stringify
+}
+
+var _ Expression = (*NotLikeX)(nil)
+var _ Statement = (*NotLikeX)(nil)
+
+func NotLike(param *ParamX, value Stringify) *NotLikeX {
+	return &NotLikeX{
+		Param: param,
+		Value: value,
+	}
+}
+
+func (e NotLikeX) GetParam() *ParamX {
+	return e.Param
+}
+
+func (e NotLikeX) GetValue() Value {
+	return e.Value
+}
+
+func (e NotLikeX) Equals(other Expression) bool {
+	if expr, ok := other.(*NotLikeX); ok {
+		return e.Param.Equals(expr.Param) && e.Value.Equals(expr.Value)
+	}
+	return false
+}
+
+func (e NotLikeX) String() string {
+	return e.Param.String() + " not_like " + e.Value.String()
+}
+
+func parseNotLike(left, right interface{}) (*NotLikeX, error) {
+	param, value, err := parseStatement(left, right)
+	if err != nil {
+		return nil, err
+	}
+	stringify, ok := value.(Stringify)
+	if !ok {
+		return nil, IncorrectType("parseNotLike", (Stringify)(nil), right)
+	}
+	return NotLike(param, stringify), nil
+}
+
+// ILikeX is like ignoring case.
+type ILikeX struct {
+	Param *ParamX
+	Value Stringify
+}
+
+var _ Expression = (*ILikeX)(nil)
+var _ Statement = (*ILikeX)(nil)
+
+func ILike(param *ParamX, value Stringify) *ILikeX {
+	return &ILikeX{
+		Param: param,
+		Value: value,
+	}
+}
+
+func (e ILikeX) GetParam() *ParamX {
+	return e.Param
+}
+
+func (e ILikeX) GetValue() Value {
+	return e.Value
+}
+
+func (e ILikeX) Equals(other Expression) bool {
+	if expr, ok := other.(*ILikeX); ok {
+		return e.Param.Equals(expr.Param) && e.Value.Equals(expr.Value)
+	}
+	return false
+}
+
+func (e ILikeX) String() string {
+	return e.Param.String() + " ilike " + e.Value.String()
+}
+
+func parseILike(left, right interface{}) (*ILikeX, error) {
+	param, value, err := parseStatement(left, right)
+	if err != nil {
+		return nil, err
+	}
+	stringify, ok := value.(Stringify)
+	if !ok {
+		return nil, IncorrectType("parseILike", (Stringify)(nil), right)
+	}
+	return ILike(param, stringify), nil
+}
+
+// GlobX matches strings with a shell glob: * stands for any sequence of
+// characters, ? for one character, [a-z] and [!a-z] for one character in
+// or out of a class, and \ escapes the next character.
+type GlobX struct {
+	Param *ParamX
+	Value Stringify
+}
+
+var _ Expression = (*GlobX)(nil)
+var _ Statement = (*GlobX)(nil)
+
+func Glob(param *ParamX, value Stringify) *GlobX {
+	return &GlobX{
+		Param: param,
+		Value: value,
+	}
+}
+
+func (e GlobX) GetParam() *ParamX {
+	return e.Param
+}
+
+func (e GlobX) GetValue() Value {
+	return e.Value
+}
+
+func (e GlobX) Equals(other Expression) bool {
+	if expr, ok := other.(*GlobX); ok {
+		return e.Param.Equals(expr.Param) && e.Value.Equals(expr.Value)
+	}
+	return false
+}
+
+func (e GlobX) String() string {
+	return e.Param.String() + " glob " + e.Value.String()
+}
+
+func parseGlob(left, right interface{}) (*GlobX, error) {
+	param, value, err := parseStatement(left, right)
+	if err != nil {
+		return nil, err
+	}
+	stringify, ok := value.(Stringify)
+	if !ok {
+		return nil, IncorrectType("parseGlob", (Stringify)(nil), right)
+	}
+	return Glob(param, stringify), nil
+}
+
+// checkPattern fails on an invalid literal pattern of a like or glob
+// operator, which would never match.
+func checkPattern(op string, right interface{}) error {
+	s, ok := right.(*StringX)
+	if !ok {
+		return nil
+	}
+	var err error
+	switch op {
+	case "like", "not_like", "ilike":
+		_, err = CompileLike(s.Val)
+	case "glob":
+		_, err = CompileGlob(s.Val)
+	}
+	return err
+}
+
+type WildcardKind int
+
+const (
+	WildcardLiteral WildcardKind = iota
+	WildcardOne
+	WildcardAny
+	WildcardClass
+)
+
+// Wildcard is an element of a LIKE pattern or a glob: a literal Rune, one
+// or any number of characters, or one character in the Ranges of a class,
+// or out of them if Negate is set.
+type Wildcard struct {
+	Kind   WildcardKind
+	Rune   rune
+	Ranges [][2]rune
+	Negate bool
+}
+
+func (w Wildcard) matches(r rune) bool {
+	switch w.Kind {
+	case WildcardLiteral:
+		return w.Rune == r
+	case WildcardClass:
+		for _, rr := range w.Ranges {
+			if rr[0] <= r && r <= rr[1] {
+				return !w.Negate
+			}
+		}
+		return w.Negate
+	default:
+		return true
+	}
+}
+
+// CompileLike splits a LIKE pattern into wildcards.
+func CompileLike(pattern string) ([]Wildcard, error) {
+	var result []Wildcard
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		switch runes[i] {
+		case '%':
+			result = append(result, Wildcard{Kind: WildcardAny})
+		case '_':
+			result = append(result, Wildcard{Kind: WildcardOne})
+		case '\\':
+			if i++; i == len(runes) {
+				return nil, InvalidPattern(pattern, "trailing escape")
+			}
+			result = append(result, Wildcard{Rune: runes[i]})
+		default:
+			result = append(result, Wildcard{Rune: runes[i]})
+		}
+	}
+	return result, nil
+}
+
+// CompileGlob splits a glob into wildcards. A ] right after [ or [! is part
+// of the class.
+func CompileGlob(pattern string) ([]Wildcard, error) {
+	var result []Wildcard
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		switch runes[i] {
+		case '*':
+			result = append(result, Wildcard{Kind: WildcardAny})
+		case '?':
+			result = append(result, Wildcard{Kind: WildcardOne})
+		case '\\':
+			if i++; i == len(runes) {
+				return nil, InvalidPattern(pattern, "trailing escape")
+			}
+			result = append(result, Wildcard{Rune: runes[i]})
+		case '[':
+			class := Wildcard{Kind: WildcardClass}
+			i++
+			if i < len(runes) && (runes[i] == '!' || runes[i] == '^') {
+				class.Negate = true
+				i++
+			}
+			for start := i; ; i++ {
+				if i >= len(runes) {
+					return nil, InvalidPattern(pattern, "unterminated class")
+				}
+				if runes[i] == ']' && i > start {
+					break
+				}
+				if runes[i] == '\\' {
+					if i++; i == len(runes) {
+						return nil, InvalidPattern(pattern, "trailing escape")
+					}
+				}
+				rr := [2]rune{runes[i], runes[i]}
+				if i+2 < len(runes) && runes[i+1] == '-' && runes[i+2] != ']' {
+					i += 2
+					if runes[i] == '\\' {
+						if i++; i == len(runes) {
+							return nil, InvalidPattern(pattern, "trailing escape")
+						}
+					}
+					rr[1] = runes[i]
+					if rr[1] < rr[0] {
+						return nil, InvalidPattern(pattern, "invalid range")
+					}
+				}
+				class.Ranges = append(class.Ranges, rr)
+			}
+			result = append(result, class)
+		default:
+			result = append(result, Wildcard{Rune: runes[i]})
+		}
+	}
+	return result, nil
+}
+
+// matchWildcards reports whether the wildcards match all of s. A failed
+// match after * retries with * taking one more character, which keeps it
+// linear in the length of s for every *.
+func matchWildcards(pattern []Wildcard, s string) bool {
+	runes := []rune(s)
+	p, i := 0, 0
+	star, mark := -1, 0
+	for i < len(runes) {
+		switch {
+		case p < len(pattern) && pattern[p].Kind == WildcardAny:
+			star, mark = p, i
+			p++
+		case p < len(pattern) && pattern[p].matches(runes[i]):
+			p++
+			i++
+		case star >= 0:
+			mark++
+			p, i = star+1, mark
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p].Kind == WildcardAny {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// matchPattern matches s with a LIKE pattern, or with a glob for the glob
+// operator; invalid patterns match nothing.
+func matchPattern(op, s, pattern string) bool {
+	compile := CompileLike
+	if op == "glob" {
+		compile = CompileGlob
+	}
+	wildcards, err := compile(pattern)
+	return err == nil && matchWildcards(wildcards, s)
+}
@@ -0,0 +1,141 @@
+package lep
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseLike(t *testing.T) {
+	var (
+		name = Param("name")
+		path = Param("path")
+	)
+
+	type testParseLike struct {
+		query string
+		expr  Expression
+		str   string
+	}
+	var tests = []testParseLike{
+		{query: `name like "foo%bar_"`, expr: Like(name, String("foo%bar_")), str: `name like "foo%bar_"`},
+		{query: `name not_like "50\%"`, expr: NotLike(name, String(`50\%`)), str: `name not_like "50\\%"`},
+		{query: `name ilike lower(path)`, expr: ILike(name, Call("lower", path)), str: `name ilike lower(path)`},
+		{query: `path glob "src/*.[ch]"`, expr: Glob(path, String("src/*.[ch]")), str: `path glob "src/*.[ch]"`},
+		{query: `"foo" like name`, expr: Compare(String("foo"), "like", name), str: `"foo" like name`},
+		{
+			query: `name like path && path glob "[!.]*"`,
+			expr:  And(Like(name, path), Glob(path, String("[!.]*"))),
+			str:   `name like path && path glob "[!.]*"`,
+		},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query)
+		if assert.NoError(t, err, tt.query) {
+			assert.True(t, tt.expr.Equals(expr), "%s: %s", tt.query, expr)
+			assert.Equal(t, tt.str, expr.String())
+
+			reparsed, err := ParseExpression(expr.String())
+			if assert.NoError(t, err, expr.String()) {
+				assert.True(t, expr.Equals(reparsed), expr.String())
+			}
+		}
+	}
+
+	type testParseLikeErrors struct {
+		query string
+		err   error
+	}
+	var errTests = []testParseLikeErrors{
+		{query: `name like "foo\\"`, err: InvalidPattern(`foo\`, "trailing escape")},
+		{query: `name glob "[a-"`, err: InvalidPattern(`[a-`, "unterminated class")},
+		{query: `name glob "[z-a]"`, err: InvalidPattern(`[z-a]`, "invalid range")},
+		{query: `"x" glob "[]"`, err: InvalidPattern(`[]`, "unterminated class")},
+	}
+
+	for _, tt := range errTests {
+		_, err := ParseExpression(tt.query)
+		if assert.Error(t, err, tt.query) {
+			assert.Contains(t, err.Error(), tt.err.Error(), tt.query)
+		}
+	}
+
+	_, err := ParseExpression(`name liked "x"`)
+	assert.Error(t, err)
+}
+
+func TestMatchPattern(t *testing.T) {
+	type testMatchPattern struct {
+		op      string
+		s       string
+		pattern string
+		result  bool
+	}
+	var tests = []testMatchPattern{
+		{op: "like", s: "foobarx", pattern: "foo%bar_", result: true},
+		{op: "like", s: "foobar", pattern: "foo%bar_", result: false},
+		{op: "like", s: "", pattern: "%", result: true},
+		{op: "like", s: "", pattern: "_", result: false},
+		{op: "like", s: "ab", pattern: "a%%b", result: true},
+		{op: "like", s: "aXbXb", pattern: "a%b", result: true},
+		{op: "like", s: "aXbXc", pattern: "a%b", result: false},
+		{op: "like", s: "50%", pattern: `50\%`, result: true},
+		{op: "like", s: "500", pattern: `50\%`, result: false},
+		{op: "like", s: `a\b`, pattern: `a\\_`, result: true},
+		{op: "like", s: "ñu", pattern: "_u", result: true},
+		{op: "like", s: "Foo", pattern: "foo", result: false},
+		{op: "like", s: "foo", pattern: `foo\`, result: false},
+		{op: "glob", s: "main.go", pattern: "*.go", result: true},
+		{op: "glob", s: "main.go.bak", pattern: "*.go", result: false},
+		{op: "glob", s: "a.c", pattern: "?.[ch]", result: true},
+		{op: "glob", s: "a.o", pattern: "?.[ch]", result: false},
+		{op: "glob", s: "x9", pattern: "[a-z][!a-z]", result: true},
+		{op: "glob", s: "x9", pattern: "[a-z][^0-9]", result: false},
+		{op: "glob", s: "]", pattern: "[]a]", result: true},
+		{op: "glob", s: "-", pattern: "[a-]", result: true},
+		{op: "glob", s: "*", pattern: `\*`, result: true},
+		{op: "glob", s: "x", pattern: `\*`, result: false},
+		{op: "glob", s: "%_", pattern: "%_", result: true},
+		{op: "glob", s: "a", pattern: "[a", result: false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.result, matchPattern(tt.op, tt.s, tt.pattern), "%q %s %q", tt.s, tt.op, tt.pattern)
+	}
+}
+
+func TestLike_Evaluate(t *testing.T) {
+	data := map[string]interface{}{
+		"name":    "Foobar_1",
+		"file":    "report-2021.csv",
+		"pattern": "foo%",
+		"wide":    "ｆｏｏ",
+		"count":   3,
+	}
+
+	type testLikeEvaluate struct {
+		query  string
+		opts   []EvalOption
+		result bool
+	}
+	var tests = []testLikeEvaluate{
+		{query: `name like "Foo%\_1" && name not_like "foo%"`, result: true},
+		{query: `name ilike pattern && name ilike "FOOBAR__"`, result: true},
+		{query: `file glob "report-[0-9][0-9][0-9][0-9].csv" && file glob "*.[!x]sv"`, result: true},
+		{query: `"foobar" like pattern && "foo" glob "f?o"`, result: true},
+		{query: `count like "3" || missing like "%" || name glob "[a-z]*"`, result: false},
+		{query: `count not_like "3" && missing not_like "%"`, result: true},
+		{query: `wide like "foo"`, result: false},
+		{query: `wide like "foo" && wide ilike "FO_"`, opts: []EvalOption{EvalNFKC()}, result: true},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query)
+		if assert.NoError(t, err, tt.query) {
+			result, err := Evaluate(expr, data, tt.opts...)
+			if assert.NoError(t, err, tt.query) {
+				assert.Equal(t, tt.result, result, tt.query)
+			}
+		}
+	}
+}
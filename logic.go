@@ -62,7 +62,7 @@ func (e AndX) Equals(other Expression) bool {
 
 func (e AndX) String() string {
 	var items []string
-	for _, conjunct := range e.Conjuncts {
+	for _, conjunct := range joinRanges(e.Conjuncts, false) {
 		if _, ok := conjunct.(*OrX); ok {
 			items = append(items, "("+conjunct.String()+")")
 		} else {
@@ -124,7 +124,7 @@ func (e OrX) Equals(other Expression) bool {
 
 func (e OrX) String() string {
 	var items []string
-	for _, disjunction := range e.Disjunctions {
+	for _, disjunction := range joinRanges(e.Disjunctions, true) {
 		items = append(items, disjunction.String())
 	}
 	return strings.Join(items, " || ")
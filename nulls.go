@@ -0,0 +1,125 @@
+package lep
+
+// Nulls selects how the evaluator treats missing params and null values.
+type Nulls int
+
+const (
+	// TwoValuedNulls, the default, makes a comparison with a missing or null
+	// operand false, so its negation, like != or not_in, is true.
+	TwoValuedNulls Nulls = iota
+	// StrictNulls fails the evaluation with ErrMissingParam when a param is
+	// missing from the data; null values are compared as with TwoValuedNulls.
+	// exists, is null, is empty, any and all test for missing params and
+	// never fail.
+	StrictNulls
+	// ThreeValuedNulls follows SQL: a comparison with a null or missing
+	// operand is Unknown, negated or not, and Unknown propagates through
+	// && and || as in a WHERE clause. Comparisons with the null literal,
+	// like a = null, stay tests for null.
+	ThreeValuedNulls
+)
+
+// EvalNulls sets the null semantics of the evaluator.
+func EvalNulls(nulls Nulls) EvalOption {
+	return func(e *Evaluator) {
+		e.nulls = nulls
+	}
+}
+
+// Truth is a value of three-valued logic.
+type Truth int
+
+const (
+	False Truth = iota
+	True
+	Unknown
+)
+
+func truthOf(b bool) Truth {
+	if b {
+		return True
+	}
+	return False
+}
+
+func (t Truth) String() string {
+	switch t {
+	case True:
+		return "true"
+	case Unknown:
+		return "unknown"
+	default:
+		return "false"
+	}
+}
+
+func (t Truth) Not() Truth {
+	switch t {
+	case True:
+		return False
+	case False:
+		return True
+	default:
+		return Unknown
+	}
+}
+
+func (t Truth) And(other Truth) Truth {
+	switch {
+	case t == False || other == False:
+		return False
+	case t == Unknown || other == Unknown:
+		return Unknown
+	default:
+		return True
+	}
+}
+
+func (t Truth) Or(other Truth) Truth {
+	switch {
+	case t == True || other == True:
+		return True
+	case t == Unknown || other == Unknown:
+		return Unknown
+	default:
+		return False
+	}
+}
+
+// unknown reports whether null operands make an operator unknown in SQL:
+// any null operand, or a null item of an array which does not contain the
+// value, as in 3 in [1, null].
+func (e *Evaluator) unknown(op string, left, right interface{}) bool {
+	if left == nil || right == nil {
+		return true
+	}
+	switch op {
+	case "in", "not_in":
+		items := toSlice(right)
+		return containsNull(items) && !e.containsValue(items, left)
+	case "has", "not_has":
+		items := toSlice(left)
+		return containsNull(items) && !e.containsValue(items, right)
+	}
+	return false
+}
+
+func containsNull(items []interface{}) bool {
+	for _, item := range items {
+		if item == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// isNullTest reports whether a comparison tests for null with the null
+// literal, which is never unknown.
+func isNullTest(op string, left, right Value) bool {
+	if op != "=" && op != "!=" {
+		return false
+	}
+	_, leftNull := left.(*NullX)
+	_, rightNull := right.(*NullX)
+	return leftNull || rightNull
+}
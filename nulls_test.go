@@ -0,0 +1,138 @@
+package lep
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTruth(t *testing.T) {
+	type testTruth struct {
+		left  Truth
+		right Truth
+		and   Truth
+		or    Truth
+	}
+	var tests = []testTruth{
+		{left: True, right: True, and: True, or: True},
+		{left: True, right: False, and: False, or: True},
+		{left: True, right: Unknown, and: Unknown, or: True},
+		{left: False, right: False, and: False, or: False},
+		{left: False, right: Unknown, and: False, or: Unknown},
+		{left: Unknown, right: Unknown, and: Unknown, or: Unknown},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.and, tt.left.And(tt.right), "%s && %s", tt.left, tt.right)
+		assert.Equal(t, tt.and, tt.right.And(tt.left), "%s && %s", tt.right, tt.left)
+		assert.Equal(t, tt.or, tt.left.Or(tt.right), "%s || %s", tt.left, tt.right)
+		assert.Equal(t, tt.or, tt.right.Or(tt.left), "%s || %s", tt.right, tt.left)
+	}
+
+	assert.Equal(t, False, True.Not())
+	assert.Equal(t, True, False.Not())
+	assert.Equal(t, Unknown, Unknown.Not())
+	assert.Equal(t, "unknown", Unknown.String())
+}
+
+func TestEvalNulls(t *testing.T) {
+	data := map[string]interface{}{
+		"age":   30,
+		"nil":   nil,
+		"tags":  []interface{}{"a", nil},
+		"roles": []interface{}{"admin"},
+	}
+
+	type testEvalNulls struct {
+		query       string
+		twoValued   Truth
+		threeValued Truth
+	}
+	var tests = []testEvalNulls{
+		{query: `age > 5`, twoValued: True, threeValued: True},
+		{query: `nil > 5`, twoValued: False, threeValued: Unknown},
+		{query: `nil != 5`, twoValued: True, threeValued: Unknown},
+		{query: `missing != 5`, twoValued: True, threeValued: Unknown},
+		{query: `nil = null && missing = null && age != null`, twoValued: True, threeValued: True},
+		{query: `nil not_in [1,2]`, twoValued: True, threeValued: Unknown},
+		{query: `age not_in [1,null]`, twoValued: True, threeValued: Unknown},
+		{query: `age in [30,null] && age not_in [1,2]`, twoValued: True, threeValued: True},
+		{query: `age in [1,null]`, twoValued: False, threeValued: Unknown},
+		{query: `tags has "b"`, twoValued: False, threeValued: Unknown},
+		{query: `tags not_has "a" || roles not_has "b"`, twoValued: True, threeValued: True},
+		{query: `nil > 5 && age < 5`, twoValued: False, threeValued: False},
+		{query: `nil > 5 || age > 5`, twoValued: True, threeValued: True},
+		{query: `nil > 5 || age < 5`, twoValued: False, threeValued: Unknown},
+		{query: `nil starts_with "a" || nil not_contains "a"`, twoValued: True, threeValued: Unknown},
+		{query: `age / 0 != 1`, twoValued: True, threeValued: Unknown},
+		{query: `age not_between 1 and nil`, twoValued: True, threeValued: Unknown},
+		{query: `age not_between 40 and nil`, twoValued: True, threeValued: True},
+		{query: `nil is null && missing is null && exists(nil) && nil is not empty`, twoValued: False, threeValued: False},
+		{query: `nil is null && missing is null && exists(nil)`, twoValued: True, threeValued: True},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query)
+		if !assert.NoError(t, err, tt.query) {
+			continue
+		}
+		truth, err := NewEvaluator().Truth(expr, data)
+		if assert.NoError(t, err, tt.query) {
+			assert.Equal(t, tt.twoValued, truth, "two-valued: %s", tt.query)
+		}
+		truth, err = NewEvaluator(EvalNulls(ThreeValuedNulls)).Truth(expr, data)
+		if assert.NoError(t, err, tt.query) {
+			assert.Equal(t, tt.threeValued, truth, "three-valued: %s", tt.query)
+		}
+		result, err := Evaluate(expr, data, EvalNulls(ThreeValuedNulls))
+		if assert.NoError(t, err, tt.query) {
+			assert.Equal(t, tt.threeValued == True, result, tt.query)
+		}
+	}
+
+	strict := NewEvaluator(EvalNulls(StrictNulls))
+	for _, query := range []string{`missing = 1`, `age > 5 && missing != 1`, `lower(missing) = "a"`, `age between 1 and missing`, `any(tags, missing = 1)`} {
+		expr, err := ParseExpression(query)
+		if assert.NoError(t, err, query) {
+			_, err = strict.Evaluate(expr, data)
+			assert.Equal(t, MissingParam("missing"), err, query)
+		}
+	}
+	type testStrictNulls struct {
+		query  string
+		result bool
+	}
+	var strictTests = []testStrictNulls{
+		{query: `nil = null && nil != 1`, result: true},
+		{query: `age < 5 && missing = 1`, result: false},
+		{query: `exists(missing) || age > 5`, result: true},
+		{query: `missing is null && nil is null`, result: true},
+		{query: `missing is not null`, result: false},
+		{query: `missing is empty || missing is not empty`, result: false},
+		{query: `any(missing, a = 1)`, result: false},
+		{query: `all(missing, a = 1)`, result: true},
+		{query: `missing.a[0] is null && age > 5`, result: true},
+	}
+
+	for _, tt := range strictTests {
+		expr, err := ParseExpression(tt.query)
+		if assert.NoError(t, err, tt.query) {
+			result, err := strict.Evaluate(expr, data)
+			if assert.NoError(t, err, tt.query) {
+				assert.Equal(t, tt.result, result, tt.query)
+			}
+		}
+	}
+}
+
+func TestTrace_Unknown(t *testing.T) {
+	expr, err := ParseExpression(`age > 5 || nil != 1`)
+	if assert.NoError(t, err) {
+		trace, err := NewEvaluator(EvalNulls(ThreeValuedNulls)).Trace(expr, map[string]interface{}{"age": 1})
+		if assert.NoError(t, err) {
+			assert.False(t, trace.Result)
+			assert.True(t, trace.Unknown)
+			assert.False(t, trace.Children[0].Unknown)
+			assert.True(t, trace.Children[1].Unknown)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package lep
+
+import "time"
+
+const parseOptionsKey = "lep.options"
+
+// parseOptions holds the settings of the lep specific parse options. It is
+// kept in the global store of the parser.
+type parseOptions struct {
+	regexpEngine RegexpEngine
+	regexpLimits RegexpLimits
+
+	dateTimeLocation *time.Location
+	dateTimeDayFirst bool
+	dateTimeLayouts  []string
+
+	decimalNumbers bool
+
+	functions Functions
+
+	unicodeIdentifiers bool
+	leadingUnderscores bool
+	identifierChars    string
+}
+
+func withParseOptions(set func(*parseOptions)) Option {
+	return func(p *parser) Option {
+		old, _ := p.cur.globalStore[parseOptionsKey].(*parseOptions)
+		opts := &parseOptions{}
+		if old != nil {
+			*opts = *old
+		}
+		set(opts)
+		p.cur.globalStore[parseOptionsKey] = opts
+		return withParseOptions(func(o *parseOptions) {
+			if old != nil {
+				*o = *old
+			} else {
+				*o = parseOptions{}
+			}
+		})
+	}
+}
+
+func (c *current) options() *parseOptions {
+	opts, _ := c.globalStore[parseOptionsKey].(*parseOptions)
+	return opts
+}
+
+// RegexpEngineOption compiles the regexp literals with engine instead of
+// RE2.
+func RegexpEngineOption(engine RegexpEngine) Option {
+	return withParseOptions(func(o *parseOptions) {
+		o.regexpEngine = engine
+	})
+}
+
+// MaxRegexpLength limits the length in bytes of the body of regexp literals.
+func MaxRegexpLength(n int) Option {
+	return withParseOptions(func(o *parseOptions) {
+		o.regexpLimits.MaxLength = n
+	})
+}
+
+// MaxRegexpProgSize limits the number of instructions of the compiled
+// program of regexp literals.
+func MaxRegexpProgSize(n int) Option {
+	return withParseOptions(func(o *parseOptions) {
+		o.regexpLimits.MaxProgSize = n
+	})
+}
+
+// MaxRegexpRepeat limits the counts of the {n,m} repetitions in regexp
+// literals.
+func MaxRegexpRepeat(n int) Option {
+	return withParseOptions(func(o *parseOptions) {
+		o.regexpLimits.MaxRepeat = n
+	})
+}
+
+// DateTimeLocation sets the location of datetime literals without a time
+// zone. The default is UTC.
+func DateTimeLocation(loc *time.Location) Option {
+	return withParseOptions(func(o *parseOptions) {
+		o.dateTimeLocation = loc
+	})
+}
+
+// DateTimeDayFirst reads ambiguous datetime literals like "01/02/2020" as
+// day first (1 February) instead of month first (2 January).
+func DateTimeDayFirst(dayFirst bool) Option {
+	return withParseOptions(func(o *parseOptions) {
+		o.dateTimeDayFirst = dayFirst
+	})
+}
+
+// DateTimeLayouts accepts only datetime literals in one of the layouts, as
+// understood by time.Parse; the first matching layout is used.
+func DateTimeLayouts(layouts ...string) Option {
+	return withParseOptions(func(o *parseOptions) {
+		o.dateTimeLayouts = layouts
+	})
+}
+
+// DecimalNumbers reads numeric literals as exact DecimalX values instead of
+// int64 and float64, so 0.1 or integers beyond int64 keep every digit.
+func DecimalNumbers() Option {
+	return withParseOptions(func(o *parseOptions) {
+		o.decimalNumbers = true
+	})
+}
+
+// FunctionsOption binds function calls to the functions of fns instead of the
+// built-in ones; calls of other functions fail to parse.
+func FunctionsOption(fns Functions) Option {
+	return withParseOptions(func(o *parseOptions) {
+		o.functions = fns
+	})
+}
+
+// UnicodeIdentifiers accepts the Unicode letters in params, so names like
+// größe or 名前 can be written without backquotes.
+func UnicodeIdentifiers() Option {
+	return withParseOptions(func(o *parseOptions) {
+		o.unicodeIdentifiers = true
+	})
+}
+
+// LeadingUnderscores accepts params starting with an underscore, like
+// _internal.
+func LeadingUnderscores() Option {
+	return withParseOptions(func(o *parseOptions) {
+		o.leadingUnderscores = true
+	})
+}
+
+// IdentifierChars accepts the characters of chars, like "@$-/", in params,
+// so $meta, @timestamp or kubernetes.io/name can be written without
+// backquotes. Arithmetic operators never start a param, but a - or / is then
+// part of the param it follows, so subtraction and division need spaces
+// around them, as in a - b.
+func IdentifierChars(chars string) Option {
+	return withParseOptions(func(o *parseOptions) {
+		o.identifierChars = chars
+	})
+}
@@ -1,15 +1,42 @@
 package lep
 
-import "strings"
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
 
+// ParamX is a param, whose Name is the text of its Path: fields, written
+// a.b or `first name`, array indices, written a[0] or a[-1] from the end,
+// and map keys, written a["content-type"].
 type ParamX struct {
 	Name string
+	Path []Segment
 }
 
 var _ Stringify = (*ParamX)(nil)
 
+// Param returns the param of a dotted name, whose parts are fields.
 func Param(name string) *ParamX {
-	return &ParamX{Name: name}
+	var path []Segment
+	for _, field := range strings.Split(name, ".") {
+		path = append(path, Field(field))
+	}
+	return ParamPath(path...)
+}
+
+func ParamPath(path ...Segment) *ParamX {
+	var b strings.Builder
+	for i, segment := range path {
+		if i == 0 && segment.Kind == SegmentField {
+			b.WriteString(quoteParam(segment.Name))
+			continue
+		}
+		b.WriteString(segment.String())
+	}
+	return &ParamX{Name: b.String(), Path: path}
 }
 
 func (p ParamX) Equals(other Expression) bool {
@@ -31,6 +58,191 @@ func (p ParamX) IsStringify() bool {
 	return true
 }
 
-func parseParam(b []byte) (*ParamX, error) {
-	return Param(strings.TrimSpace(string(b))), nil
+// Segments returns the path of the param; a ParamX built without one, like
+// &ParamX{Name: "a.b"}, has the fields of its dotted name.
+func (p ParamX) Segments() []Segment {
+	if len(p.Path) > 0 || p.Name == "" {
+		return p.Path
+	}
+	return Param(p.Name).Path
+}
+
+// schemaName returns the name of the param in a Schema: the names of its
+// fields joined by dots, without quotes, and its indices and keys as
+// written, so `first name` is first name.
+func (p ParamX) schemaName() string {
+	var b strings.Builder
+	for i, segment := range p.Segments() {
+		switch {
+		case segment.Kind != SegmentField:
+			b.WriteString(segment.String())
+		case i > 0:
+			b.WriteString("." + segment.Name)
+		default:
+			b.WriteString(segment.Name)
+		}
+	}
+	return b.String()
+}
+
+// base returns the schema name of the fields the path starts with, before
+// its first index or key.
+func (p ParamX) base() string {
+	path := p.Segments()
+	for i, segment := range path {
+		if segment.Kind != SegmentField {
+			return ParamPath(path[:i]...).schemaName()
+		}
+	}
+	return p.schemaName()
+}
+
+type SegmentKind int
+
+const (
+	SegmentField SegmentKind = iota
+	SegmentIndex
+	SegmentKey
+)
+
+// Segment is a step of a param path. Fields and keys both look up Name in
+// a map; they only differ in how they are written.
+type Segment struct {
+	Kind  SegmentKind
+	Name  string
+	Index int
+}
+
+func Field(name string) Segment {
+	return Segment{Kind: SegmentField, Name: name}
+}
+
+func Index(index int) Segment {
+	return Segment{Kind: SegmentIndex, Index: index}
+}
+
+func Key(key string) Segment {
+	return Segment{Kind: SegmentKey, Name: key}
+}
+
+func (s Segment) String() string {
+	switch s.Kind {
+	case SegmentIndex:
+		return "[" + strconv.Itoa(s.Index) + "]"
+	case SegmentKey:
+		return "[" + String(s.Name).String() + "]"
+	default:
+		return "." + quoteField(s.Name)
+	}
+}
+
+var (
+	plainParam = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
+	plainField = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+)
+
+// reservedWords are the literals and the operators written as words, which
+// are never read as params; a param with one of these names is written in
+// backquotes. Words like any or exists, which are only keywords before a
+// bracket or after a param, are not reserved.
+var reservedWords = map[string]bool{
+	"true": true, "false": true, "null": true,
+	"in": true, "not_in": true, "between": true, "not_between": true, "and": true,
+	"has": true, "not_has": true, "has_any": true, "has_all": true,
+	"starts_with": true, "ends_with": true, "istarts_with": true, "iends_with": true,
+	"contains": true, "not_contains": true, "like": true, "not_like": true,
+	"ilike": true, "glob": true, "ieq": true, "is": true,
+}
+
+// quoteParam writes the first field of a param like quoteField, also
+// quoting the names which would not be read back as a param without
+// options.
+func quoteParam(name string) string {
+	if name == "" || plainParam.MatchString(name) && !reservedWords[name] {
+		return name
+	}
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// quoteField writes a field in backquotes, unless it can be written as it
+// is, doubling the backquotes it contains.
+func quoteField(name string) string {
+	if name == "" || plainField.MatchString(name) {
+		return name
+	}
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// extendedIdentifiers reports whether params can have more characters than
+// ASCII letters, digits and underscores.
+func (o *parseOptions) extendedIdentifiers() bool {
+	return o != nil && (o.unicodeIdentifiers || o.leadingUnderscores || o.identifierChars != "")
+}
+
+// identifierStart reports whether the character ch, which is not an ASCII
+// letter, can start a param with the options.
+func (o *parseOptions) identifierStart(ch []byte) bool {
+	r, _ := utf8.DecodeRune(ch)
+	switch {
+	case r == '_':
+		return o.leadingUnderscores
+	case r >= utf8.RuneSelf && unicode.IsLetter(r):
+		return o.unicodeIdentifiers
+	case strings.ContainsRune("+-*/%", r):
+		// -a is a negation
+		return false
+	}
+	return r != '.' && r != '`' && strings.ContainsRune(o.identifierChars, r)
+}
+
+// identifierPart reports whether the character ch, which is not an ASCII
+// letter, digit or underscore, can be part of a param with the options.
+func (o *parseOptions) identifierPart(ch []byte) bool {
+	r, _ := utf8.DecodeRune(ch)
+	if r >= utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)) {
+		return o.unicodeIdentifiers
+	}
+	return r != '.' && r != '`' && strings.ContainsRune(o.identifierChars, r)
+}
+
+func parseParam(first, rest interface{}) (*ParamX, error) {
+	name, ok := first.(string)
+	if !ok {
+		return nil, IncorrectType("parseParam", "", first)
+	}
+	segments, ok := rest.([]interface{})
+	if !ok {
+		return nil, IncorrectType("parseParam", []interface{}{}, rest)
+	}
+	path := []Segment{Field(name)}
+	for _, segment := range segments {
+		s, ok := segment.(Segment)
+		if !ok {
+			return nil, IncorrectType("parseParam", Segment{}, segment)
+		}
+		path = append(path, s)
+	}
+	return ParamPath(path...), nil
+}
+
+func parseQuotedField(b []byte) (string, error) {
+	name := string(b[1 : len(b)-1])
+	return strings.ReplaceAll(name, "``", "`"), nil
+}
+
+func parseSegment(kind SegmentKind, value interface{}) (Segment, error) {
+	switch v := value.(type) {
+	case string:
+		if kind == SegmentIndex {
+			index, err := strconv.Atoi(v)
+			if err != nil {
+				return Segment{}, OutOfRange(v, "int")
+			}
+			return Index(index), nil
+		}
+		return Field(v), nil
+	case *StringX:
+		return Key(v.Val), nil
+	}
+	return Segment{}, IncorrectType("parseSegment", "", value)
 }
@@ -3,38 +3,136 @@ package lep
 import (
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestParseParam(t *testing.T) {
 	type testParseParam struct {
-		raw    []byte
+		query  string
+		path   []Segment
 		result string
 	}
 	var tests = []testParseParam{
 		{
-			raw:    []byte("a"),
+			query:  "a",
+			path:   []Segment{Field("a")},
 			result: "a",
 		},
 		{
-			raw:    []byte("    param_a    "),
+			query:  "    param_a    ",
+			path:   []Segment{Field("param_a")},
 			result: "param_a",
 		},
 		{
-			raw:    []byte("object_a.field_a"),
+			query:  "object_a.field_a",
+			path:   []Segment{Field("object_a"), Field("field_a")},
 			result: "object_a.field_a",
 		},
+		{
+			query:  "tags[0]",
+			path:   []Segment{Field("tags"), Index(0)},
+			result: "tags[0]",
+		},
+		{
+			query:  `matrix[ 1 ][-2].x`,
+			path:   []Segment{Field("matrix"), Index(1), Index(-2), Field("x")},
+			result: "matrix[1][-2].x",
+		},
+		{
+			query:  `attrs["content-type"]["a\"b"]`,
+			path:   []Segment{Field("attrs"), Key("content-type"), Key(`a"b`)},
+			result: `attrs["content-type"]["a\"b"]`,
+		},
+		{
+			query:  "`first name`.`a``b`.`plain`",
+			path:   []Segment{Field("first name"), Field("a`b"), Field("plain")},
+			result: "`first name`.`a``b`.plain",
+		},
+		{
+			query:  "a.1.`x-y`[2]",
+			path:   []Segment{Field("a"), Field("1"), Field("x-y"), Index(2)},
+			result: "a.1.`x-y`[2]",
+		},
 	}
 
 	for _, tt := range tests {
-		p, err := parseParam(tt.raw)
-		if assert.NoError(t, err) {
-			assert.IsType(t, (*ParamX)(nil), p)
+		expr, err := ParseExpression(tt.query + " = 1")
+		if assert.NoError(t, err, tt.query) {
+			p := expr.(*EqualsX).Param
+			assert.Equal(t, tt.path, p.Path)
 			assert.Equal(t, tt.result, p.Name)
 			assert.Equal(t, tt.result, p.Value())
 			assert.Equal(t, tt.result, p.String())
 			assert.Equal(t, true, p.IsStringify())
+			assert.True(t, ParamPath(tt.path...).Equals(p))
 		}
 	}
+
+	for _, query := range []string{"a. = 1", "a[] = 1", "a[x] = 1", "a[1.5] = 1", "`` = 1", "`a = 1", "a[99999999999999999999] = 1", "a .b = 1"} {
+		_, err := ParseExpression(query)
+		assert.Error(t, err, query)
+	}
+}
+
+func TestParseParam_Identifiers(t *testing.T) {
+	opts := []Option{UnicodeIdentifiers(), LeadingUnderscores(), IdentifierChars("@$-/")}
+
+	type testParseParamIdentifiers struct {
+		query  string
+		opts   []Option
+		expr   Expression
+		result string
+	}
+	var tests = []testParseParamIdentifiers{
+		{
+			query:  `_internal = false && größe > 40 && 名前.ä = "x"`,
+			opts:   opts,
+			expr:   And(Equals(Param("_internal"), Boolean(false)), GreaterThan(Param("größe"), Integer(40)), Equals(Param("名前.ä"), String("x"))),
+			result: "`_internal`=false && `größe`>40 && `名前`.`ä`=\"x\"",
+		},
+		{
+			query:  `@timestamp > now() - 1h && $meta.a_b = 1 && kubernetes.io/name = "web"`,
+			opts:   opts,
+			expr:   And(GreaterThan(Param("@timestamp"), Now(Duration(-time.Hour))), Equals(Param("$meta.a_b"), Integer(1)), Equals(Param("kubernetes.io/name"), String("web"))),
+			result: "`@timestamp`>now() - 1h && `$meta`.a_b=1 && kubernetes.`io/name`=\"web\"",
+		},
+		{
+			query:  `a-b = -c && d/2 > e / 2`,
+			opts:   []Option{IdentifierChars("-/")},
+			expr:   And(Equals(Param("a-b"), Negate(Param("c"))), GreaterThan(Param("d/2"), Arithmetic(Param("e"), "/", Integer(2)))),
+			result: "`a-b`=-c && `d/2`>e / 2",
+		},
+		{
+			query:  `a-b = 1`,
+			expr:   Compare(Arithmetic(Param("a"), "-", Param("b")), "=", Integer(1)),
+			result: "a - b=1",
+		},
+		{
+			query:  "`in` = 1 && `true` has `has` && a.in = 1 && any = 1",
+			expr:   And(Equals(Param("in"), Integer(1)), Has(Param("true"), Param("has")), Equals(Param("a.in"), Integer(1)), Equals(Param("any"), Integer(1))),
+			result: "`in`=1 && `true` has `has` && a.in=1 && any=1",
+		},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query, tt.opts...)
+		if assert.NoError(t, err, tt.query) {
+			assert.True(t, tt.expr.Equals(expr), "%s: %s", tt.query, expr)
+			assert.Equal(t, tt.result, expr.String())
+
+			reparsed, err := ParseExpression(expr.String())
+			if assert.NoError(t, err, expr.String()) {
+				assert.True(t, expr.Equals(reparsed), expr.String())
+			}
+		}
+	}
+
+	for _, query := range []string{"_a = 1", "größe = 1", "$a = 1", "in = 1", "has has 1", "a = in"} {
+		_, err := ParseExpression(query)
+		assert.Error(t, err, query)
+	}
+	_, err := ParseExpression("in = 1", opts...)
+	assert.Error(t, err)
 }
 
 func TestParam_Equals(t *testing.T) {
@@ -59,6 +157,21 @@ func TestParam_Equals(t *testing.T) {
 			p2:     String("a"),
 			result: false,
 		},
+		{
+			p1:     Param("a.b"),
+			p2:     ParamPath(Field("a"), Field("b")),
+			result: true,
+		},
+		{
+			p1:     ParamPath(Field("a"), Key("b")),
+			p2:     ParamPath(Field("a"), Field("b")),
+			result: false,
+		},
+		{
+			p1:     Param("first name"),
+			p2:     ParamPath(Field("first name")),
+			result: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -66,3 +179,59 @@ func TestParam_Equals(t *testing.T) {
 		assert.Equal(t, tt.result, tt.p2.Equals(tt.p1))
 	}
 }
+
+func TestParam_Evaluate(t *testing.T) {
+	data := map[string]interface{}{
+		"tags":    []interface{}{"a", "b", "c"},
+		"matrix":  [][]int{{1, 2}, {3, 4}},
+		"attrs":   map[string]interface{}{"content-type": "text/html", "a.b": 1},
+		"headers": map[string]string{"x-id": "7"},
+		"users":   []interface{}{map[string]interface{}{"first name": "Ann"}},
+		"a.b":     map[string]interface{}{"c": 2},
+	}
+
+	type testParamEvaluate struct {
+		query  string
+		result bool
+	}
+	var tests = []testParamEvaluate{
+		{query: `tags[0] = "a" && tags[-1] = "c" && tags[-3] = "a"`, result: true},
+		{query: `matrix[1][0] = 3 && matrix[-1][-1] = 4`, result: true},
+		{query: `attrs["content-type"] = "text/html" && attrs.a.b = 1 && attrs["a.b"] = 1`, result: true},
+		{query: `headers["x-id"] = "7" && users[0].` + "`first name`" + ` = "Ann"`, result: true},
+		{query: `a.b.c = 2 && a.b["c"] = 2`, result: true},
+		{query: `tags[3] is null && tags[-4] is null && attrs["x"] is null && tags["x"] is null && attrs[0] is null`, result: true},
+		{query: `exists(tags[2]) && exists(attrs["content-type"])`, result: true},
+		{query: `exists(tags[3]) || exists(headers["y"])`, result: false},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query)
+		if assert.NoError(t, err, tt.query) {
+			result, err := Evaluate(expr, data)
+			if assert.NoError(t, err, tt.query) {
+				assert.Equal(t, tt.result, result, tt.query)
+			}
+		}
+	}
+
+	expr, err := ParseExpression(`tags[5] = "a"`)
+	if assert.NoError(t, err) {
+		_, err = Evaluate(expr, data, EvalNulls(StrictNulls))
+		assert.Equal(t, MissingParam("tags[5]"), err)
+	}
+
+	// params built without a path look up the fields of their names
+	exprs := []Expression{
+		Equals(&ParamX{Name: "a"}, Integer(5)),
+		Compare(&ParamX{Name: "b.c"}, ">", Integer(1)),
+		&ExistsX{Param: &ParamX{Name: "b.c"}},
+	}
+	for _, expr := range exprs {
+		result, err := Evaluate(expr, map[string]interface{}{"a": 5, "b": map[string]interface{}{"c": 2}})
+		if assert.NoError(t, err, expr.String()) {
+			assert.True(t, result, expr.String())
+		}
+	}
+	assert.Equal(t, Param("b.c").Path, (&ParamX{Name: "b.c"}).Segments())
+}
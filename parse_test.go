@@ -3,6 +3,7 @@ package lep
 import (
 	"errors"
 	"github.com/stretchr/testify/assert"
+	"math/rand"
 	"regexp"
 	"testing"
 	"time"
@@ -120,16 +121,24 @@ func TestParseExpression(t *testing.T) {
 			),
 		},
 		{
-			query: `a =~ /[a-zA-Z]+/ && b !~ /[0-9]+/gm && (c =~ /[\d]+/ || d !~ /[\D]+/)`,
+			query: `a =~ /[a-zA-Z]+/ && b !~ /[0-9]+/ms && (c =~ /[\d]+\/x/ || d !~ /[\D]+/)`,
 			expr: And(
-				MatchRegexp(a, Regexp(regexp.MustCompile(`/[a-zA-Z]+/`))),
-				NotMatchRegexp(b, Regexp(regexp.MustCompile(`/[0-9]+/gm`))),
+				MatchRegexp(a, Regexp(regexp.MustCompile(`[a-zA-Z]+`))),
+				NotMatchRegexp(b, MustCompileRegexp(`[0-9]+`, "ms")),
 				Or(
-					MatchRegexp(c, Regexp(regexp.MustCompile(`/[\d]+/`))),
-					NotMatchRegexp(d, Regexp(regexp.MustCompile(`/[\D]+/`))),
+					MatchRegexp(c, Regexp(regexp.MustCompile(`[\d]+/x`))),
+					NotMatchRegexp(d, Regexp(regexp.MustCompile(`[\D]+`))),
 				),
 			),
 		},
+		{
+			query: `a =~ /[a-z]+/gi`,
+			err:   UnsupportedFlag("/[a-z]+/gi", "g"),
+		},
+		{
+			query: "\n  a=1 && b=2\n",
+			expr:  And(Equals(a, Integer(1)), Equals(b, Integer(2))),
+		},
 		{
 			query: `a=="undefined operator"`,
 			err:   errors.New("no match found"),
@@ -172,6 +181,14 @@ func TestExpression_String(t *testing.T) {
 			query:  `((b >= c) && (a=123 || g=345 || j!=null)) || t<123 && k in [1,2,3,4,5]`,
 			result: `b>=c && (a=123 || g=345 || j!=null) || t<123 && k in [1,2,3,4,5]`,
 		},
+		{
+			query:  `a !~ /x+/i && b=10.0 && c=0.123456789 && d="say \"hi\" \\o/"`,
+			result: `a !~ /x+/i && b=10.0 && c=0.123456789 && d="say \"hi\" \\o/"`,
+		},
+		{
+			query:  `a in [1e6,1.5E-3,.5,0xFF,1_000_000,+5] && b=-0x10`,
+			result: `a in [1e6,1.5E-3,.5,0xFF,1_000_000,+5] && b=-0x10`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -181,3 +198,57 @@ func TestExpression_String(t *testing.T) {
 		}
 	}
 }
+
+func TestExpression_String_RoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		expr := randomInput(r)
+		parsed, err := ParseExpression(expr.String())
+		if assert.NoError(t, err, expr.String()) {
+			assert.True(t, expr.Equals(parsed), "%s\n%#v", expr, parsed)
+		}
+	}
+}
+
+func TestSyntaxErrors(t *testing.T) {
+	type testSyntaxErrors struct {
+		query  string
+		result []ErrSyntax
+	}
+	var tests = []testSyntaxErrors{
+		{
+			query: `a=1 && b=2`,
+		},
+		{
+			query: `a=1 &&`,
+			result: []ErrSyntax{
+				{Line: 1, Col: 7, Offset: 6},
+			},
+		},
+		{
+			query: "a=1 &&\nb==2",
+			result: []ErrSyntax{
+				{Line: 2, Col: 3, Offset: 9},
+			},
+		},
+		{
+			query: `a=99999999999999999999`,
+			result: []ErrSyntax{
+				{Line: 1, Col: 3, Offset: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseExpression(tt.query)
+		result := SyntaxErrors(err)
+		if assert.Len(t, result, len(tt.result), tt.query) {
+			for i, e := range result {
+				assert.Equal(t, tt.result[i].Line, e.Line)
+				assert.Equal(t, tt.result[i].Col, e.Col)
+				assert.Equal(t, tt.result[i].Offset, e.Offset)
+				assert.NotEmpty(t, e.Message)
+			}
+		}
+	}
+}
@@ -0,0 +1,434 @@
+package lep
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// PlaceholderX is a value given later with Bind: a named placeholder,
+// written :name, or a positional one, written ?, whose Position counts the
+// positional placeholders of the expression from 1.
+type PlaceholderX struct {
+	Name     string
+	Position int
+}
+
+var _ Stringify = (*PlaceholderX)(nil)
+
+func Placeholder(name string) *PlaceholderX {
+	return &PlaceholderX{Name: name}
+}
+
+func Positional(position int) *PlaceholderX {
+	return &PlaceholderX{Position: position}
+}
+
+func (p PlaceholderX) Equals(other Expression) bool {
+	if expr, ok := other.(*PlaceholderX); ok {
+		return p.Name == expr.Name && p.Position == expr.Position
+	}
+	return false
+}
+
+func (p PlaceholderX) String() string {
+	if p.Name == "" {
+		return "?"
+	}
+	return ":" + p.Name
+}
+
+func (p PlaceholderX) Value() interface{} {
+	return nil
+}
+
+// IsStringify is true, so placeholders can be the right side of string
+// operators; Bind checks the value it gets.
+func (p PlaceholderX) IsStringify() bool {
+	return true
+}
+
+// Key is the key of the value of the placeholder in Bind: its name, or its
+// position for a positional placeholder.
+func (p PlaceholderX) Key() string {
+	if p.Name == "" {
+		return strconv.Itoa(p.Position)
+	}
+	return p.Name
+}
+
+func parsePlaceholder(name interface{}) (*PlaceholderX, error) {
+	if name == nil {
+		return Positional(0), nil
+	}
+	s, ok := name.(string)
+	if !ok {
+		return nil, IncorrectType("parsePlaceholder", "", name)
+	}
+	return Placeholder(s), nil
+}
+
+// parseInput numbers the positional placeholders of a parsed expression in
+// the order they are written. A placeholder shared by the comparisons of a
+// range, like ? between 1 and 10, is numbered once.
+func parseInput(text []byte, expr interface{}) (interface{}, error) {
+	if expr == nil {
+		return nil, nil
+	}
+	e, ok := expr.(Expression)
+	if !ok {
+		return nil, IncorrectType("parseInput", (*Expression)(nil), expr)
+	}
+	if bytes.IndexByte(text, '?') < 0 {
+		return e, nil
+	}
+	position := 0
+	numbered := map[*PlaceholderX]bool{}
+	_, err := bindExpression(e, func(p *PlaceholderX) (Value, error) {
+		if p.Name == "" && !numbered[p] {
+			numbered[p] = true
+			position++
+			p.Position = position
+		}
+		return p, nil
+	})
+	return e, err
+}
+
+// Placeholders returns the keys of the placeholders of expr, in the order
+// they are written and without repetitions.
+func Placeholders(expr Expression) []string {
+	var keys []string
+	seen := map[string]bool{}
+	_, _ = bindExpression(expr, func(p *PlaceholderX) (Value, error) {
+		if key := p.Key(); !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+		return p, nil
+	})
+	return keys
+}
+
+// hasPositional reports whether expr has positional placeholders.
+func hasPositional(expr Expression) bool {
+	found := false
+	_, _ = bindExpression(expr, func(p *PlaceholderX) (Value, error) {
+		found = found || p.Name == ""
+		return p, nil
+	})
+	return found
+}
+
+// Bind returns expr with its placeholders replaced by the literals of
+// values, keyed by Key: nil, booleans, numbers, strings, time.Time,
+// time.Duration, *regexp.Regexp, slices of these for in and has_any, or
+// Values. It fails with ErrBindMismatch if a placeholder has no value or a
+// value has no placeholder, and with the errors of the parser if a literal
+// does not fit its operator. expr itself is not changed.
+func Bind(expr Expression, values map[string]interface{}) (Expression, error) {
+	var unbound, extra []string
+	keys := Placeholders(expr)
+	used := map[string]bool{}
+	for _, key := range keys {
+		used[key] = true
+		if _, ok := values[key]; !ok {
+			unbound = append(unbound, key)
+		}
+	}
+	for key := range values {
+		if !used[key] {
+			extra = append(extra, key)
+		}
+	}
+	if len(unbound) > 0 || len(extra) > 0 {
+		sort.Strings(extra)
+		return nil, BindMismatch(unbound, extra)
+	}
+
+	return bindExpression(expr, func(p *PlaceholderX) (Value, error) {
+		return literalOf(p, values[p.Key()])
+	})
+}
+
+// literalOf returns the literal of a Go value bound to a placeholder.
+func literalOf(p *PlaceholderX, value interface{}) (Value, error) {
+	switch v := value.(type) {
+	case Value:
+		return v, nil
+	case time.Duration:
+		return Duration(v), nil
+	case time.Time:
+		return DateTime(v, time.RFC3339Nano), nil
+	case *regexp.Regexp:
+		return Regexp(v), nil
+	case []byte:
+		return nil, InvalidBinding(p.Key(), fmt.Sprintf("%#v", value))
+	case uint:
+		return literalOf(p, uint64(v))
+	case uint64:
+		// the evaluator compares such numbers as floats, but a literal
+		// keeps its value
+		if v > math.MaxInt64 {
+			return nil, OutOfRange(strconv.FormatUint(v, 10), "int64")
+		}
+	}
+	switch v := normalizeValue(value).(type) {
+	case nil:
+		return Null(), nil
+	case bool:
+		return Boolean(v), nil
+	case int64:
+		return Integer(v), nil
+	case float64:
+		// NaN and infinities have no literals
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, InvalidBinding(p.Key(), fmt.Sprintf("%#v", value))
+		}
+		return Float(v), nil
+	case *big.Rat:
+		return Decimal(v), nil
+	case string:
+		return String(v), nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Bool:
+		return Boolean(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Integer(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return literalOf(p, rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return literalOf(p, rv.Float())
+	case reflect.String:
+		return String(rv.String()), nil
+	case reflect.Slice, reflect.Array:
+	default:
+		return nil, InvalidBinding(p.Key(), fmt.Sprintf("%#v", value))
+	}
+	items := make([]Value, rv.Len())
+	for i := range items {
+		item, err := literalOf(p, rv.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := item.(*SliceX); ok {
+			return nil, InvalidBinding(p.Key(), fmt.Sprintf("%#v", value))
+		}
+		items[i] = item
+	}
+	return Slice(items...), nil
+}
+
+// bindExpression returns expr with the placeholders replaced by bind. The
+// nodes without placeholders are kept; the others are built again as the
+// parser builds them, so "status in :statuses" becomes an InSliceX.
+func bindExpression(expr Expression, bind func(*PlaceholderX) (Value, error)) (Expression, error) {
+	switch e := expr.(type) {
+	case *AndX:
+		conjuncts, err := bindExpressions(e.Conjuncts, bind)
+		if err != nil {
+			return nil, err
+		}
+		return And(conjuncts...), nil
+	case *OrX:
+		disjunctions, err := bindExpressions(e.Disjunctions, bind)
+		if err != nil {
+			return nil, err
+		}
+		return Or(disjunctions...), nil
+	case *AnyX:
+		inner, err := bindExpression(e.Expr, bind)
+		if err != nil {
+			return nil, err
+		}
+		return Any(e.Param, inner), nil
+	case *AllX:
+		inner, err := bindExpression(e.Expr, bind)
+		if err != nil {
+			return nil, err
+		}
+		return All(e.Param, inner), nil
+	case *BetweenX:
+		from, to, changed, err := bindBounds(e.From, e.To, bind)
+		if err != nil || !changed {
+			return expr, err
+		}
+		return &BetweenX{Param: e.Param, From: from, To: to, ExcludeFrom: e.ExcludeFrom, ExcludeTo: e.ExcludeTo}, nil
+	case *NotBetweenX:
+		from, to, changed, err := bindBounds(e.From, e.To, bind)
+		if err != nil || !changed {
+			return expr, err
+		}
+		return &NotBetweenX{Param: e.Param, From: from, To: to, ExcludeFrom: e.ExcludeFrom, ExcludeTo: e.ExcludeTo}, nil
+	case *CompareX:
+		left, err := bindValue(e.Left, bind)
+		if err != nil {
+			return nil, err
+		}
+		right, err := bindOperand(e.Operator, e.Right, bind)
+		if err != nil {
+			return nil, err
+		}
+		if left == e.Left && right == e.Right {
+			return expr, nil
+		}
+		return parseComparison(e.Operator, left, right)
+	case Statement:
+		op := operatorOf(expr)
+		value, err := bindOperand(op, e.GetValue(), bind)
+		if err != nil || value == e.GetValue() {
+			return expr, err
+		}
+		return parseComparison(op, e.GetParam(), value)
+	}
+	return expr, nil
+}
+
+func bindExpressions(exprs []Expression, bind func(*PlaceholderX) (Value, error)) ([]Expression, error) {
+	result := make([]Expression, len(exprs))
+	for i, expr := range exprs {
+		var err error
+		if result[i], err = bindExpression(expr, bind); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func bindBounds(from, to Value, bind func(*PlaceholderX) (Value, error)) (Value, Value, bool, error) {
+	boundFrom, err := bindValue(from, bind)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	boundTo, err := bindValue(to, bind)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if boundFrom == from && boundTo == to {
+		return from, to, false, nil
+	}
+	if err := checkBounds(boundFrom, boundTo); err != nil {
+		return nil, nil, false, err
+	}
+	return boundFrom, boundTo, true, nil
+}
+
+// bindOperand binds the right operand of op, which is a slice for in,
+// not_in, has_any and has_all.
+func bindOperand(op string, value Value, bind func(*PlaceholderX) (Value, error)) (Value, error) {
+	p, ok := value.(*PlaceholderX)
+	switch {
+	case !ok:
+		return bindValue(value, bind)
+	case op == "in" || op == "not_in" || op == "has_any" || op == "has_all":
+		literal, err := bind(p)
+		if err != nil {
+			return nil, err
+		}
+		switch literal.(type) {
+		case *SliceX, *PlaceholderX:
+			return literal, nil
+		}
+		return nil, InvalidBinding(p.Key(), literal.String())
+	}
+	return bindValue(value, bind)
+}
+
+// bindValue returns value with the placeholders replaced by bind, or value
+// itself if it has none. Only function arguments can be bound to slices.
+func bindValue(value Value, bind func(*PlaceholderX) (Value, error)) (Value, error) {
+	switch v := value.(type) {
+	case *PlaceholderX:
+		literal, err := bind(v)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := literal.(*SliceX); ok {
+			return nil, InvalidBinding(v.Key(), literal.String())
+		}
+		return literal, nil
+	case *SliceX:
+		values, changed, err := bindValues(v.Values, bind)
+		if err != nil || !changed {
+			return value, err
+		}
+		return Slice(values...), nil
+	case *FunctionCallX:
+		args, changed, err := bindArguments(v.Args, bind)
+		if err != nil || !changed {
+			return value, err
+		}
+		if v.Function != nil {
+			if err := v.Function.CheckArguments(v.Name, typesOf(args)); err != nil {
+				return nil, err
+			}
+		}
+		return &FunctionCallX{Name: v.Name, Args: args, Function: v.Function}, nil
+	case *ArithmeticX:
+		var left Value
+		if v.Left != nil {
+			var err error
+			if left, err = bindValue(v.Left, bind); err != nil {
+				return nil, err
+			}
+		}
+		right, err := bindValue(v.Right, bind)
+		if err != nil {
+			return nil, err
+		}
+		if left == v.Left && right == v.Right {
+			return value, nil
+		}
+		for _, operand := range []Value{left, right} {
+			if operand == nil {
+				continue
+			}
+			if err := checkOperand(v.Operator, operand); err != nil {
+				return nil, err
+			}
+		}
+		return &ArithmeticX{Left: left, Operator: v.Operator, Right: right}, nil
+	}
+	return value, nil
+}
+
+// bindArguments binds the arguments of a function call, which can be slices.
+func bindArguments(args []Value, bind func(*PlaceholderX) (Value, error)) ([]Value, bool, error) {
+	result := make([]Value, len(args))
+	changed := false
+	for i, arg := range args {
+		var err error
+		if p, ok := arg.(*PlaceholderX); ok {
+			result[i], err = bind(p)
+		} else {
+			result[i], err = bindValue(arg, bind)
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		changed = changed || result[i] != arg
+	}
+	return result, changed, nil
+}
+
+func bindValues(values []Value, bind func(*PlaceholderX) (Value, error)) ([]Value, bool, error) {
+	result := make([]Value, len(values))
+	changed := false
+	for i, value := range values {
+		var err error
+		if result[i], err = bindValue(value, bind); err != nil {
+			return nil, false, err
+		}
+		changed = changed || result[i] != value
+	}
+	return result, changed, nil
+}
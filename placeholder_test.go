@@ -0,0 +1,237 @@
+package lep
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestParsePlaceholders(t *testing.T) {
+	type testParsePlaceholders struct {
+		query string
+		expr  Expression
+		keys  []string
+		str   string
+	}
+	var tests = []testParsePlaceholders{
+		{
+			query: `tenant_id = :tenant && created_at > :since && status in :statuses`,
+			expr: And(
+				Equals(Param("tenant_id"), Placeholder("tenant")),
+				GreaterThan(Param("created_at"), Placeholder("since")),
+				Compare(Param("status"), "in", Placeholder("statuses")),
+			),
+			keys: []string{"tenant", "since", "statuses"},
+			str:  `tenant_id=:tenant && created_at>:since && status in :statuses`,
+		},
+		{
+			query: `a = ? && b between ? and ? || c starts_with ? && ? < d`,
+			expr: Or(
+				And(Equals(Param("a"), Positional(1)), Between(Param("b"), Positional(2), Positional(3))),
+				And(StartsWith(Param("c"), Positional(4)), GreaterThan(Param("d"), Positional(5))),
+			),
+			keys: []string{"1", "2", "3", "4", "5"},
+			str:  `a=? && b between ? and ? || c starts_with ? && d>?`,
+		},
+		{
+			query: `lower(name) = :name && max(a, :_m) - :x > 1 && :y in tags && a = :name`,
+			expr: And(
+				Compare(Call("lower", Param("name")), "=", Placeholder("name")),
+				Compare(Arithmetic(Call("max", Param("a"), Placeholder("_m")), "-", Placeholder("x")), ">", Integer(1)),
+				Has(Param("tags"), Placeholder("y")),
+				Equals(Param("a"), Placeholder("name")),
+			),
+			keys: []string{"name", "_m", "x", "y"},
+			str:  `lower(name)=:name && max(a, :_m) - :x>1 && tags has :y && a=:name`,
+		},
+		{
+			query: `? between 1 and 10 && b = ?`,
+			expr: And(
+				Compare(Positional(1), ">=", Integer(1)),
+				Compare(Positional(1), "<=", Integer(10)),
+				Equals(Param("b"), Positional(2)),
+			),
+			keys: []string{"1", "2"},
+			str:  `? between 1 and 10 && b=?`,
+		},
+		{
+			query: `? in [1..10) || ? not_between a and ? || c = ?`,
+			expr: Or(
+				And(Compare(Positional(1), ">=", Integer(1)), Compare(Positional(1), "<", Integer(10))),
+				GreaterThan(Param("a"), Positional(2)),
+				Compare(Positional(2), ">", Positional(3)),
+				Equals(Param("c"), Positional(4)),
+			),
+			keys: []string{"1", "2", "3", "4"},
+			str:  `? in [1..10) || ? not_between a and ? || c=?`,
+		},
+		{
+			query: `a = "?" && b = ":c"`,
+			expr:  And(Equals(Param("a"), String("?")), Equals(Param("b"), String(":c"))),
+			str:   `a="?" && b=":c"`,
+		},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query)
+		if assert.NoError(t, err, tt.query) {
+			assert.Equal(t, tt.str, expr.String())
+			assert.Equal(t, tt.keys, Placeholders(expr))
+			assert.True(t, tt.expr.Equals(expr), "%s: %s", tt.query, expr)
+
+			reparsed, err := ParseExpression(expr.String())
+			if assert.NoError(t, err, expr.String()) {
+				assert.True(t, expr.Equals(reparsed), expr.String())
+				assert.Equal(t, tt.str, Print(reparsed), tt.query)
+			}
+		}
+	}
+
+	for _, query := range []string{`a = :`, `a = :1`, `a = ??`, `a =~ :re`, `a in [:x]`, `a.:b = 1`} {
+		_, err := ParseExpression(query)
+		assert.Error(t, err, query)
+	}
+}
+
+func TestBind(t *testing.T) {
+	since := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	type testBind struct {
+		query  string
+		values map[string]interface{}
+		expr   Expression
+		str    string
+	}
+	var tests = []testBind{
+		{
+			query:  `tenant_id = :tenant && created_at > :since && status in :statuses`,
+			values: map[string]interface{}{"tenant": uint8(7), "since": since, "statuses": []string{"new", "paid"}},
+			expr: And(
+				Equals(Param("tenant_id"), Integer(7)),
+				GreaterThan(Param("created_at"), DateTime(since, time.RFC3339Nano)),
+				InSlice(Param("status"), Slice(String("new"), String("paid"))),
+			),
+			str: `tenant_id=7 && created_at>dt:"2020-01-02T03:04:05Z" && status in ["new","paid"]`,
+		},
+		{
+			query:  `a = ? && b between ? and ? || c starts_with ? && ? < d`,
+			values: map[string]interface{}{"1": nil, "2": 1.5, "3": time.Hour, "4": "x", "5": true},
+			str:    `a=null && b between 1.5 and 1h || c starts_with "x" && d>true`,
+		},
+		{
+			query:  `tags has_any :tags && :tag in tags && score - :x > 1 && max(a, :b) < 1 && len(:list) = 2`,
+			values: map[string]interface{}{"tags": []interface{}{1, "a"}, "tag": "a", "x": big.NewRat(1, 2), "b": Param("c"), "list": [2]int{1, 2}},
+			str:    `tags has_any [1,"a"] && tags has "a" && score - 0.5>1 && max(a, c)<1 && len([1,2])=2`,
+		},
+		{
+			query:  `? between 1 and 10 && b = ?`,
+			values: map[string]interface{}{"1": 5, "2": "x"},
+			str:    `5>=1 && 5<=10 && b="x"`,
+		},
+		{
+			query:  `name not_in :names && any(items, price > :min)`,
+			values: map[string]interface{}{"names": []string{}, "min": 10},
+			str:    `name not_in [] && any(items, price>10)`,
+		},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query)
+		if !assert.NoError(t, err, tt.query) {
+			continue
+		}
+		bound, err := Bind(expr, tt.values)
+		if assert.NoError(t, err, tt.query) {
+			assert.Equal(t, tt.str, bound.String())
+			assert.Empty(t, Placeholders(bound))
+			if tt.expr != nil {
+				assert.True(t, tt.expr.Equals(bound), "%s: %#v", tt.query, bound)
+			}
+		}
+		// the template can be bound again
+		assert.NotEmpty(t, Placeholders(expr))
+	}
+}
+
+type uintID uint64
+
+func TestBind_Errors(t *testing.T) {
+	expr, err := ParseExpression(`tenant_id = :tenant && status in :statuses && name starts_with :prefix && n between :lo and 10`)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	type testBindErrors struct {
+		values map[string]interface{}
+		err    error
+	}
+	var tests = []testBindErrors{
+		{
+			values: map[string]interface{}{"tenant": 1, "status": "x", "extra": 1},
+			err:    BindMismatch([]string{"statuses", "prefix", "lo"}, []string{"extra", "status"}),
+		},
+		{
+			values: map[string]interface{}{"tenant": 1, "statuses": "new", "prefix": "a", "lo": 1},
+			err:    InvalidBinding("statuses", `"new"`),
+		},
+		{
+			values: map[string]interface{}{"tenant": []int{1}, "statuses": []int{}, "prefix": "a", "lo": 1},
+			err:    InvalidBinding("tenant", "[1]"),
+		},
+		{
+			values: map[string]interface{}{"tenant": struct{}{}, "statuses": []int{}, "prefix": "a", "lo": 1},
+			err:    InvalidBinding("tenant", "struct {}{}"),
+		},
+		{
+			values: map[string]interface{}{"tenant": 1, "statuses": [][]int{{1}}, "prefix": "a", "lo": 1},
+			err:    InvalidBinding("statuses", "[][]int{[]int{1}}"),
+		},
+		{
+			values: map[string]interface{}{"tenant": uint64(math.MaxUint64), "statuses": []int{}, "prefix": "a", "lo": 1},
+			err:    OutOfRange("18446744073709551615", "int64"),
+		},
+		{
+			values: map[string]interface{}{"tenant": 1, "statuses": []uintID{1, math.MaxInt64 + 1}, "prefix": "a", "lo": 1},
+			err:    OutOfRange("9223372036854775808", "int64"),
+		},
+		{
+			values: map[string]interface{}{"tenant": math.NaN(), "statuses": []int{}, "prefix": "a", "lo": 1},
+			err:    InvalidBinding("tenant", "NaN"),
+		},
+		{
+			values: map[string]interface{}{"tenant": 1, "statuses": []float32{1, float32(math.Inf(-1))}, "prefix": "a", "lo": 1},
+			err:    InvalidBinding("statuses", "-Inf"),
+		},
+		{
+			values: map[string]interface{}{"tenant": 1, "statuses": []int{}, "prefix": "a", "lo": math.Inf(1)},
+			err:    InvalidBinding("lo", "+Inf"),
+		},
+		{
+			values: map[string]interface{}{"tenant": 1, "statuses": []int{}, "prefix": "a", "lo": nil},
+			err:    InvalidOperand("between", "null"),
+		},
+		{
+			values: map[string]interface{}{"tenant": 1, "statuses": []int{}, "prefix": 1, "lo": 1},
+			err:    IncorrectType("parseStartsWith", (Stringify)(nil), Integer(1)),
+		},
+	}
+
+	for _, tt := range tests {
+		_, err := Bind(expr, tt.values)
+		assert.Equal(t, tt.err, err, "%v", tt.values)
+	}
+
+	_, err = Evaluate(expr, map[string]interface{}{})
+	assert.Equal(t, UnboundPlaceholder("tenant"), err)
+
+	expr, err = ParseExpression(`lower(:x) = "a" && max(a, ?) > 1`)
+	if assert.NoError(t, err) {
+		_, err = Bind(expr, map[string]interface{}{"x": 5, "1": 1})
+		assert.Equal(t, InvalidArgument("lower", 1, TypeString, TypeInteger), err)
+		_, err = Bind(expr, map[string]interface{}{"x": "A", "1": "b"})
+		assert.Equal(t, InvalidArgument("max", 2, TypeFloat, TypeString), err)
+	}
+	assert.EqualError(t, BindMismatch([]string{"a", "1"}, []string{"b"}), "unbound placeholders: a, 1; extra values: b")
+}
@@ -0,0 +1,203 @@
+package lep
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+type PrintOption func(*Printer)
+
+// PrintIndent sets the string nested groups are indented with when an
+// expression is broken across lines. The default is two spaces.
+func PrintIndent(indent string) PrintOption {
+	return func(p *Printer) {
+		p.indent = indent
+	}
+}
+
+// PrintMaxWidth breaks the && and || groups that do not fit in width
+// columns across lines.
+func PrintMaxWidth(width int) PrintOption {
+	return func(p *Printer) {
+		p.maxWidth = width
+	}
+}
+
+// PrintMultiline breaks every && and || group across lines.
+func PrintMultiline() PrintOption {
+	return func(p *Printer) {
+		p.multiline = true
+	}
+}
+
+// PrintSpaced puts spaces around =, !=, <, <=, > and >=.
+func PrintSpaced() PrintOption {
+	return func(p *Printer) {
+		p.spaced = true
+	}
+}
+
+// PrintSorted orders the clauses of every && and || group by their text,
+// except in groups with positional placeholders.
+func PrintSorted() PrintOption {
+	return func(p *Printer) {
+		p.sorted = true
+	}
+}
+
+// Printer prints expressions in a form that parses back to an equal
+// expression.
+type Printer struct {
+	indent    string
+	maxWidth  int
+	multiline bool
+	spaced    bool
+	sorted    bool
+}
+
+func NewPrinter(opts ...PrintOption) *Printer {
+	p := &Printer{indent: "  "}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+func Print(expr Expression, opts ...PrintOption) string {
+	return NewPrinter(opts...).Print(expr)
+}
+
+func (p *Printer) Print(expr Expression) string {
+	flat := p.flat(expr)
+	if _, children := p.group(expr); len(children) < 2 || p.fits(flat, 0) {
+		return flat
+	}
+	return p.broken(expr, "")
+}
+
+func (p *Printer) fits(s string, column int) bool {
+	if p.multiline {
+		return false
+	}
+	return p.maxWidth <= 0 || column+utf8.RuneCountInString(s) <= p.maxWidth
+}
+
+// group returns the operator and the clauses of an && or || group.
+func (p *Printer) group(expr Expression) (string, []Expression) {
+	var op string
+	var children []Expression
+	switch e := expr.(type) {
+	default:
+		return "", nil
+	case *AndX:
+		op, children = "&&", joinRanges(e.Conjuncts, false)
+	case *OrX:
+		op, children = "||", joinRanges(e.Disjunctions, true)
+	}
+	// the values of positional placeholders are bound in the order they
+	// are written
+	if p.sorted && !hasPositional(expr) {
+		children = p.sort(children)
+	}
+	return op, children
+}
+
+func (p *Printer) sort(children []Expression) []Expression {
+	type clause struct {
+		expr Expression
+		text string
+	}
+	clauses := make([]clause, len(children))
+	for i, child := range children {
+		clauses[i] = clause{expr: child, text: p.flat(child)}
+	}
+	sort.SliceStable(clauses, func(i, j int) bool {
+		return clauses[i].text < clauses[j].text
+	})
+	sorted := make([]Expression, len(children))
+	for i, c := range clauses {
+		sorted[i] = c.expr
+	}
+	return sorted
+}
+
+// needsBrackets reports whether child must be bracketed inside parent.
+func needsBrackets(parent, child Expression) bool {
+	switch child.(type) {
+	case *OrX:
+		_, ok := parent.(*AndX)
+		return ok
+	case *AndX:
+		_, ok := parent.(*AndX)
+		return ok
+	}
+	return false
+}
+
+func (p *Printer) flat(expr Expression) string {
+	op, children := p.group(expr)
+	if children == nil {
+		return p.statement(expr)
+	}
+	var items []string
+	for _, child := range children {
+		item := p.flat(child)
+		if needsBrackets(expr, child) {
+			item = "(" + item + ")"
+		}
+		items = append(items, item)
+	}
+	return strings.Join(items, " "+op+" ")
+}
+
+// broken prints every clause of the group on its own line. Nested groups
+// that do not fit are bracketed and indented one level deeper.
+func (p *Printer) broken(expr Expression, indent string) string {
+	op, children := p.group(expr)
+	var b strings.Builder
+	for i, child := range children {
+		column := utf8.RuneCountInString(indent)
+		if i > 0 {
+			b.WriteString("\n" + indent + op + " ")
+			column += len(op) + 1
+		}
+
+		flat := p.flat(child)
+		if needsBrackets(expr, child) {
+			flat = "(" + flat + ")"
+		}
+		if _, nested := p.group(child); len(nested) < 2 || p.fits(flat, column) {
+			b.WriteString(flat)
+			continue
+		}
+		b.WriteString("(\n" + indent + p.indent)
+		b.WriteString(p.broken(child, indent+p.indent))
+		b.WriteString("\n" + indent + ")")
+	}
+	return b.String()
+}
+
+func (p *Printer) operator(op string) string {
+	if p.spaced {
+		return " " + op + " "
+	}
+	return operatorText(op)
+}
+
+func (p *Printer) statement(expr Expression) string {
+	op := operatorOf(expr)
+	switch x := expr.(type) {
+	case *CompareX:
+		return x.Left.String() + p.operator(op) + x.Right.String()
+	case *AnyX:
+		return "any(" + x.Param.String() + ", " + p.flat(x.Expr) + ")"
+	case *AllX:
+		return "all(" + x.Param.String() + ", " + p.flat(x.Expr) + ")"
+	case Statement:
+		if op != "" {
+			return x.GetParam().String() + p.operator(op) + x.GetValue().String()
+		}
+	}
+	return expr.String()
+}
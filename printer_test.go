@@ -0,0 +1,335 @@
+package lep
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestPrint(t *testing.T) {
+	type testPrint struct {
+		query  string
+		opts   []PrintOption
+		result string
+	}
+	var tests = []testPrint{
+		{
+			query:  `a=1.0 && b !~ /x/ && (c=1 || d in [1.50,0x2])`,
+			result: `a=1.0 && b !~ /x/ && (c=1 || d in [1.50,0x2])`,
+		},
+		{
+			query:  `a = 1 && b<=c && d =~ /x/`,
+			opts:   []PrintOption{PrintSpaced()},
+			result: `a = 1 && b <= c && d =~ /x/`,
+		},
+		{
+			query:  `z=1 || (c=1 && b=2) || a has_any [3,1]`,
+			opts:   []PrintOption{PrintSorted()},
+			result: `a has_any [3,1] || b=2 && c=1 || z=1`,
+		},
+		{
+			query:  `z=1 || (b=? && a=?) || (d=:x && c=:y) || ? between 1 and 10`,
+			opts:   []PrintOption{PrintSorted()},
+			result: `z=1 || b=? && a=? || c=:y && d=:x || ? between 1 and 10`,
+		},
+		{
+			query:  `z=1 || (d=:x && c=:y)`,
+			opts:   []PrintOption{PrintSorted()},
+			result: `c=:y && d=:x || z=1`,
+		},
+		{
+			query:  `a=1 && (b=2 || c=3 && d=4) && e=5`,
+			opts:   []PrintOption{PrintMultiline()},
+			result: "a=1\n&& (\n  b=2\n  || (\n    c=3\n    && d=4\n  )\n)\n&& e=5",
+		},
+		{
+			query:  `a=1 && (b=2 || c=3 && d=4) && e=5`,
+			opts:   []PrintOption{PrintMultiline(), PrintIndent("\t")},
+			result: "a=1\n&& (\n\tb=2\n\t|| (\n\t\tc=3\n\t\t&& d=4\n\t)\n)\n&& e=5",
+		},
+		{
+			query:  `a=1 && (b=2 || c=3 && d=4) && e=5`,
+			opts:   []PrintOption{PrintMaxWidth(40)},
+			result: `a=1 && (b=2 || c=3 && d=4) && e=5`,
+		},
+		{
+			query:  `name starts_with "foo" && (b=2 || c=3 && d=4) && e=5`,
+			opts:   []PrintOption{PrintMaxWidth(30)},
+			result: "name starts_with \"foo\"\n&& (b=2 || c=3 && d=4)\n&& e=5",
+		},
+		{
+			query:  `name starts_with "foo" && (b=2 || c=3 && d=4) && e=5`,
+			opts:   []PrintOption{PrintMaxWidth(20)},
+			result: "name starts_with \"foo\"\n&& (\n  b=2\n  || c=3 && d=4\n)\n&& e=5",
+		},
+		{
+			query:  `a=1`,
+			opts:   []PrintOption{PrintMultiline()},
+			result: `a=1`,
+		},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query)
+		if assert.NoError(t, err, tt.query) {
+			assert.Equal(t, tt.result, Print(expr, tt.opts...), tt.query)
+
+			reparsed, err := ParseExpression(tt.result)
+			if assert.NoError(t, err, tt.result) {
+				assert.True(t, expr.Equals(reparsed), tt.result)
+			}
+		}
+	}
+}
+
+func TestPrint_RoundTrip(t *testing.T) {
+	var options = [][]PrintOption{
+		nil,
+		{PrintSpaced()},
+		{PrintSorted()},
+		{PrintMultiline()},
+		{PrintMaxWidth(40), PrintIndent("\t")},
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		expr := randomInput(r)
+		for _, opts := range options {
+			text := Print(expr, opts...)
+			parsed, err := ParseExpression(text)
+			if assert.NoError(t, err, text) {
+				assert.True(t, expr.Equals(parsed), "%s\n%#v", text, parsed)
+			}
+		}
+	}
+}
+
+var (
+	randomParams = []string{"a", "b", "user.age", "name_2", "x1", "_id", "in", "größe"}
+	randomRunes  = []rune("abcXYZ019 _-.,:;!?#%&*+/=<>()[]{}'\"\\")
+	randomLikes  = []string{`a%b_`, `50\%`, `%\\%`, ``}
+	randomGlobs  = []string{`*.go`, `[!a-c]?x`, `\*[]a-]`, `[\]]`}
+	randomRegexp = [][2]string{{`^a.*b$`, ""}, {`[0-9]+`, "i"}, {`\d{2,3}`, "msU"}, {`a/b|\\`, ""}}
+)
+
+// randomExpression builds a random expression which can be written in the
+// grammar, nesting && and || groups up to depth levels.
+// randomInput is a random expression whose positional placeholders are
+// numbered in the order they are written, as the parser numbers them.
+func randomInput(r *rand.Rand) Expression {
+	input, _ := parseInput([]byte("?"), randomExpression(r, 3))
+	return input.(Expression)
+}
+
+func randomExpression(r *rand.Rand, depth int) Expression {
+	if depth > 0 && r.Intn(3) == 0 {
+		children := make([]Expression, 2+r.Intn(3))
+		for i := range children {
+			children[i] = randomExpression(r, depth-1)
+		}
+		if r.Intn(2) == 0 {
+			return And(children...)
+		}
+		return Or(children...)
+	}
+
+	param := randomParam(r)
+	switch r.Intn(35) {
+	default:
+		return Equals(param, randomOperand(r))
+	case 1:
+		return NotEquals(param, randomOperand(r))
+	case 2:
+		return GreaterThan(param, randomOperand(r))
+	case 3:
+		return GreaterThanEqual(param, randomOperand(r))
+	case 4:
+		return LessThan(param, randomOperand(r))
+	case 5:
+		return LessThanEqual(param, randomOperand(r))
+	case 6:
+		if r.Intn(2) == 0 {
+			return StartsWith(param, randomParam(r))
+		}
+		return StartsWith(param, randomString(r))
+	case 7:
+		return EndsWith(param, randomString(r))
+	case 8:
+		return InSlice(param, randomSlice(r))
+	case 9:
+		return NotInSlice(param, randomSlice(r))
+	case 10:
+		return Has(param, randomValue(r))
+	case 11:
+		return NotHas(param, randomValue(r))
+	case 12:
+		return HasAny(param, randomSlice(r))
+	case 13:
+		return HasAll(param, randomSlice(r))
+	case 14:
+		return MatchRegexp(param, randomRegexpValue(r))
+	case 15:
+		return NotMatchRegexp(param, randomRegexpValue(r))
+	case 16:
+		return IStartsWith(param, randomString(r))
+	case 17:
+		return IEndsWith(param, randomParam(r))
+	case 18:
+		return IEquals(param, randomString(r))
+	case 19:
+		return Contains(param, randomString(r))
+	case 20:
+		return NotContains(param, randomString(r))
+	case 21:
+		return Like(param, String(randomLikes[r.Intn(len(randomLikes))]))
+	case 22:
+		return NotLike(param, randomParam(r))
+	case 23:
+		return ILike(param, String(randomLikes[r.Intn(len(randomLikes))]))
+	case 24:
+		return Glob(param, String(randomGlobs[r.Intn(len(randomGlobs))]))
+	case 25:
+		return &BetweenX{Param: param, From: randomBound(r), To: randomBound(r), ExcludeTo: r.Intn(2) == 0}
+	case 26:
+		return &NotBetweenX{Param: param, From: randomBound(r), To: randomBound(r), ExcludeFrom: r.Intn(2) == 0}
+	case 27:
+		return Exists(param)
+	case 28:
+		return IsNull(param)
+	case 29:
+		return IsNotNull(param)
+	case 30:
+		return IsEmpty(param)
+	case 31:
+		return IsNotEmpty(param)
+	case 32:
+		return Any(param, randomExpression(r, depth-1))
+	case 33:
+		return All(param, randomExpression(r, depth-1))
+	case 34:
+		op := "between"
+		if r.Intn(2) == 0 {
+			op = "not_between"
+		}
+		b := &bounds{From: randomBound(r), To: randomBound(r), ExcludeFrom: r.Intn(2) == 0, ExcludeTo: r.Intn(2) == 0}
+		expr, _ := parseBetween(op, Positional(0), b)
+		return expr
+	}
+}
+
+func randomParam(r *rand.Rand) *ParamX {
+	param := Param(randomParams[r.Intn(len(randomParams))])
+	switch r.Intn(8) {
+	case 0:
+		return ParamPath(append(param.Path, Index(r.Intn(5)-2))...)
+	case 1:
+		return ParamPath(append(param.Path, Key(randomString(r).Val), Field("f`"+randomString(r).Val))...)
+	}
+	return param
+}
+
+// randomBound is an operand for a range, which cannot be null or a boolean.
+func randomBound(r *rand.Rand) Value {
+	for {
+		switch bound := randomOperand(r).(type) {
+		case *NullX, *BooleanX:
+		default:
+			return bound
+		}
+	}
+}
+
+func randomOperand(r *rand.Rand) Value {
+	switch r.Intn(10) {
+	case 0, 1:
+		return randomParam(r)
+	case 2:
+		return randomArithmetic(r, 2)
+	case 3:
+		if r.Intn(2) == 0 {
+			return Positional(0)
+		}
+		return Placeholder(randomParams[r.Intn(2)])
+	}
+	return randomValue(r)
+}
+
+// randomArithmetic builds a random arithmetic operation on params, numbers,
+// durations and function calls, nesting operations up to depth levels.
+func randomArithmetic(r *rand.Rand, depth int) Value {
+	operand := func() Value {
+		if depth > 0 && r.Intn(2) == 0 {
+			return randomArithmetic(r, depth-1)
+		}
+		switch r.Intn(6) {
+		default:
+			return randomParam(r)
+		case 5:
+			if r.Intn(2) == 0 {
+				return Today()
+			}
+			return Now(Duration(time.Duration(r.Intn(48)-24) * time.Hour))
+		case 4:
+			return Call("max", randomParam(r), Integer(r.Int63n(10)))
+		case 1:
+			return Integer(r.Int63n(200) - 100)
+		case 2:
+			return Float(r.NormFloat64() * 10)
+		case 3:
+			return Duration(time.Duration(r.Int63n(1<<40) - 1<<39))
+		}
+	}
+	if r.Intn(5) == 0 {
+		return Negate(operand())
+	}
+	operators := []string{"+", "-", "*", "/", "%"}
+	return Arithmetic(operand(), operators[r.Intn(len(operators))], operand())
+}
+
+func randomValue(r *rand.Rand) Value {
+	switch r.Intn(8) {
+	default:
+		return Null()
+	case 1:
+		return Boolean(r.Intn(2) == 0)
+	case 2:
+		return Integer(r.Int63n(1<<40) - 1<<39)
+	case 3:
+		if r.Intn(3) == 0 {
+			return Float(float64(r.Intn(100)))
+		}
+		return Float(r.NormFloat64() * 1000)
+	case 4:
+		return randomString(r)
+	case 5:
+		dt := time.Date(1970+r.Intn(100), time.Month(1+r.Intn(12)), 1+r.Intn(28), 0, 0, 0, 0, time.UTC)
+		return DateTime(dt, "2006-01-02")
+	case 6:
+		dt := time.Date(1970+r.Intn(100), time.Month(1+r.Intn(12)), 1+r.Intn(28), r.Intn(24), r.Intn(60), r.Intn(60), 0, time.UTC)
+		return DateTime(dt, "2006-01-02 15:04:05")
+	case 7:
+		return Duration(time.Duration(r.Int63n(1<<50) - 1<<49))
+	}
+}
+
+func randomString(r *rand.Rand) *StringX {
+	runes := make([]rune, r.Intn(8))
+	for i := range runes {
+		runes[i] = randomRunes[r.Intn(len(randomRunes))]
+	}
+	return String(string(runes))
+}
+
+func randomSlice(r *rand.Rand) *SliceX {
+	values := make([]Value, 1+r.Intn(4))
+	for i := range values {
+		values[i] = randomValue(r)
+	}
+	return Slice(values...)
+}
+
+func randomRegexpValue(r *rand.Rand) *RegexpX {
+	re := randomRegexp[r.Intn(len(randomRegexp))]
+	return MustCompileRegexp(re[0], re[1])
+}
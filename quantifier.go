@@ -0,0 +1,101 @@
+package lep
+
+import "reflect"
+
+// AnyX matches an array param with at least one element which matches Expr,
+// written any(items, price > 100) or items[*].price > 100. The params of
+// Expr are fields of the element.
+type AnyX struct {
+	Param *ParamX
+	Expr  Expression
+}
+
+var _ Expression = (*AnyX)(nil)
+
+func Any(param *ParamX, expr Expression) *AnyX {
+	return &AnyX{Param: param, Expr: expr}
+}
+
+func (e AnyX) Equals(other Expression) bool {
+	if expr, ok := other.(*AnyX); ok {
+		return e.Param.Equals(expr.Param) && e.Expr.Equals(expr.Expr)
+	}
+	return false
+}
+
+func (e AnyX) String() string {
+	return "any(" + e.Param.String() + ", " + e.Expr.String() + ")"
+}
+
+// AllX matches an array param whose elements all match Expr, which is true
+// of an empty, null or missing array.
+type AllX struct {
+	Param *ParamX
+	Expr  Expression
+}
+
+var _ Expression = (*AllX)(nil)
+
+func All(param *ParamX, expr Expression) *AllX {
+	return &AllX{Param: param, Expr: expr}
+}
+
+func (e AllX) Equals(other Expression) bool {
+	if expr, ok := other.(*AllX); ok {
+		return e.Param.Equals(expr.Param) && e.Expr.Equals(expr.Expr)
+	}
+	return false
+}
+
+func (e AllX) String() string {
+	return "all(" + e.Param.String() + ", " + e.Expr.String() + ")"
+}
+
+// evalQuantifier evaluates expr against every element of the array, with
+// the elements which are not objects as empty objects. Null and missing
+// arrays have no elements; values of other types match neither quantifier.
+func (e *Evaluator) evalQuantifier(param *ParamX, expr Expression, all bool, data map[string]interface{}) (Truth, error) {
+	value := paramValue(param, data)
+	if kind := reflect.ValueOf(value).Kind(); value != nil && kind != reflect.Slice && kind != reflect.Array {
+		return False, nil
+	}
+	result := truthOf(all)
+	for _, item := range toSlice(value) {
+		element, _ := item.(map[string]interface{})
+		truth, err := e.Truth(expr, element)
+		if err != nil {
+			return False, err
+		}
+		if all {
+			result = result.And(truth)
+		} else {
+			result = result.Or(truth)
+		}
+		if result == truthOf(!all) {
+			break
+		}
+	}
+	return result, nil
+}
+
+func parseQuantifier(quantifier, param, expr interface{}) (Expression, error) {
+	q, ok := quantifier.([]byte)
+	if !ok {
+		return nil, IncorrectType("parseQuantifier", []byte{}, quantifier)
+	}
+	p, ok := param.(*ParamX)
+	if !ok {
+		return nil, IncorrectType("parseQuantifier", (*ParamX)(nil), param)
+	}
+	x, ok := expr.(Expression)
+	if !ok {
+		return nil, IncorrectType("parseQuantifier", (*Expression)(nil), expr)
+	}
+	switch string(q) {
+	case "any":
+		return Any(p, x), nil
+	case "all":
+		return All(p, x), nil
+	}
+	return nil, IncorrectValue("parseQuantifier", "quantifier", quantifier)
+}
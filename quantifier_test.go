@@ -0,0 +1,128 @@
+package lep
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseQuantifiers(t *testing.T) {
+	var (
+		items = Param("items")
+		price = Param("price")
+		qty   = Param("qty")
+	)
+
+	type testParseQuantifiers struct {
+		query string
+		expr  Expression
+		str   string
+	}
+	var tests = []testParseQuantifiers{
+		{
+			query: `any(items, price > 100 && qty >= 2)`,
+			expr:  Any(items, And(GreaterThan(price, Integer(100)), GreaterThanEqual(qty, Integer(2)))),
+			str:   `any(items, price>100 && qty>=2)`,
+		},
+		{
+			query: `all( items , status = "done" )`,
+			expr:  All(items, Equals(Param("status"), String("done"))),
+			str:   `all(items, status="done")`,
+		},
+		{
+			query: `items[*].price > 100`,
+			expr:  Any(items, GreaterThan(price, Integer(100))),
+			str:   `any(items, price>100)`,
+		},
+		{
+			query: `order.items[*].tags has "x" && items[*].qty is null`,
+			expr:  And(Any(Param("order.items"), Has(Param("tags"), String("x"))), Any(items, IsNull(qty))),
+			str:   `any(order.items, tags has "x") && any(items, qty is null)`,
+		},
+		{
+			query: `orders[*].items[*].price between 1 and 10`,
+			expr:  Any(Param("orders"), Any(items, Between(price, Integer(1), Integer(10)))),
+			str:   `any(orders, any(items, price between 1 and 10))`,
+		},
+		{
+			query: `all(orders, any(items, price < 1) || paid = true)`,
+			expr:  All(Param("orders"), Or(Any(items, LessThan(price, Integer(1))), Equals(Param("paid"), Boolean(true)))),
+			str:   `all(orders, any(items, price<1) || paid=true)`,
+		},
+		{query: `any = 1`, expr: Equals(Param("any"), Integer(1)), str: `any=1`},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query)
+		if assert.NoError(t, err, tt.query) {
+			assert.True(t, tt.expr.Equals(expr), "%s: %s", tt.query, expr)
+			assert.Equal(t, tt.str, expr.String())
+
+			reparsed, err := ParseExpression(expr.String())
+			if assert.NoError(t, err, expr.String()) {
+				assert.True(t, expr.Equals(reparsed), expr.String())
+			}
+		}
+	}
+
+	for _, query := range []string{`any(items)`, `any(items, 1)`, `any(1, a = 1)`, `items[*] = 1`, `items[*].price`} {
+		_, err := ParseExpression(query)
+		assert.Error(t, err, query)
+	}
+}
+
+func TestQuantifiers_Evaluate(t *testing.T) {
+	data := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"price": 150, "qty": 1, "status": "done"},
+			map[string]interface{}{"price": 120, "qty": 3, "status": "done"},
+			map[string]interface{}{"price": 5, "qty": 10, "status": "done", "tags": []interface{}{"sale"}},
+		},
+		"orders": []map[string]interface{}{
+			{"items": []interface{}{map[string]interface{}{"price": 1}}},
+			{"items": []interface{}{}},
+		},
+		"empty": []interface{}{},
+		"nil":   nil,
+		"names": []string{"a", "b"},
+		"count": 3,
+	}
+
+	type testQuantifiersEvaluate struct {
+		query  string
+		result bool
+	}
+	var tests = []testQuantifiersEvaluate{
+		{query: `any(items, price > 100 && qty >= 2)`, result: true},
+		{query: `any(items, price > 130 && qty >= 2)`, result: false},
+		{query: `all(items, status = "done") && all(items, price >= 5)`, result: true},
+		{query: `all(items, price > 5)`, result: false},
+		{query: `items[*].tags has "sale" && items[*].price < 10`, result: true},
+		{query: `any(items, tags is empty)`, result: false},
+		{query: `orders[*].items[*].price = 1 && all(orders, items is not null)`, result: true},
+		{query: `all(orders, any(items, price = 1))`, result: false},
+		{query: `any(empty, a = 1) || any(nil, a = 1) || any(missing, a = 1)`, result: false},
+		{query: `all(empty, a = 1) && all(nil, a = 1) && all(missing, a = 1)`, result: true},
+		{query: `any(names, a is null) && all(names, a is null)`, result: true},
+		{query: `any(count, a is null) || all(count, a is null)`, result: false},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query)
+		if assert.NoError(t, err, tt.query) {
+			result, err := Evaluate(expr, data)
+			if assert.NoError(t, err, tt.query) {
+				assert.Equal(t, tt.result, result, tt.query)
+			}
+		}
+	}
+
+	expr, err := ParseExpression(`any(items, discount > 1)`)
+	if assert.NoError(t, err) {
+		truth, err := NewEvaluator(EvalNulls(ThreeValuedNulls)).Truth(expr, data)
+		if assert.NoError(t, err) {
+			assert.Equal(t, Unknown, truth)
+		}
+		_, err = Evaluate(expr, data, EvalNulls(StrictNulls))
+		assert.Equal(t, MissingParam("discount"), err)
+	}
+}
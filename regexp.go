@@ -1,9 +1,112 @@
 package lep
 
-import "regexp"
+import (
+	"regexp"
+	"regexp/syntax"
+	"strings"
+)
+
+// Matcher is a compiled regexp. String returns its source.
+type Matcher interface {
+	MatchString(s string) bool
+	String() string
+}
+
+// RegexpEngine compiles the body and the flags of /body/flags literals.
+type RegexpEngine interface {
+	Compile(body, flags string) (Matcher, error)
+}
+
+type re2Engine struct{}
+
+// RE2 is the default regexp engine, the regexp package of the standard
+// library. The flags i, m, s and U are passed to it as inline flags.
+var RE2 RegexpEngine = re2Engine{}
+
+const regexpFlags = "imsU"
+
+func (re2Engine) Compile(body, flags string) (Matcher, error) {
+	for _, flag := range flags {
+		if !strings.ContainsRune(regexpFlags, flag) {
+			return nil, UnsupportedFlag("/"+body+"/"+flags, string(flag))
+		}
+	}
+	re, err := regexp.Compile(inlineFlags(flags) + body)
+	if err != nil {
+		return nil, err
+	}
+	return re, nil
+}
+
+func inlineFlags(flags string) string {
+	if flags == "" {
+		return ""
+	}
+	return "(?" + flags + ")"
+}
+
+// RegexpLimits caps the size of regexp literals; zero means no limit.
+// MaxProgSize and MaxRepeat are measured on the RE2 parse of the body, so
+// they can only be used with engines whose syntax RE2 understands.
+type RegexpLimits struct {
+	MaxLength   int
+	MaxProgSize int
+	MaxRepeat   int
+}
+
+func (l RegexpLimits) Check(body, flags string) error {
+	literal := "/" + body + "/" + flags
+	if l.MaxLength > 0 && len(body) > l.MaxLength {
+		return RegexpLimit(literal, "length", len(body), l.MaxLength)
+	}
+	if l.MaxProgSize <= 0 && l.MaxRepeat <= 0 {
+		return nil
+	}
+
+	var inline string
+	for _, flag := range flags {
+		if strings.ContainsRune(regexpFlags, flag) {
+			inline += string(flag)
+		}
+	}
+	re, err := syntax.Parse(inlineFlags(inline)+body, syntax.Perl)
+	if err != nil {
+		return err
+	}
+	if n := maxRepeat(re); l.MaxRepeat > 0 && n > l.MaxRepeat {
+		return RegexpLimit(literal, "repeat count", n, l.MaxRepeat)
+	}
+	if l.MaxProgSize > 0 {
+		prog, err := syntax.Compile(re.Simplify())
+		if err != nil {
+			return err
+		}
+		if n := len(prog.Inst); n > l.MaxProgSize {
+			return RegexpLimit(literal, "program size", n, l.MaxProgSize)
+		}
+	}
+	return nil
+}
+
+func maxRepeat(re *syntax.Regexp) int {
+	var n int
+	if re.Op == syntax.OpRepeat {
+		n = re.Max
+		if re.Min > n {
+			n = re.Min
+		}
+	}
+	for _, sub := range re.Sub {
+		if m := maxRepeat(sub); m > n {
+			n = m
+		}
+	}
+	return n
+}
 
 type RegexpX struct {
-	Regexp *regexp.Regexp
+	Regexp Matcher
+	Flags  string
 }
 
 var _ Expression = (*RegexpX)(nil)
@@ -13,6 +116,23 @@ func Regexp(regexp *regexp.Regexp) *RegexpX {
 	return &RegexpX{Regexp: regexp}
 }
 
+// CompileRegexp compiles the body of a /body/flags literal with RE2.
+func CompileRegexp(body, flags string) (*RegexpX, error) {
+	re, err := RE2.Compile(body, flags)
+	if err != nil {
+		return nil, err
+	}
+	return &RegexpX{Regexp: re, Flags: flags}, nil
+}
+
+func MustCompileRegexp(body, flags string) *RegexpX {
+	re, err := CompileRegexp(body, flags)
+	if err != nil {
+		panic(err)
+	}
+	return re
+}
+
 func (e RegexpX) Equals(other Expression) bool {
 	if expr, ok := other.(*RegexpX); ok {
 		return e.Regexp.String() == expr.Regexp.String()
@@ -21,19 +141,55 @@ func (e RegexpX) Equals(other Expression) bool {
 }
 
 func (e RegexpX) String() string {
-	return e.Regexp.String()
+	body := strings.TrimPrefix(e.Regexp.String(), inlineFlags(e.Flags))
+	if body == "" {
+		body = "(?:)"
+	}
+
+	var b strings.Builder
+	b.WriteByte('/')
+	for i := 0; i < len(body); i++ {
+		switch {
+		case body[i] == '\\' && i+1 < len(body):
+			b.WriteString(body[i : i+2])
+			i++
+		case body[i] == '/':
+			b.WriteString(`\/`)
+		default:
+			b.WriteByte(body[i])
+		}
+	}
+	b.WriteByte('/')
+	b.WriteString(e.Flags)
+	return b.String()
 }
 
 func (e RegexpX) Value() interface{} {
 	return e.Regexp
 }
 
-func parseRegexp(b []byte) (*RegexpX, error) {
-	re, err := regexp.Compile(string(b))
+func parseRegexp(b []byte, opts *parseOptions) (*RegexpX, error) {
+	literal := strings.TrimSpace(string(b))
+	end := strings.LastIndexByte(literal, '/')
+	if !strings.HasPrefix(literal, "/") || end < 1 {
+		return nil, IncorrectValue("parseRegexp", "/body/flags", literal)
+	}
+	body, flags := strings.ReplaceAll(literal[1:end], `\/`, "/"), literal[end+1:]
+
+	engine := RE2
+	if opts != nil {
+		if err := opts.regexpLimits.Check(body, flags); err != nil {
+			return nil, err
+		}
+		if opts.regexpEngine != nil {
+			engine = opts.regexpEngine
+		}
+	}
+	re, err := engine.Compile(body, flags)
 	if err != nil {
 		return nil, err
 	}
-	return Regexp(re), nil
+	return &RegexpX{Regexp: re, Flags: flags}, nil
 }
 
 type MatchRegexpX struct {
@@ -105,7 +261,7 @@ func (e NotMatchRegexpX) Equals(other Expression) bool {
 }
 
 func (e NotMatchRegexpX) String() string {
-	return e.Param.String() + " =~ " + e.Regexp.String()
+	return e.Param.String() + " !~ " + e.Regexp.String()
 }
 
 func (e NotMatchRegexpX) GetParam() *ParamX {
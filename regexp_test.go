@@ -2,6 +2,7 @@ package lep
 
 import (
 	"github.com/stretchr/testify/assert"
+	"path"
 	"regexp"
 	"regexp/syntax"
 	"testing"
@@ -11,32 +12,140 @@ func TestParseRegexp(t *testing.T) {
 	type testParseRegexp struct {
 		raw    []byte
 		result *regexp.Regexp
+		flags  string
+		str    string
 		err    error
 	}
 	var tests = []testParseRegexp{
 		{
 			raw:    []byte("/[a-zA-Z0-9]+/"),
-			result: regexp.MustCompile("/[a-zA-Z0-9]+/"),
+			result: regexp.MustCompile("[a-zA-Z0-9]+"),
+			str:    "/[a-zA-Z0-9]+/",
+		},
+		{
+			raw:    []byte("/^abc$/imsU"),
+			result: regexp.MustCompile("(?imsU)^abc$"),
+			flags:  "imsU",
+			str:    "/^abc$/imsU",
+		},
+		{
+			raw:    []byte(`/a\/b\d\\/`),
+			result: regexp.MustCompile(`a/b\d\\`),
+			str:    `/a\/b\d\\/`,
 		},
 		{
 			raw: []byte("/([a-z]+/"),
-			err: &syntax.Error{Code: syntax.ErrMissingParen, Expr: "/([a-z]+/"},
+			err: &syntax.Error{Code: syntax.ErrMissingParen, Expr: "([a-z]+"},
+		},
+		{
+			raw: []byte("/abc/ix"),
+			err: UnsupportedFlag("/abc/ix", "x"),
+		},
+		{
+			raw: []byte("abc"),
+			err: IncorrectValue("parseRegexp", "/body/flags", "abc"),
 		},
 	}
 
 	for _, tt := range tests {
-		r, err := parseRegexp(tt.raw)
+		r, err := parseRegexp(tt.raw, nil)
 		if tt.err == nil && assert.NoError(t, err) {
 			assert.IsType(t, (*RegexpX)(nil), r)
 			assert.Equal(t, tt.result, r.Regexp)
 			assert.Equal(t, tt.result, r.Value())
-			assert.Equal(t, tt.result.String(), r.String())
+			assert.Equal(t, tt.flags, r.Flags)
+			assert.Equal(t, tt.str, r.String())
 		} else {
 			assert.EqualError(t, err, tt.err.Error())
 		}
 	}
 }
 
+type globMatcher string
+
+func (g globMatcher) MatchString(s string) bool {
+	ok, _ := path.Match(string(g), s)
+	return ok
+}
+
+func (g globMatcher) String() string {
+	return string(g)
+}
+
+type globEngine struct{}
+
+func (globEngine) Compile(body, flags string) (Matcher, error) {
+	if flags != "" {
+		return nil, UnsupportedFlag("/"+body+"/"+flags, flags[:1])
+	}
+	if _, err := path.Match(body, ""); err != nil {
+		return nil, err
+	}
+	return globMatcher(body), nil
+}
+
+func TestParseRegexp_Options(t *testing.T) {
+	type testParseRegexpOptions struct {
+		query string
+		opts  []Option
+		err   error
+	}
+	var tests = []testParseRegexpOptions{
+		{
+			query: `a =~ /^a{1,1000}$/`,
+		},
+		{
+			query: `a =~ /abcdef/`,
+			opts:  []Option{MaxRegexpLength(5)},
+			err:   RegexpLimit("/abcdef/", "length", 6, 5),
+		},
+		{
+			query: `a =~ /abcde/`,
+			opts:  []Option{MaxRegexpLength(5)},
+		},
+		{
+			query: `a =~ /x(a{2,50}|b{100,})/i`,
+			opts:  []Option{MaxRegexpRepeat(99)},
+			err:   RegexpLimit("/x(a{2,50}|b{100,})/i", "repeat count", 100, 99),
+		},
+		{
+			query: `a =~ /(a{10}){10}/`,
+			opts:  []Option{MaxRegexpRepeat(10), MaxRegexpProgSize(50)},
+			err:   RegexpLimit("/(a{10}){10}/", "program size", 122, 50),
+		},
+		{
+			query: `a =~ /[a-z]+\d/`,
+			opts:  []Option{MaxRegexpProgSize(50)},
+		},
+		{
+			query: `a =~ /*.txt/`,
+			opts:  []Option{RegexpEngineOption(globEngine{})},
+		},
+		{
+			query: `a =~ /[/`,
+			opts:  []Option{RegexpEngineOption(globEngine{})},
+			err:   path.ErrBadPattern,
+		},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseExpression(tt.query, tt.opts...)
+		if tt.err == nil {
+			assert.NoError(t, err, tt.query)
+		} else if assert.Error(t, err, tt.query) {
+			assert.Contains(t, err.Error(), tt.err.Error())
+		}
+	}
+
+	expr, err := ParseExpression(`name =~ /*.txt/`, RegexpEngineOption(globEngine{}))
+	if assert.NoError(t, err) {
+		assert.Equal(t, `name =~ /*.txt/`, expr.String())
+		ok, err := Evaluate(expr, map[string]interface{}{"name": "notes.txt"})
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	}
+}
+
 func TestParseMatchRegexp(t *testing.T) {
 	type testParseMatchRegexp struct {
 		left   interface{}
@@ -47,12 +156,12 @@ func TestParseMatchRegexp(t *testing.T) {
 	var tests = []testParseMatchRegexp{
 		{
 			left:   Param("a"),
-			right:  Regexp(regexp.MustCompile(`/[a-z]+/gm`)),
-			result: "a =~ /[a-z]+/gm",
+			right:  MustCompileRegexp(`[a-z]+`, "m"),
+			result: "a =~ /[a-z]+/m",
 		},
 		{
 			left:  Integer(1),
-			right: Regexp(regexp.MustCompile(`/[a-z]+/gm`)),
+			right: MustCompileRegexp(`[a-z]+`, "m"),
 			err:   IncorrectType("parseMatchRegexp", (*ParamX)(nil), (*IntegerX)(nil)),
 		},
 		{
@@ -87,12 +196,12 @@ func TestParseNotMatchRegexp(t *testing.T) {
 	var tests = []testParseNotMatchRegexp{
 		{
 			left:   Param("a"),
-			right:  Regexp(regexp.MustCompile(`/[a-z]+/gm`)),
-			result: "a =~ /[a-z]+/gm",
+			right:  MustCompileRegexp(`[a-z]+`, "m"),
+			result: "a !~ /[a-z]+/m",
 		},
 		{
 			left:  Integer(1),
-			right: Regexp(regexp.MustCompile(`/[a-z]+/gm`)),
+			right: MustCompileRegexp(`[a-z]+`, "m"),
 			err:   IncorrectType("parseNotMatchRegexp", (*ParamX)(nil), (*IntegerX)(nil)),
 		},
 		{
@@ -119,8 +228,8 @@ func TestParseNotMatchRegexp(t *testing.T) {
 
 func TestRegexp_Equals(t *testing.T) {
 	var (
-		re1 = regexp.MustCompile("/[a-zA-Z0-9]+/")
-		re2 = regexp.MustCompile("/[a-zA-Z]+/")
+		re1 = regexp.MustCompile("[a-zA-Z0-9]+")
+		re2 = regexp.MustCompile("[a-zA-Z]+")
 	)
 
 	type testRegexpEquals struct {
@@ -156,8 +265,8 @@ func TestMatchRegexp_Equals(t *testing.T) {
 	var (
 		p1 = Param("a")
 		p2 = Param("b")
-		r1 = Regexp(regexp.MustCompile("/[a-zA-Z0-9]+/"))
-		r2 = Regexp(regexp.MustCompile("/[a-zA-Z]+/"))
+		r1 = Regexp(regexp.MustCompile("[a-zA-Z0-9]+"))
+		r2 = Regexp(regexp.MustCompile("[a-zA-Z]+"))
 	)
 
 	type testMatchRegexp struct {
@@ -203,8 +312,8 @@ func TestNotMatchRegexp_Equals(t *testing.T) {
 	var (
 		p1 = Param("a")
 		p2 = Param("b")
-		r1 = Regexp(regexp.MustCompile("/[a-zA-Z0-9]+/"))
-		r2 = Regexp(regexp.MustCompile("/[a-zA-Z]+/"))
+		r1 = Regexp(regexp.MustCompile("[a-zA-Z0-9]+"))
+		r2 = Regexp(regexp.MustCompile("[a-zA-Z]+"))
 	)
 
 	type testNotMatchRegexp struct {
@@ -245,3 +354,12 @@ func TestNotMatchRegexp_Equals(t *testing.T) {
 		assert.Equal(t, tt.result, tt.r2.Equals(tt.r1))
 	}
 }
+
+func TestRegexp_String(t *testing.T) {
+	assert.Equal(t, `/[a-z]+/i`, MustCompileRegexp(`[a-z]+`, "i").String())
+	assert.Equal(t, `/[a-z]+\/x/`, Regexp(regexp.MustCompile(`[a-z]+/x`)).String())
+	assert.Equal(t, `/(?i)[a-z]+/`, Regexp(regexp.MustCompile(`(?i)[a-z]+`)).String())
+	assert.Equal(t, `/(?:)/`, Regexp(regexp.MustCompile(``)).String())
+	assert.True(t, MustCompileRegexp(`x`, "i").Equals(Regexp(regexp.MustCompile(`(?i)x`))))
+	assert.Panics(t, func() { MustCompileRegexp(`x`, "g") })
+}
@@ -0,0 +1,148 @@
+package lep
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+var startOfUnits = []string{"minute", "hour", "day", "week", "month", "quarter", "year"}
+
+// RelativeDateTimeX is a datetime computed from the current time: now(),
+// today() or startOf("unit"), followed by durations to add or subtract.
+type RelativeDateTimeX struct {
+	Unit    string
+	Offsets []*DurationX
+}
+
+var _ Value = (*RelativeDateTimeX)(nil)
+
+func RelativeDateTime(unit string, offsets ...*DurationX) *RelativeDateTimeX {
+	return &RelativeDateTimeX{
+		Unit:    unit,
+		Offsets: offsets,
+	}
+}
+
+func Now(offsets ...*DurationX) *RelativeDateTimeX {
+	return RelativeDateTime("", offsets...)
+}
+
+func Today(offsets ...*DurationX) *RelativeDateTimeX {
+	return RelativeDateTime("day", offsets...)
+}
+
+func (v RelativeDateTimeX) Equals(other Expression) bool {
+	expr, ok := other.(*RelativeDateTimeX)
+	if !ok || v.Unit != expr.Unit || len(v.Offsets) != len(expr.Offsets) {
+		return false
+	}
+	for i, offset := range v.Offsets {
+		if !offset.Equals(expr.Offsets[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v RelativeDateTimeX) String() string {
+	var b strings.Builder
+	switch v.Unit {
+	case "":
+		b.WriteString("now()")
+	case "day":
+		b.WriteString("today()")
+	default:
+		b.WriteString("startOf(" + strconv.Quote(v.Unit) + ")")
+	}
+	for _, offset := range v.Offsets {
+		if offset.Val < 0 {
+			b.WriteString(" - " + Duration(-offset.Val).String())
+		} else {
+			b.WriteString(" + " + offset.String())
+		}
+	}
+	return b.String()
+}
+
+// Value resolves the datetime against the current time.
+func (v RelativeDateTimeX) Value() interface{} {
+	return v.Resolve(time.Now())
+}
+
+// Resolve returns the datetime relative to now.
+func (v RelativeDateTimeX) Resolve(now time.Time) time.Time {
+	t := startOf(now, v.Unit)
+	for _, offset := range v.Offsets {
+		t = t.Add(offset.Val)
+	}
+	return t
+}
+
+func startOf(t time.Time, unit string) time.Time {
+	year, month, day := t.Date()
+	switch unit {
+	default:
+		return t
+	case "minute":
+		return time.Date(year, month, day, t.Hour(), t.Minute(), 0, 0, t.Location())
+	case "hour":
+		return time.Date(year, month, day, t.Hour(), 0, 0, 0, t.Location())
+	case "day":
+		return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	case "week":
+		// weeks start on Monday
+		weekday := (int(t.Weekday()) + 6) % 7
+		return time.Date(year, month, day-weekday, 0, 0, 0, 0, t.Location())
+	case "month":
+		return time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+	case "quarter":
+		return time.Date(year, (month-1)/3*3+1, 1, 0, 0, 0, 0, t.Location())
+	case "year":
+		return time.Date(year, 1, 1, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// parseStartOf returns no typed nil on errors, which would reach
+// parseRelativeDateTime.
+func parseStartOf(unit interface{}) (interface{}, error) {
+	strVal, ok := unit.(*StringX)
+	if !ok {
+		return nil, IncorrectType("parseStartOf", (*StringX)(nil), unit)
+	}
+	for _, u := range startOfUnits {
+		if strVal.Val == u {
+			return RelativeDateTime(u), nil
+		}
+	}
+	return nil, InvalidUnit("startOf", strVal.Val, startOfUnits)
+}
+
+func parseOffset(sign []byte, duration interface{}) (*DurationX, error) {
+	d, ok := duration.(*DurationX)
+	if !ok {
+		return nil, IncorrectType("parseOffset", (*DurationX)(nil), duration)
+	}
+	if string(sign) == "-" {
+		return Duration(-d.Val), nil
+	}
+	return d, nil
+}
+
+func parseRelativeDateTime(base interface{}, offsets ...interface{}) (interface{}, error) {
+	if base == nil {
+		// the base has reported its error
+		return nil, nil
+	}
+	rel, ok := base.(*RelativeDateTimeX)
+	if !ok {
+		return nil, IncorrectType("parseRelativeDateTime", (*RelativeDateTimeX)(nil), base)
+	}
+	result := RelativeDateTime(rel.Unit)
+	for _, e := range parseExpressions(offsets...) {
+		if d, ok := e.(*DurationX); ok {
+			result.Offsets = append(result.Offsets, d)
+		}
+	}
+	return result, nil
+}
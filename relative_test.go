@@ -0,0 +1,83 @@
+package lep
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseRelativeDateTime(t *testing.T) {
+	// Wednesday
+	now := time.Date(2021, 5, 12, 15, 4, 5, 6, time.UTC)
+
+	type testParseRelativeDateTime struct {
+		query  string
+		value  Value
+		str    string
+		result time.Time
+		err    string
+	}
+	var tests = []testParseRelativeDateTime{
+		{
+			query:  `a>now()`,
+			value:  Now(),
+			str:    `a>now()`,
+			result: now,
+		},
+		{
+			query:  `a > now ( ) - 7d`,
+			value:  Now(Duration(-7 * day)),
+			str:    `a>now() - 1w`,
+			result: time.Date(2021, 5, 5, 15, 4, 5, 6, time.UTC),
+		},
+		{
+			query:  `a>=today()+2h+30m-500ms`,
+			value:  Today(Duration(2*time.Hour), Duration(30*time.Minute), Duration(-500*time.Millisecond)),
+			str:    `a>=today() + 2h + 30m - 500ms`,
+			result: time.Date(2021, 5, 12, 2, 29, 59, 500000000, time.UTC),
+		},
+		{
+			query:  `a<startOf("week")`,
+			value:  RelativeDateTime("week"),
+			str:    `a<startOf("week")`,
+			result: time.Date(2021, 5, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			query:  `a<startOf("quarter") - 1d`,
+			value:  RelativeDateTime("quarter", Duration(-day)),
+			str:    `a<startOf("quarter") - 1d`,
+			result: time.Date(2021, 3, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			query:  `a<startOf("year") + 15s`,
+			value:  RelativeDateTime("year", Duration(15*time.Second)),
+			str:    `a<startOf("year") + 15s`,
+			result: time.Date(2021, 1, 1, 0, 0, 15, 0, time.UTC),
+		},
+		{
+			query: `a<startOf("fortnight")`,
+			err:   InvalidUnit("startOf", "fortnight", startOfUnits).Error(),
+		},
+		{
+			query: `a>now() - 99999999999w`,
+			err:   "value out of range",
+		},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query)
+		if tt.err != "" {
+			if assert.Error(t, err, tt.query) {
+				assert.Contains(t, err.Error(), tt.err)
+				assert.NotContains(t, err.Error(), "parseRelativeDateTime", tt.query)
+			}
+			continue
+		}
+		if assert.NoError(t, err, tt.query) {
+			value := expr.(Statement).GetValue()
+			assert.True(t, tt.value.Equals(value), tt.query)
+			assert.Equal(t, tt.str, expr.String())
+			assert.Equal(t, tt.result, value.(*RelativeDateTimeX).Resolve(now))
+		}
+	}
+}
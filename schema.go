@@ -0,0 +1,326 @@
+package lep
+
+import "strings"
+
+type Type string
+
+const (
+	TypeAny      Type = "any"
+	TypeString   Type = "string"
+	TypeInteger  Type = "integer"
+	TypeFloat    Type = "float"
+	TypeBoolean  Type = "boolean"
+	TypeDateTime Type = "datetime"
+	TypeDuration Type = "duration"
+	TypeArray    Type = "array"
+	TypeRegexp   Type = "regexp"
+	TypeNull     Type = "null"
+)
+
+func TypeOf(value Value) Type {
+	switch v := value.(type) {
+	default:
+		return TypeAny
+	case *StringX:
+		return TypeString
+	case *IntegerX:
+		return TypeInteger
+	case *FloatX:
+		return TypeFloat
+	case *BooleanX:
+		return TypeBoolean
+	case *DateTimeX, *RelativeDateTimeX:
+		return TypeDateTime
+	case *DecimalX:
+		if v.Val.IsInt() {
+			return TypeInteger
+		}
+		return TypeFloat
+	case *DurationX:
+		return TypeDuration
+	case *SliceX:
+		return TypeArray
+	case *RegexpX:
+		return TypeRegexp
+	case *NullX:
+		return TypeNull
+	case *FunctionCallX:
+		if v.Function == nil {
+			return TypeAny
+		}
+		return v.Function.Returns
+	case *ArithmeticX:
+		right := TypeOf(v.Right)
+		if v.Left == nil {
+			return negatedType(right)
+		}
+		t, _ := arithmeticType(v.Operator, TypeOf(v.Left), right)
+		return t
+	}
+}
+
+// arithmeticType returns the type of the result of an arithmetic operator;
+// ok is false if the operator does not apply to the operands. It follows
+// the evaluator: integers divided become floats and numbers mixed with
+// durations are nanoseconds.
+func arithmeticType(op string, left, right Type) (t Type, ok bool) {
+	numeric := func(t Type) bool {
+		return t == TypeInteger || t == TypeFloat
+	}
+	switch {
+	case left == TypeAny || right == TypeAny:
+		known := left
+		if known == TypeAny {
+			known = right
+		}
+		if known == TypeAny || numeric(known) || known == TypeDuration || known == TypeDateTime {
+			return TypeAny, true
+		}
+	case left == TypeInteger && right == TypeInteger:
+		if op == "/" {
+			return TypeFloat, true
+		}
+		return TypeInteger, true
+	case numeric(left) && numeric(right):
+		return TypeFloat, true
+	case left == TypeDuration && right == TypeDuration:
+		if op == "/" {
+			return TypeFloat, true
+		}
+		if op != "*" {
+			return TypeDuration, true
+		}
+	case left == TypeDuration && numeric(right):
+		if right == TypeInteger || op == "*" || op == "/" {
+			return TypeDuration, true
+		}
+	case numeric(left) && right == TypeDuration:
+		if op == "*" || (left == TypeInteger && (op == "+" || op == "-")) {
+			return TypeDuration, true
+		}
+	case left == TypeDateTime && right == TypeDateTime:
+		if op == "-" {
+			return TypeDuration, true
+		}
+	case left == TypeDateTime && right == TypeDuration:
+		if op == "+" || op == "-" {
+			return TypeDateTime, true
+		}
+	case left == TypeDuration && right == TypeDateTime:
+		if op == "+" {
+			return TypeDateTime, true
+		}
+	}
+	return TypeAny, false
+}
+
+func negatedType(t Type) Type {
+	switch t {
+	case TypeInteger, TypeFloat, TypeDuration:
+		return t
+	}
+	return TypeAny
+}
+
+func (t Type) Accepts(other Type) bool {
+	switch {
+	case t == TypeAny || other == TypeAny || other == TypeNull:
+		return true
+	case t == TypeFloat && other == TypeInteger:
+		return true
+	default:
+		return t == other
+	}
+}
+
+// Schema maps the names of params, their fields joined by dots without
+// quotes, to their types.
+type Schema map[string]Type
+
+func (s Schema) Check(expr Expression) []error {
+	var errs []error
+	switch e := expr.(type) {
+	case *AndX:
+		for _, conjunct := range e.Conjuncts {
+			errs = append(errs, s.Check(conjunct)...)
+		}
+	case *OrX:
+		for _, disjunction := range e.Disjunctions {
+			errs = append(errs, s.Check(disjunction)...)
+		}
+	case Statement:
+		errs = append(errs, s.checkStatement(e)...)
+	case *CompareX:
+		errs = append(errs, s.checkCompare(e)...)
+	case *BetweenX:
+		errs = append(errs, s.checkBetween(e.Param, e.From, e.To)...)
+	case *NotBetweenX:
+		errs = append(errs, s.checkBetween(e.Param, e.From, e.To)...)
+	case *ExistsX:
+		_, errs = s.typeOf(e.Param)
+	case *IsNullX:
+		_, errs = s.typeOf(e.Param)
+	case *IsNotNullX:
+		_, errs = s.typeOf(e.Param)
+	case *IsEmptyX:
+		errs = s.checkEmpty(e.Param)
+	case *IsNotEmptyX:
+		errs = s.checkEmpty(e.Param)
+	case *AnyX:
+		errs = s.checkQuantifier(e.Param, e.Expr)
+	case *AllX:
+		errs = s.checkQuantifier(e.Param, e.Expr)
+	}
+	return errs
+}
+
+func (s Schema) typeOf(value Value) (Type, []error) {
+	switch v := value.(type) {
+	case *ParamX:
+		name := v.schemaName()
+		t, ok := s[name]
+		if !ok {
+			// the elements and values of arrays and maps have no types
+			if base := v.base(); base != name {
+				if _, ok := s[base]; ok {
+					return TypeAny, nil
+				}
+			}
+			return TypeAny, []error{UnknownParam(v.Name)}
+		}
+		return t, nil
+	case *ArithmeticX:
+		return s.arithmeticType(v)
+	case *FunctionCallX:
+		return s.callType(v)
+	}
+	return TypeOf(value), nil
+}
+
+func (s Schema) callType(e *FunctionCallX) (Type, []error) {
+	var errs []error
+	types := make([]Type, len(e.Args))
+	for i, arg := range e.Args {
+		t, argErrs := s.typeOf(arg)
+		types[i] = t
+		errs = append(errs, argErrs...)
+	}
+	if e.Function == nil {
+		return TypeAny, append(errs, UnknownFunction(e.Name))
+	}
+	if err := e.Function.CheckArguments(e.Name, types); err != nil {
+		errs = append(errs, err)
+	}
+	return e.Function.Returns, errs
+}
+
+func (s Schema) arithmeticType(e *ArithmeticX) (Type, []error) {
+	right, errs := s.typeOf(e.Right)
+	if e.Left == nil {
+		t := negatedType(right)
+		if t == TypeAny && right != TypeAny {
+			errs = append(errs, TypeMismatch(e.String(), TypeFloat, right))
+		}
+		return t, errs
+	}
+	left, leftErrs := s.typeOf(e.Left)
+	errs = append(leftErrs, errs...)
+	t, ok := arithmeticType(e.Operator, left, right)
+	if !ok {
+		errs = append(errs, TypeMismatch(e.String(), left, right))
+	}
+	return t, errs
+}
+
+func (s Schema) checkCompare(e *CompareX) []error {
+	left, errs := s.typeOf(e.Left)
+	right, rightErrs := s.typeOf(e.Right)
+	errs = append(errs, rightErrs...)
+	switch e.Operator {
+	case "=", "!=", "<", "<=", ">", ">=":
+		if !left.Accepts(right) && !right.Accepts(left) {
+			errs = append(errs, TypeMismatch(e.Left.String(), left, right))
+		}
+	}
+	return errs
+}
+
+func (s Schema) checkBetween(param *ParamX, from, to Value) []error {
+	expected, errs := s.typeOf(param)
+	if errs != nil {
+		return errs
+	}
+	for _, bound := range []Value{from, to} {
+		received, boundErrs := s.typeOf(bound)
+		errs = append(errs, boundErrs...)
+		if !expected.Accepts(received) {
+			errs = append(errs, TypeMismatch(param.Name, expected, received))
+		}
+	}
+	return errs
+}
+
+// checkEmpty checks that only strings and arrays are tested for emptiness.
+func (s Schema) checkEmpty(param *ParamX) []error {
+	t, errs := s.typeOf(param)
+	if errs == nil && !TypeString.Accepts(t) && !TypeArray.Accepts(t) {
+		errs = append(errs, TypeMismatch(param.Name, TypeString, t))
+	}
+	return errs
+}
+
+// checkQuantifier checks that the param is an array, and the expression
+// against the fields of its elements, given as items.price for an array
+// items. Without such fields, the elements are not checked.
+func (s Schema) checkQuantifier(param *ParamX, expr Expression) []error {
+	t, errs := s.typeOf(param)
+	if errs == nil && !TypeArray.Accepts(t) {
+		errs = append(errs, TypeMismatch(param.Name, TypeArray, t))
+	}
+	fields := Schema{}
+	prefix := param.schemaName() + "."
+	for name, t := range s {
+		if strings.HasPrefix(name, prefix) {
+			fields[strings.TrimPrefix(name, prefix)] = t
+		}
+	}
+	if len(fields) > 0 {
+		errs = append(errs, fields.Check(expr)...)
+	}
+	return errs
+}
+
+func (s Schema) checkStatement(st Statement) []error {
+	param := st.GetParam()
+	expected, errs := s.typeOf(param)
+	if errs != nil {
+		return errs
+	}
+
+	value := st.GetValue()
+	received, errs := s.typeOf(value)
+
+	switch st.(type) {
+	case *StartsWithX, *EndsWithX, *IStartsWithX, *IEndsWithX, *IEqualsX, *ContainsX, *NotContainsX,
+		*LikeX, *NotLikeX, *ILikeX, *GlobX,
+		*MatchRegexpX, *NotMatchRegexpX:
+		if !TypeString.Accepts(expected) {
+			errs = append(errs, TypeMismatch(param.Name, TypeString, expected))
+		}
+	case *HasX, *NotHasX, *HasAnyX, *HasAllX:
+		if !TypeArray.Accepts(expected) {
+			errs = append(errs, TypeMismatch(param.Name, TypeArray, expected))
+		}
+	case *InSliceX, *NotInSliceX:
+		for _, item := range value.(*SliceX).Values {
+			if t := TypeOf(item); !expected.Accepts(t) {
+				errs = append(errs, TypeMismatch(param.Name, expected, t))
+			}
+		}
+	default:
+		if !expected.Accepts(received) {
+			errs = append(errs, TypeMismatch(param.Name, expected, received))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,180 @@
+package lep
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestTypeOf(t *testing.T) {
+	type testTypeOf struct {
+		value  Value
+		result Type
+	}
+	var tests = []testTypeOf{
+		{value: String("foo"), result: TypeString},
+		{value: Integer(1), result: TypeInteger},
+		{value: Float(1.5), result: TypeFloat},
+		{value: Boolean(true), result: TypeBoolean},
+		{value: DateTime(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), "2006-01-02"), result: TypeDateTime},
+		{value: Now(Duration(time.Hour)), result: TypeDateTime},
+		{value: Decimal(big.NewRat(3, 1)), result: TypeInteger},
+		{value: Decimal(big.NewRat(1, 10)), result: TypeFloat},
+		{value: Slice(Integer(1)), result: TypeArray},
+		{value: Null(), result: TypeNull},
+		{value: Param("a"), result: TypeAny},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.result, TypeOf(tt.value))
+	}
+}
+
+func TestSchema_Check(t *testing.T) {
+	schema := Schema{
+		"name":       TypeString,
+		"nick":       TypeString,
+		"age":        TypeInteger,
+		"score":      TypeFloat,
+		"active":     TypeBoolean,
+		"created_at": TypeDateTime,
+		"tags":       TypeArray,
+		"meta":       TypeAny,
+		"elapsed":    TypeDuration,
+		"lines":      TypeArray,
+		"lines.sku":  TypeString,
+		"lines.qty":  TypeInteger,
+	}
+
+	type testSchemaCheck struct {
+		query string
+		errs  []error
+	}
+	var tests = []testSchemaCheck{
+		{
+			query: `name="foo" && age>=18 && score<1 && score>0.5 && active=true && created_at>dt:"2020-01-01"`,
+		},
+		{
+			query: `name =* nick && name contains "a" && name=nick && name starts_with nick && tags has 1 && tags has_any [1,2] && meta=1 && meta="foo"`,
+		},
+		{
+			query: `name=null || age!=null || age in [1,2,null] || name =~ /foo/`,
+		},
+		{
+			query: `created_at > now() - 1d * 2 && created_at < today() + 1h - elapsed * 2`,
+		},
+		{
+			query: `foo=1 || name=bar`,
+			errs:  []error{UnknownParam("foo"), UnknownParam("bar")},
+		},
+		{
+			query: `name=1 && age=1.5 && active="true"`,
+			errs: []error{
+				TypeMismatch("name", TypeString, TypeInteger),
+				TypeMismatch("age", TypeInteger, TypeFloat),
+				TypeMismatch("active", TypeBoolean, TypeString),
+			},
+		},
+		{
+			query: `age starts_with "1" || name has "a" || age in [1,"2"] || name=age || age istarts_with "1" || age glob "1*"`,
+			errs: []error{
+				TypeMismatch("age", TypeString, TypeInteger),
+				TypeMismatch("name", TypeArray, TypeString),
+				TypeMismatch("age", TypeInteger, TypeString),
+				TypeMismatch("name", TypeString, TypeInteger),
+				TypeMismatch("age", TypeString, TypeInteger),
+				TypeMismatch("age", TypeString, TypeInteger),
+			},
+		},
+		{
+			query: `18 <= age && "a" in tags && [1,2] has_all tags && 1 = foo && "x" = 1`,
+			errs: []error{
+				UnknownParam("foo"),
+				TypeMismatch(`"x"`, TypeString, TypeInteger),
+			},
+		},
+		{
+			query: `age between 1 and "9" && name in ["a".."b") && foo not_between 1 and 2 && age in [1..bar]`,
+			errs: []error{
+				TypeMismatch("age", TypeInteger, TypeString),
+				UnknownParam("foo"),
+				UnknownParam("bar"),
+			},
+		},
+		{
+			query: `exists(foo) && age is null && age is empty && name is not empty && tags is empty`,
+			errs: []error{
+				UnknownParam("foo"),
+				TypeMismatch("age", TypeString, TypeInteger),
+			},
+		},
+		{
+			query: `any(lines, sku = "a" && qty > 1) && all(lines, qty = "1" || foo = 1) && any(age, a = 1) && tags[*].a = 1`,
+			errs: []error{
+				TypeMismatch("qty", TypeInteger, TypeString),
+				UnknownParam("foo"),
+				TypeMismatch("age", TypeArray, TypeInteger),
+			},
+		},
+		{
+			query: `tags[0] = 1 && meta["a"][-1] = "x" && foo[0] = 1 && age.x = 1`,
+			errs: []error{
+				UnknownParam("foo[0]"),
+				UnknownParam("age.x"),
+			},
+		},
+		{
+			query: `age * 2 > score && score / age = 0.5 && created_at - 1d > created_at - elapsed && -age < 0`,
+		},
+		{
+			query: `age / 2 = 1 && created_at + age > created_at && -name = foo * 2 && age = score / 2`,
+			errs: []error{
+				TypeMismatch("created_at + age", TypeDateTime, TypeInteger),
+				TypeMismatch("-name", TypeFloat, TypeString),
+				UnknownParam("foo"),
+				TypeMismatch("age", TypeInteger, TypeFloat),
+			},
+		},
+		{
+			query: `lower(name) = nick && len(tags) > age && year(created_at) = 2020 && abs(score) < score`,
+		},
+		{
+			query: `lower(age) = "x" && len(foo) = 1 && age = lower(nick) && year(name) = 1`,
+			errs: []error{
+				InvalidArgument("lower", 1, TypeString, TypeInteger),
+				UnknownParam("foo"),
+				TypeMismatch("age", TypeInteger, TypeString),
+				InvalidArgument("year", 1, TypeDateTime, TypeString),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query)
+		if assert.NoError(t, err) {
+			assert.Equal(t, tt.errs, schema.Check(expr), tt.query)
+		}
+	}
+}
+
+func TestSchema_CheckQuoted(t *testing.T) {
+	schema := Schema{
+		"first name":       TypeString,
+		"in":               TypeInteger,
+		"_id":              TypeString,
+		"größe":            TypeFloat,
+		"users":            TypeArray,
+		"users.first name": TypeString,
+		"a.b`c":            TypeInteger,
+	}
+
+	query := "`first name` = \"x\" && `in` > 1 && _id = \"7\" && größe > 1.5 && any(users, `first name` = 1) && users[0].x = 1 && a.`b``c` = \"1\""
+	expr, err := ParseExpression(query, LeadingUnderscores(), UnicodeIdentifiers())
+	if assert.NoError(t, err) {
+		assert.Equal(t, []error{
+			TypeMismatch("`first name`", TypeString, TypeInteger),
+			TypeMismatch("a.`b``c`", TypeInteger, TypeString),
+		}, schema.Check(expr))
+	}
+}
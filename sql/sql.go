@@ -0,0 +1,944 @@
+// Package sql translates parsed expressions to SQL WHERE fragments with
+// placeholders and arguments.
+package sql
+
+import (
+	"fmt"
+	lep "github.com/mgudov/logic-expression-parser"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Dialect string
+
+const (
+	Postgres Dialect = "postgres"
+	MySQL    Dialect = "mysql"
+	SQLite   Dialect = "sqlite"
+)
+
+func ParseDialect(name string) (Dialect, error) {
+	switch d := Dialect(strings.ToLower(name)); d {
+	case Postgres, MySQL, SQLite:
+		return d, nil
+	case "postgresql", "pg":
+		return Postgres, nil
+	case "sqlite3":
+		return SQLite, nil
+	default:
+		return "", fmt.Errorf("sql: unknown dialect: %s", name)
+	}
+}
+
+type ErrUnsupported struct {
+	Dialect    Dialect
+	Expression lep.Expression
+}
+
+func (e ErrUnsupported) Error() string {
+	return fmt.Sprintf("sql: %T is not supported by dialect %s", e.Expression, e.Dialect)
+}
+
+type Option func(*translator)
+
+// Clock sets the clock that now(), today() and startOf() are resolved
+// against. The default is time.Now.
+func Clock(now func() time.Time) Option {
+	return func(t *translator) {
+		t.now = now
+	}
+}
+
+// Translate returns the WHERE fragment for the expression and the arguments
+// for its placeholders.
+func Translate(expr lep.Expression, dialect Dialect, opts ...Option) (string, []interface{}, error) {
+	if keys := lep.Placeholders(expr); len(keys) > 0 {
+		// placeholders are bound with lep.Bind before translating
+		return "", nil, lep.UnboundPlaceholder(keys[0])
+	}
+	t := &translator{dialect: dialect, now: time.Now}
+	for _, opt := range opts {
+		opt(t)
+	}
+	where, err := t.translate(expr)
+	if err != nil {
+		return "", nil, err
+	}
+	return where, t.args, nil
+}
+
+// NFKC normalizes both sides of the string operators to NFKC, like
+// lep.EvalNFKC. Only Postgres supports it.
+func NFKC() Option {
+	return func(t *translator) {
+		t.nfkc = true
+	}
+}
+
+type translator struct {
+	dialect Dialect
+	now     func() time.Time
+	nfkc    bool
+	args    []interface{}
+	// element is the alias of the array element params are fields of,
+	// inside a quantifier
+	element  string
+	elements int
+}
+
+func (t *translator) translate(expr lep.Expression) (string, error) {
+	switch e := expr.(type) {
+	default:
+		return "", lep.UnsupportedExpression("Translate", expr)
+	case *lep.AndX:
+		var items []string
+		for _, conjunct := range e.Conjuncts {
+			item, err := t.translate(conjunct)
+			if err != nil {
+				return "", err
+			}
+			if _, ok := conjunct.(*lep.OrX); ok {
+				item = "(" + item + ")"
+			}
+			items = append(items, item)
+		}
+		return strings.Join(items, " AND "), nil
+	case *lep.OrX:
+		var items []string
+		for _, disjunction := range e.Disjunctions {
+			item, err := t.translate(disjunction)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, item)
+		}
+		return strings.Join(items, " OR "), nil
+	case *lep.EqualsX:
+		if _, ok := e.Value.(*lep.NullX); ok {
+			return t.column(e.Param) + " IS NULL", nil
+		}
+		return t.compare(e.Param, "=", e.Value)
+	case *lep.NotEqualsX:
+		if _, ok := e.Value.(*lep.NullX); ok {
+			return t.column(e.Param) + " IS NOT NULL", nil
+		}
+		return t.compare(e.Param, "<>", e.Value)
+	case *lep.GreaterThanX:
+		return t.compare(e.Param, ">", e.Value)
+	case *lep.GreaterThanEqualX:
+		return t.compare(e.Param, ">=", e.Value)
+	case *lep.LessThanX:
+		return t.compare(e.Param, "<", e.Value)
+	case *lep.LessThanEqualX:
+		return t.compare(e.Param, "<=", e.Value)
+	case *lep.StartsWithX:
+		return t.like(expr, e.Param, e.Value, "", "%", false, false)
+	case *lep.EndsWithX:
+		return t.like(expr, e.Param, e.Value, "%", "", false, false)
+	case *lep.IStartsWithX:
+		return t.like(expr, e.Param, e.Value, "", "%", false, true)
+	case *lep.IEndsWithX:
+		return t.like(expr, e.Param, e.Value, "%", "", false, true)
+	case *lep.ContainsX:
+		return t.like(expr, e.Param, e.Value, "%", "%", false, false)
+	case *lep.NotContainsX:
+		return t.like(expr, e.Param, e.Value, "%", "%", true, false)
+	case *lep.IEqualsX:
+		return t.equalFold(expr, e.Param, e.Value)
+	case *lep.LikeX:
+		return t.likePattern(expr, e.Param, e.Value, false, false)
+	case *lep.NotLikeX:
+		return t.likePattern(expr, e.Param, e.Value, true, false)
+	case *lep.ILikeX:
+		return t.likePattern(expr, e.Param, e.Value, false, true)
+	case *lep.GlobX:
+		return t.glob(expr, e.Param, e.Value)
+	case *lep.InSliceX:
+		return t.in(e.Param, e.Slice, false)
+	case *lep.NotInSliceX:
+		return t.in(e.Param, e.Slice, true)
+	case *lep.MatchRegexpX:
+		return t.regexp(expr, e.Param, e.Regexp, false)
+	case *lep.NotMatchRegexpX:
+		return t.regexp(expr, e.Param, e.Regexp, true)
+	case *lep.HasX:
+		return t.has(expr, e.Param, e.Value, false)
+	case *lep.NotHasX:
+		return t.has(expr, e.Param, e.Value, true)
+	case *lep.HasAnyX:
+		return t.hasAny(expr, e.Param, e.Slice)
+	case *lep.HasAllX:
+		return t.hasAll(expr, e.Param, e.Slice)
+	case *lep.CompareX:
+		return t.compareValues(e)
+	case *lep.BetweenX:
+		if e.ExcludeFrom || e.ExcludeTo {
+			return t.translate(e.ToComparisons())
+		}
+		return t.between(e.Param, e.From, e.To, false)
+	case *lep.NotBetweenX:
+		if e.ExcludeFrom || e.ExcludeTo {
+			// the comparisons are an OR, which may be a conjunct
+			where, err := t.translate(e.ToComparisons())
+			if err != nil {
+				return "", err
+			}
+			return "(" + where + ")", nil
+		}
+		return t.between(e.Param, e.From, e.To, true)
+	case *lep.IsNullX:
+		return t.column(e.Param) + " IS NULL", nil
+	case *lep.IsNotNullX:
+		return t.column(e.Param) + " IS NOT NULL", nil
+	case *lep.ExistsX, *lep.IsEmptyX, *lep.IsNotEmptyX:
+		// columns always exist, and emptiness depends on their type
+		return "", ErrUnsupported{Dialect: t.dialect, Expression: expr}
+	case *lep.AnyX:
+		return t.quantifier(expr, e.Param, e.Expr, false)
+	case *lep.AllX:
+		return t.quantifier(expr, e.Param, e.Expr, true)
+	}
+}
+
+var simpleIdent = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// column writes a param: its fields are a column, qualified by its table,
+// and the indices and keys after them extract a JSON value as text. Inside
+// a quantifier, the whole param is a path in the element.
+func (t *translator) column(param *lep.ParamX) string {
+	return t.path(param, "->>")
+}
+
+// path writes a param whose JSON value is extracted with op: ->> for its
+// text, or -> for its jsonb value in Postgres.
+func (t *translator) path(param *lep.ParamX, op string) string {
+	base, path := t.element+".value", param.Segments()
+	if t.element == "" {
+		var parts []string
+		for len(path) > 0 && path[0].Kind == lep.SegmentField {
+			parts = append(parts, t.quoteIdent(path[0].Name))
+			path = path[1:]
+		}
+		base = strings.Join(parts, ".")
+	}
+	if len(path) == 0 {
+		return base
+	}
+	switch t.dialect {
+	case Postgres:
+		for i, segment := range path {
+			if i < len(path)-1 {
+				base += "->"
+			} else {
+				base += op
+			}
+			switch {
+			case segment.Kind == lep.SegmentIndex && segment.Index < 0:
+				// jsonb counts negative indices from the end too
+				base += "(" + strconv.Itoa(segment.Index) + ")"
+			case segment.Kind == lep.SegmentIndex:
+				base += strconv.Itoa(segment.Index)
+			default:
+				base += t.literal(segment.Name)
+			}
+		}
+		return base
+	case MySQL:
+		return base + "->>" + t.literal(t.jsonPath(path))
+	default:
+		return "json_extract(" + base + ", " + t.literal(t.jsonPath(path)) + ")"
+	}
+}
+
+var jsonKeyEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// jsonPath writes the path of a JSON value for MySQL and SQLite, where
+// negative indices are $[last] and $[#-1].
+func (t *translator) jsonPath(path []lep.Segment) string {
+	result := "$"
+	for _, segment := range path {
+		switch {
+		case segment.Kind != lep.SegmentIndex:
+			result += `."` + jsonKeyEscaper.Replace(segment.Name) + `"`
+		case segment.Index >= 0:
+			result += "[" + strconv.Itoa(segment.Index) + "]"
+		case t.dialect == MySQL && segment.Index == -1:
+			result += "[last]"
+		case t.dialect == MySQL:
+			result += "[last-" + strconv.Itoa(-segment.Index-1) + "]"
+		default:
+			result += "[#" + strconv.Itoa(segment.Index) + "]"
+		}
+	}
+	return result
+}
+
+// literal writes a string literal; MySQL also escapes backslashes.
+func (t *translator) literal(s string) string {
+	s = strings.ReplaceAll(s, "'", "''")
+	if t.dialect == MySQL {
+		s = strings.ReplaceAll(s, `\`, `\\`)
+	}
+	return "'" + s + "'"
+}
+
+// json reports whether a param is a jsonb value of Postgres: a field of an
+// element, or a param with indices or keys.
+func (t *translator) json(param *lep.ParamX) bool {
+	if t.element != "" {
+		return true
+	}
+	for _, segment := range param.Segments() {
+		if t.dialect == Postgres && segment.Kind != lep.SegmentField {
+			return true
+		}
+	}
+	return false
+}
+
+// typed writes a param compared with a value. JSON values of Postgres are
+// extracted as text, so they are cast to the type of the value.
+func (t *translator) typed(param *lep.ParamX, value lep.Value) string {
+	if !t.json(param) {
+		return t.column(param)
+	}
+	typ := lep.TypeOf(value)
+	if _, ok := value.(*lep.ArithmeticX); ok && typ == lep.TypeAny {
+		// arithmetic of fields, which are numeric
+		typ = lep.TypeFloat
+	}
+	switch typ {
+	case lep.TypeInteger, lep.TypeFloat:
+		return "(" + t.column(param) + ")::numeric"
+	case lep.TypeBoolean:
+		return "(" + t.column(param) + ")::boolean"
+	case lep.TypeDateTime:
+		return "(" + t.column(param) + ")::timestamptz"
+	}
+	return t.column(param)
+}
+
+// quantifier writes an EXISTS over the elements of a jsonb array for Postgres;
+// all is an EXISTS of an element which does not match.
+func (t *translator) quantifier(expr lep.Expression, param *lep.ParamX, inner lep.Expression, all bool) (string, error) {
+	if t.dialect != Postgres {
+		return "", ErrUnsupported{Dialect: t.dialect, Expression: expr}
+	}
+	array := t.path(param, "->")
+	outer := t.element
+	t.elements++
+	t.element = "e" + strconv.Itoa(t.elements)
+	alias := t.element
+	where, err := t.translate(inner)
+	t.element = outer
+	if err != nil {
+		return "", err
+	}
+	from := "SELECT 1 FROM jsonb_array_elements(" + array + ") AS " + alias + "(value) WHERE "
+	if all {
+		return "NOT EXISTS (" + from + "(" + where + ") IS NOT TRUE)", nil
+	}
+	return "EXISTS (" + from + where + ")", nil
+}
+
+func (t *translator) quoteIdent(name string) string {
+	if simpleIdent.MatchString(name) {
+		return name
+	}
+	if t.dialect == MySQL {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func (t *translator) placeholder(arg interface{}) string {
+	t.args = append(t.args, arg)
+	if t.dialect == Postgres {
+		return "$" + strconv.Itoa(len(t.args))
+	}
+	return "?"
+}
+
+func (t *translator) operand(value lep.Value) (string, error) {
+	switch v := value.(type) {
+	case *lep.ParamX:
+		return t.column(v), nil
+	case *lep.NullX:
+		return "", fmt.Errorf("sql: null can only be compared with = or !=")
+	case *lep.RegexpX:
+		return t.placeholder(v.Regexp.String()), nil
+	case *lep.RelativeDateTimeX:
+		return t.placeholder(v.Resolve(t.now())), nil
+	case *lep.DurationX:
+		return t.duration(v)
+	case *lep.ArithmeticX:
+		return t.arithmetic(v)
+	case *lep.FunctionCallX:
+		return t.call(v)
+	case *lep.DecimalX:
+		// a string keeps every digit; databases cast it to the column type
+		return t.placeholder(v.Canonical()), nil
+	default:
+		return t.placeholder(value.Value()), nil
+	}
+}
+
+// arithmetic writes an arithmetic operation, bracketing the operations it
+// is made of. Division and modulo by zero give null, as in the evaluator, and
+// integers are divided as decimals.
+func (t *translator) arithmetic(e *lep.ArithmeticX) (string, error) {
+	if e.Left == nil {
+		right, err := t.arithmeticOperand(e.Right)
+		if err != nil {
+			return "", err
+		}
+		return "-" + right, nil
+	}
+	left, err := t.arithmeticOperand(e.Left)
+	if err != nil {
+		return "", err
+	}
+	right, err := t.arithmeticOperand(e.Right)
+	if err != nil {
+		return "", err
+	}
+	switch e.Operator {
+	case "/":
+		// MySQL divides integers as decimals already
+		if t.dialect != MySQL {
+			left += " * 1.0"
+		}
+		right = "NULLIF(" + right + ", 0)"
+	case "%":
+		right = "NULLIF(" + right + ", 0)"
+	}
+	return left + " " + e.Operator + " " + right, nil
+}
+
+func (t *translator) arithmeticOperand(value lep.Value) (string, error) {
+	if param, ok := value.(*lep.ParamX); ok && t.element != "" {
+		return "(" + t.column(param) + ")::numeric", nil
+	}
+	operand, err := t.operand(value)
+	if err != nil {
+		return "", err
+	}
+	if e, ok := value.(*lep.ArithmeticX); ok && e.Left != nil {
+		operand = "(" + operand + ")"
+	}
+	return operand, nil
+}
+
+// sqlFunctions are the built-in functions which have the same name in every
+// dialect.
+var sqlFunctions = map[string]string{
+	"lower": "LOWER",
+	"upper": "UPPER",
+	"trim":  "TRIM",
+	"abs":   "ABS",
+	"round": "ROUND",
+	"floor": "FLOOR",
+	"ceil":  "CEIL",
+}
+
+var datePartFormats = map[string]string{
+	"year":  "%Y",
+	"month": "%m",
+	"day":   "%d",
+	"hour":  "%H",
+}
+
+// call writes a call of a built-in function; other functions are only known
+// to the evaluator.
+func (t *translator) call(e *lep.FunctionCallX) (string, error) {
+	if e.Function == nil || e.Function != lep.Builtins()[e.Name] {
+		return "", ErrUnsupported{Dialect: t.dialect, Expression: e}
+	}
+	args := make([]string, len(e.Args))
+	for i, arg := range e.Args {
+		operand, err := t.operand(arg)
+		if err != nil {
+			return "", err
+		}
+		args[i] = operand
+	}
+	if name, ok := sqlFunctions[e.Name]; ok {
+		return name + "(" + strings.Join(args, ", ") + ")", nil
+	}
+	switch e.Name {
+	case "concat":
+		if t.dialect == SQLite {
+			return "(" + strings.Join(args, " || ") + ")", nil
+		}
+		return "CONCAT(" + strings.Join(args, ", ") + ")", nil
+	case "min", "max":
+		// SQLite's min and max of several arguments are scalar functions
+		name := map[string]string{"min": "LEAST", "max": "GREATEST"}[e.Name]
+		if t.dialect == SQLite {
+			name = strings.ToUpper(e.Name)
+		}
+		return name + "(" + strings.Join(args, ", ") + ")", nil
+	case "year", "month", "day", "hour":
+		if t.dialect == SQLite {
+			return "CAST(strftime('" + datePartFormats[e.Name] + "', " + args[0] + ") AS INTEGER)", nil
+		}
+		return "EXTRACT(" + strings.ToUpper(e.Name) + " FROM " + args[0] + ")", nil
+	}
+	return "", ErrUnsupported{Dialect: t.dialect, Expression: e}
+}
+
+var intervalUnits = []struct {
+	name string
+	unit time.Duration
+}{
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+	{"millisecond", time.Millisecond},
+	{"microsecond", time.Microsecond},
+}
+
+// duration writes an interval literal for Postgres. The other dialects have
+// no interval type, so they get the duration in nanoseconds.
+func (t *translator) duration(d *lep.DurationX) (string, error) {
+	if t.dialect != Postgres {
+		return t.placeholder(int64(d.Val)), nil
+	}
+	if d.Val%time.Microsecond != 0 {
+		return "", fmt.Errorf("sql: interval %s is more precise than microseconds", d)
+	}
+	var sign string
+	val := d.Val
+	if val < 0 {
+		sign, val = "-", -val
+	}
+	var parts []string
+	for _, u := range intervalUnits {
+		n := val / u.unit
+		if n == 0 {
+			continue
+		}
+		val -= n * u.unit
+		part := sign + strconv.FormatInt(int64(n), 10) + " " + u.name
+		if n != 1 {
+			part += "s"
+		}
+		parts = append(parts, part)
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "0 seconds")
+	}
+	return "INTERVAL '" + strings.Join(parts, " ") + "'", nil
+}
+
+func (t *translator) compare(param *lep.ParamX, op string, value lep.Value) (string, error) {
+	if other, ok := value.(*lep.ParamX); ok && t.json(param) && t.json(other) {
+		// JSON values compared with each other keep their types
+		return t.path(param, "->") + " " + op + " " + t.path(other, "->"), nil
+	}
+	right, err := t.operand(value)
+	if err != nil {
+		return "", err
+	}
+	return t.typed(param, value) + " " + op + " " + right, nil
+}
+
+var sqlComparators = map[string]string{
+	"=":  "=",
+	"!=": "<>",
+	">":  ">",
+	">=": ">=",
+	"<":  "<",
+	"<=": "<=",
+}
+
+func (t *translator) between(param *lep.ParamX, from, to lep.Value, not bool) (string, error) {
+	lower, err := t.operand(from)
+	if err != nil {
+		return "", err
+	}
+	upper, err := t.operand(to)
+	if err != nil {
+		return "", err
+	}
+	op := " BETWEEN "
+	if not {
+		op = " NOT BETWEEN "
+	}
+	return t.typed(param, from) + op + lower + " AND " + upper, nil
+}
+
+// compareValues translates comparisons of two literals or of arrays; only
+// the comparators are supported.
+func (t *translator) compareValues(e *lep.CompareX) (string, error) {
+	op, ok := sqlComparators[e.Operator]
+	if !ok {
+		return "", ErrUnsupported{Dialect: t.dialect, Expression: e}
+	}
+	left, err := t.operand(e.Left)
+	if err != nil {
+		return "", err
+	}
+	right, err := t.operand(e.Right)
+	if err != nil {
+		return "", err
+	}
+	return left + " " + op + " " + right, nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// like writes a LIKE for the value between a prefix and a suffix, which is
+// escaped if it is a literal.
+func (t *translator) like(expr lep.Expression, param *lep.ParamX, value lep.Value, prefix, suffix string, not, fold bool) (string, error) {
+	if s, ok := value.(*lep.StringX); ok {
+		return t.match(expr, param, t.placeholder(prefix+likeEscaper.Replace(s.Val)+suffix), not, fold)
+	}
+	operand, err := t.operand(value)
+	if err != nil {
+		return "", err
+	}
+	return t.match(expr, param, t.concat(prefix, operand, suffix), not, fold)
+}
+
+// likePattern writes a like, not_like or ilike, whose patterns are LIKE
+// patterns with the escape character of the dialects already.
+func (t *translator) likePattern(expr lep.Expression, param *lep.ParamX, value lep.Value, not, fold bool) (string, error) {
+	pattern, err := t.operand(value)
+	if err != nil {
+		return "", err
+	}
+	return t.match(expr, param, pattern, not, fold)
+}
+
+// match writes a LIKE, or a case-insensitive match when fold is set:
+// ILIKE for Postgres and LOWER on both sides for the other dialects.
+func (t *translator) match(expr lep.Expression, param *lep.ParamX, pattern string, not, fold bool) (string, error) {
+	column, err := t.text(expr, t.column(param))
+	if err != nil {
+		return "", err
+	}
+	if pattern, err = t.text(expr, pattern); err != nil {
+		return "", err
+	}
+
+	op := "LIKE"
+	switch {
+	case fold && t.dialect == Postgres:
+		op = "ILIKE"
+	case fold:
+		column, pattern = "LOWER("+column+")", "LOWER("+pattern+")"
+	}
+	if not {
+		op = "NOT " + op
+	}
+	where := column + " " + op + " " + pattern
+	if t.dialect == SQLite {
+		where += ` ESCAPE '\'`
+	}
+	return where, nil
+}
+
+// glob writes a GLOB for SQLite. The other dialects get a LIKE, or a
+// regexp if the glob has classes. Only literal globs can be rewritten.
+func (t *translator) glob(expr lep.Expression, param *lep.ParamX, value lep.Value) (string, error) {
+	s, ok := value.(*lep.StringX)
+	if !ok {
+		return "", ErrUnsupported{Dialect: t.dialect, Expression: expr}
+	}
+	wildcards, err := lep.CompileGlob(s.Val)
+	if err != nil {
+		return "", err
+	}
+	column, err := t.text(expr, t.column(param))
+	if err != nil {
+		return "", err
+	}
+	if t.dialect == SQLite {
+		pattern, ok := sqliteGlob(wildcards)
+		if !ok {
+			return "", ErrUnsupported{Dialect: t.dialect, Expression: expr}
+		}
+		return column + " GLOB " + t.placeholder(pattern), nil
+	}
+	if pattern, ok := globLike(wildcards); ok {
+		return t.match(expr, param, t.placeholder(pattern), false, false)
+	}
+	op := " REGEXP "
+	if t.dialect == Postgres {
+		op = " ~ "
+	}
+	return column + op + t.placeholder(globRegexp(wildcards)), nil
+}
+
+// globLike rewrites a glob without classes as a LIKE pattern.
+func globLike(wildcards []lep.Wildcard) (string, bool) {
+	var b strings.Builder
+	for _, w := range wildcards {
+		switch w.Kind {
+		case lep.WildcardLiteral:
+			b.WriteString(likeEscaper.Replace(string(w.Rune)))
+		case lep.WildcardOne:
+			b.WriteByte('_')
+		case lep.WildcardAny:
+			b.WriteByte('%')
+		default:
+			return "", false
+		}
+	}
+	return b.String(), true
+}
+
+func globRegexp(wildcards []lep.Wildcard) string {
+	var b strings.Builder
+	b.WriteByte('^')
+	for _, w := range wildcards {
+		switch w.Kind {
+		case lep.WildcardLiteral:
+			b.WriteString(regexp.QuoteMeta(string(w.Rune)))
+		case lep.WildcardOne:
+			b.WriteByte('.')
+		case lep.WildcardAny:
+			b.WriteString(".*")
+		case lep.WildcardClass:
+			b.WriteByte('[')
+			if w.Negate {
+				b.WriteByte('^')
+			}
+			for _, r := range w.Ranges {
+				b.WriteString(classEscaper.Replace(string(r[0])))
+				if r[1] != r[0] {
+					b.WriteString("-" + classEscaper.Replace(string(r[1])))
+				}
+			}
+			b.WriteByte(']')
+		}
+	}
+	b.WriteByte('$')
+	return b.String()
+}
+
+var classEscaper = strings.NewReplacer(`\`, `\\`, `]`, `\]`, `[`, `\[`, `^`, `\^`, `-`, `\-`)
+
+// sqliteGlob writes a glob for SQLite, which has no escape character:
+// special characters are written as classes of one. Classes with special
+// characters cannot be written.
+func sqliteGlob(wildcards []lep.Wildcard) (string, bool) {
+	var b strings.Builder
+	for _, w := range wildcards {
+		switch w.Kind {
+		case lep.WildcardLiteral:
+			if strings.ContainsRune("*?[", w.Rune) {
+				b.WriteString("[" + string(w.Rune) + "]")
+			} else {
+				b.WriteRune(w.Rune)
+			}
+		case lep.WildcardOne:
+			b.WriteByte('?')
+		case lep.WildcardAny:
+			b.WriteByte('*')
+		case lep.WildcardClass:
+			b.WriteByte('[')
+			if w.Negate {
+				b.WriteByte('^')
+			}
+			for _, r := range w.Ranges {
+				if strings.ContainsAny(string(r[:]), "]-^") {
+					return "", false
+				}
+				b.WriteRune(r[0])
+				if r[1] != r[0] {
+					b.WriteString("-" + string(r[1]))
+				}
+			}
+			b.WriteByte(']')
+		}
+	}
+	return b.String(), true
+}
+
+func (t *translator) equalFold(expr lep.Expression, param *lep.ParamX, value lep.Value) (string, error) {
+	column, err := t.text(expr, t.column(param))
+	if err != nil {
+		return "", err
+	}
+	operand, err := t.operand(value)
+	if err != nil {
+		return "", err
+	}
+	if operand, err = t.text(expr, operand); err != nil {
+		return "", err
+	}
+	return "LOWER(" + column + ") = LOWER(" + operand + ")", nil
+}
+
+// text normalizes an operand of a string operator with the NFKC option.
+func (t *translator) text(expr lep.Expression, operand string) (string, error) {
+	if !t.nfkc {
+		return operand, nil
+	}
+	if t.dialect != Postgres {
+		return "", ErrUnsupported{Dialect: t.dialect, Expression: expr}
+	}
+	return "NORMALIZE(" + operand + ", NFKC)", nil
+}
+
+func (t *translator) concat(prefix, column, suffix string) string {
+	var items []string
+	if prefix != "" {
+		items = append(items, "'"+prefix+"'")
+	}
+	items = append(items, column)
+	if suffix != "" {
+		items = append(items, "'"+suffix+"'")
+	}
+	if t.dialect == MySQL {
+		return "CONCAT(" + strings.Join(items, ", ") + ")"
+	}
+	return strings.Join(items, " || ")
+}
+
+func (t *translator) list(slice *lep.SliceX) ([]string, error) {
+	var items []string
+	for _, value := range slice.Values {
+		item, err := t.operand(value)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (t *translator) in(param *lep.ParamX, slice *lep.SliceX, not bool) (string, error) {
+	if len(slice.Values) == 0 {
+		if not {
+			return "1 = 1", nil
+		}
+		return "1 = 0", nil
+	}
+	items, err := t.list(slice)
+	if err != nil {
+		return "", err
+	}
+	op := " IN "
+	if not {
+		op = " NOT IN "
+	}
+	return t.typed(param, slice.Values[0]) + op + "(" + strings.Join(items, ", ") + ")", nil
+}
+
+func (t *translator) regexp(expr lep.Expression, param *lep.ParamX, re *lep.RegexpX, not bool) (string, error) {
+	op := " REGEXP "
+	if not {
+		op = " NOT REGEXP "
+	}
+	if _, ok := re.Regexp.(*regexp.Regexp); !ok {
+		return "", ErrUnsupported{Dialect: t.dialect, Expression: expr}
+	}
+	pattern := re.Regexp.String()
+	switch t.dialect {
+	case Postgres:
+		// Postgres regexps take options with the operator, not inline
+		op = " ~ "
+		if re.Flags == "i" {
+			op = " ~* "
+			pattern = strings.TrimPrefix(pattern, "(?i)")
+		} else if re.Flags != "" {
+			return "", ErrUnsupported{Dialect: t.dialect, Expression: expr}
+		}
+		if not {
+			op = " !" + strings.TrimPrefix(op, " ")
+		}
+	case MySQL:
+		if strings.Contains(re.Flags, "U") {
+			return "", ErrUnsupported{Dialect: t.dialect, Expression: expr}
+		}
+	}
+	return t.column(param) + op + t.placeholder(pattern), nil
+}
+
+func (t *translator) has(expr lep.Expression, param *lep.ParamX, value lep.Value, not bool) (string, error) {
+	if t.element != "" {
+		// fields of elements are jsonb, not arrays
+		return "", ErrUnsupported{Dialect: t.dialect, Expression: expr}
+	}
+	item, err := t.operand(value)
+	if err != nil {
+		return "", err
+	}
+	var where string
+	switch t.dialect {
+	default:
+		return "", ErrUnsupported{Dialect: t.dialect, Expression: expr}
+	case Postgres:
+		where = item + " = ANY(" + t.column(param) + ")"
+	case MySQL:
+		where = "JSON_CONTAINS(" + t.column(param) + ", JSON_ARRAY(" + item + "))"
+	case SQLite:
+		where = "EXISTS (SELECT 1 FROM json_each(" + t.column(param) + ") WHERE value = " + item + ")"
+	}
+	if not {
+		where = "NOT " + where
+	}
+	return where, nil
+}
+
+func (t *translator) hasAny(expr lep.Expression, param *lep.ParamX, slice *lep.SliceX) (string, error) {
+	if t.element != "" {
+		// fields of elements are jsonb, not arrays
+		return "", ErrUnsupported{Dialect: t.dialect, Expression: expr}
+	}
+	if len(slice.Values) == 0 {
+		// an empty ARRAY[] has no type in Postgres
+		return "1 = 0", nil
+	}
+	items, err := t.list(slice)
+	if err != nil {
+		return "", err
+	}
+	list := strings.Join(items, ", ")
+	switch t.dialect {
+	default:
+		return "", ErrUnsupported{Dialect: t.dialect, Expression: expr}
+	case Postgres:
+		return t.column(param) + " && ARRAY[" + list + "]", nil
+	case MySQL:
+		return "JSON_OVERLAPS(" + t.column(param) + ", JSON_ARRAY(" + list + "))", nil
+	case SQLite:
+		return "EXISTS (SELECT 1 FROM json_each(" + t.column(param) + ") WHERE value IN (" + list + "))", nil
+	}
+}
+
+func (t *translator) hasAll(expr lep.Expression, param *lep.ParamX, slice *lep.SliceX) (string, error) {
+	if t.element != "" {
+		// fields of elements are jsonb, not arrays
+		return "", ErrUnsupported{Dialect: t.dialect, Expression: expr}
+	}
+	if len(slice.Values) == 0 {
+		return "1 = 1", nil
+	}
+	items, err := t.list(slice)
+	if err != nil {
+		return "", err
+	}
+	switch t.dialect {
+	default:
+		return "", ErrUnsupported{Dialect: t.dialect, Expression: expr}
+	case Postgres:
+		return t.column(param) + " @> ARRAY[" + strings.Join(items, ", ") + "]", nil
+	case MySQL:
+		return "JSON_CONTAINS(" + t.column(param) + ", JSON_ARRAY(" + strings.Join(items, ", ") + "))", nil
+	case SQLite:
+		// every item must be found among the elements of the JSON array
+		var selects []string
+		for _, item := range items {
+			selects = append(selects, "SELECT "+item+" AS value")
+		}
+		return "NOT EXISTS (SELECT 1 FROM (" + strings.Join(selects, " UNION ") + ") AS items" +
+			" WHERE items.value NOT IN (SELECT value FROM json_each(" + t.column(param) + ")))", nil
+	}
+}
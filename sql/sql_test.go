@@ -0,0 +1,468 @@
+package sql
+
+import (
+	lep "github.com/mgudov/logic-expression-parser"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseDialect(t *testing.T) {
+	type testParseDialect struct {
+		name    string
+		dialect Dialect
+		err     bool
+	}
+	var tests = []testParseDialect{
+		{name: "postgres", dialect: Postgres},
+		{name: "PostgreSQL", dialect: Postgres},
+		{name: "mysql", dialect: MySQL},
+		{name: "sqlite3", dialect: SQLite},
+		{name: "oracle", err: true},
+	}
+
+	for _, tt := range tests {
+		dialect, err := ParseDialect(tt.name)
+		if tt.err {
+			assert.Error(t, err)
+		} else if assert.NoError(t, err) {
+			assert.Equal(t, tt.dialect, dialect)
+		}
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	type testTranslate struct {
+		query   string
+		dialect Dialect
+		where   string
+		args    []interface{}
+	}
+	var tests = []testTranslate{
+		{
+			query:   `active=true && email!=null && (last_login>dt:"2010-01-01" || role in ["client","customer"])`,
+			dialect: Postgres,
+			where:   `active = $1 AND email IS NOT NULL AND (last_login > $2 OR role IN ($3, $4))`,
+			args:    []interface{}{true, time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC), "client", "customer"},
+		},
+		{
+			query:   `active=true && email!=null && (last_login>dt:"2010-01-01" || role in ["client","customer"])`,
+			dialect: MySQL,
+			where:   `active = ? AND email IS NOT NULL AND (last_login > ? OR role IN (?, ?))`,
+			args:    []interface{}{true, time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC), "client", "customer"},
+		},
+		{
+			query:   `a=null || b!=1 || c>=1.5 || d<e || f<=-1 || g not_in [1,2]`,
+			dialect: Postgres,
+			where:   `a IS NULL OR b <> $1 OR c >= $2 OR d < e OR f <= $3 OR g NOT IN ($4, $5)`,
+			args:    []interface{}{int64(1), 1.5, int64(-1), int64(1), int64(2)},
+		},
+		{
+			query:   `userName="x" && user.id=1`,
+			dialect: Postgres,
+			where:   `"userName" = $1 AND user.id = $2`,
+			args:    []interface{}{"x", int64(1)},
+		},
+		{
+			query:   `userName="x"`,
+			dialect: MySQL,
+			where:   "`userName` = ?",
+			args:    []interface{}{"x"},
+		},
+		{
+			query:   `a starts_with "50%_off" && b ends_with "x"`,
+			dialect: Postgres,
+			where:   `a LIKE $1 AND b LIKE $2`,
+			args:    []interface{}{`50\%\_off%`, `%x`},
+		},
+		{
+			query:   `a starts_with b && a ends_with b`,
+			dialect: MySQL,
+			where:   `a LIKE CONCAT(b, '%') AND a LIKE CONCAT('%', b)`,
+		},
+		{
+			query:   `a starts_with b && a ends_with "x"`,
+			dialect: SQLite,
+			where:   `a LIKE b || '%' ESCAPE '\' AND a LIKE ? ESCAPE '\'`,
+			args:    []interface{}{`%x`},
+		},
+		{
+			query:   `a =~ /[a-z]+/ && b !~ /[0-9]+/`,
+			dialect: MySQL,
+			where:   `a REGEXP ? AND b NOT REGEXP ?`,
+			args:    []interface{}{`[a-z]+`, `[0-9]+`},
+		},
+		{
+			query:   `a =~ /[a-z]+/ms && b !~ /[0-9]+/i`,
+			dialect: SQLite,
+			where:   `a REGEXP ? AND b NOT REGEXP ?`,
+			args:    []interface{}{`(?ms)[a-z]+`, `(?i)[0-9]+`},
+		},
+		{
+			query:   `a =~ /[a-z]+/ && b !~ /[0-9]+/ && c =~ /x/i && d !~ /y/i`,
+			dialect: Postgres,
+			where:   `a ~ $1 AND b !~ $2 AND c ~* $3 AND d !~* $4`,
+			args:    []interface{}{`[a-z]+`, `[0-9]+`, `x`, `y`},
+		},
+		{
+			query:   `a has 1 && b not_has "x" && c has_any [1,2] && d has_all [3,4]`,
+			dialect: Postgres,
+			where:   `$1 = ANY(a) AND NOT $2 = ANY(b) AND c && ARRAY[$3, $4] AND d @> ARRAY[$5, $6]`,
+			args:    []interface{}{int64(1), "x", int64(1), int64(2), int64(3), int64(4)},
+		},
+		{
+			query:   `a has 1 && c has_any [1,2] && d has_all [3,4]`,
+			dialect: MySQL,
+			where:   `JSON_CONTAINS(a, JSON_ARRAY(?)) AND JSON_OVERLAPS(c, JSON_ARRAY(?, ?)) AND JSON_CONTAINS(d, JSON_ARRAY(?, ?))`,
+			args:    []interface{}{int64(1), int64(1), int64(2), int64(3), int64(4)},
+		},
+		{
+			query:   `a has 1 && c has_any [1,2] && d has_all [3,4]`,
+			dialect: SQLite,
+			where: `EXISTS (SELECT 1 FROM json_each(a) WHERE value = ?) AND ` +
+				`EXISTS (SELECT 1 FROM json_each(c) WHERE value IN (?, ?)) AND ` +
+				`NOT EXISTS (SELECT 1 FROM (SELECT ? AS value UNION SELECT ? AS value) AS items ` +
+				`WHERE items.value NOT IN (SELECT value FROM json_each(d)))`,
+			args: []interface{}{int64(1), int64(1), int64(2), int64(3), int64(4)},
+		},
+		{
+			query:   `latency>250ms && ttl<=1w1d1h30m && delay>=-1s500us && age!=0s`,
+			dialect: Postgres,
+			where: `latency > INTERVAL '250 milliseconds' AND ttl <= INTERVAL '8 days 1 hour 30 minutes' AND ` +
+				`delay >= INTERVAL '-1 second -500 microseconds' AND age <> INTERVAL '0 seconds'`,
+		},
+		{
+			query:   `18 <= age && "admin" in roles && a in b && 1 = 1`,
+			dialect: Postgres,
+			where:   `age >= $1 AND $2 = ANY(roles) AND a = ANY(b) AND $3 = $4`,
+			args:    []interface{}{int64(18), "admin", int64(1), int64(1)},
+		},
+		{
+			query:   `price * quantity > 1000 && score / max >= 0.8 && -(a + 1) % 3 = b - c * 2`,
+			dialect: Postgres,
+			where:   `price * quantity > $1 AND score * 1.0 / NULLIF(max, 0) >= $2 AND -(a + $3) % NULLIF($4, 0) = b - (c * $5)`,
+			args:    []interface{}{int64(1000), 0.8, int64(1), int64(3), int64(2)},
+		},
+		{
+			query:   `score / max >= 0.8 && ends_at - 1d > starts_at`,
+			dialect: MySQL,
+			where:   `score / NULLIF(max, 0) >= ? AND starts_at < ends_at - ?`,
+			args:    []interface{}{0.8, int64(24 * time.Hour)},
+		},
+		{
+			query:   `lower(email) = "x@y.com" && abs(delta) < 5 && name starts_with upper(nick) && year(created_at) = 2020`,
+			dialect: Postgres,
+			where:   `LOWER(email) = $1 AND ABS(delta) < $2 AND name LIKE UPPER(nick) || '%' AND EXTRACT(YEAR FROM created_at) = $3`,
+			args:    []interface{}{"x@y.com", int64(5), int64(2020)},
+		},
+		{
+			query:   `concat(first, " ", last) = name && max(a, b) > 1 && month(created_at) = 5`,
+			dialect: SQLite,
+			where:   `name = (first || ? || last) AND MAX(a, b) > ? AND CAST(strftime('%m', created_at) AS INTEGER) = ?`,
+			args:    []interface{}{" ", int64(1), int64(5)},
+		},
+		{
+			query:   `concat(first, last) = name && min(a, b) > 1`,
+			dialect: MySQL,
+			where:   `name = CONCAT(first, last) AND LEAST(a, b) > ?`,
+			args:    []interface{}{int64(1)},
+		},
+		{
+			query:   `a istarts_with "x_" && b iends_with c && d =* "Foo" && e contains "50%" && f not_contains g`,
+			dialect: Postgres,
+			where:   `a ILIKE $1 AND b ILIKE '%' || c AND LOWER(d) = LOWER($2) AND e LIKE $3 AND f NOT LIKE '%' || g || '%'`,
+			args:    []interface{}{`x\_%`, "Foo", `%50\%%`},
+		},
+		{
+			query:   `a istarts_with "x" && d =* e && f not_contains "y"`,
+			dialect: MySQL,
+			where:   `LOWER(a) LIKE LOWER(?) AND LOWER(d) = LOWER(e) AND f NOT LIKE ?`,
+			args:    []interface{}{`x%`, `%y%`},
+		},
+		{
+			query:   `a iends_with "x" && f contains g`,
+			dialect: SQLite,
+			where:   `LOWER(a) LIKE LOWER(?) ESCAPE '\' AND f LIKE '%' || g || '%' ESCAPE '\'`,
+			args:    []interface{}{`%x`},
+		},
+		{
+			query:   `a like "x%\_" && b not_like c && d ilike "Foo%" && e glob "*.go" && f glob "[!a-c]?"`,
+			dialect: Postgres,
+			where:   `a LIKE $1 AND b NOT LIKE c AND d ILIKE $2 AND e LIKE $3 AND f ~ $4`,
+			args:    []interface{}{`x%\_`, "Foo%", `%.go`, `^[^a-c].$`},
+		},
+		{
+			query:   `d ilike "Foo%" && e glob "a_[0-9]" && f glob "[]^-]"`,
+			dialect: MySQL,
+			where:   `LOWER(d) LIKE LOWER(?) AND e REGEXP ? AND f REGEXP ?`,
+			args:    []interface{}{"Foo%", `^a_[0-9]$`, `^[\]\^\-]$`},
+		},
+		{
+			query:   `a like "50\%" && e glob "\*[a-z]?x*"`,
+			dialect: SQLite,
+			where:   `a LIKE ? ESCAPE '\' AND e GLOB ?`,
+			args:    []interface{}{`50\%`, `[*][a-z]?x*`},
+		},
+		{
+			query:   `age between 18 and 65 && score not_between lo and hi + 1`,
+			dialect: Postgres,
+			where:   `age BETWEEN $1 AND $2 AND score NOT BETWEEN lo AND hi + $3`,
+			args:    []interface{}{int64(18), int64(65), int64(1)},
+		},
+		{
+			query:   `ts in [dt:"2024-01-01"..dt:"2024-02-01") && age not_in (1..10] && b = 1`,
+			dialect: MySQL,
+			where:   `ts >= ? AND ts < ? AND (age <= ? OR age > ?) AND b = ?`,
+			args: []interface{}{
+				time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+				int64(1), int64(10), int64(1),
+			},
+		},
+		{
+			query:   `a is null && b is not null && c = null`,
+			dialect: SQLite,
+			where:   `a IS NULL AND b IS NOT NULL AND c IS NULL`,
+		},
+		{
+			query:   `latency>250ms && latency in [1s,2s]`,
+			dialect: SQLite,
+			where:   `latency > ? AND latency IN (?, ?)`,
+			args:    []interface{}{int64(250 * time.Millisecond), int64(time.Second), int64(2 * time.Second)},
+		},
+		{
+			query:   `any(items, price > 100 && qty >= 2) && all(items, status = "done")`,
+			dialect: Postgres,
+			where: `EXISTS (SELECT 1 FROM jsonb_array_elements(items) AS e1(value) WHERE (e1.value->>'price')::numeric > $1 AND (e1.value->>'qty')::numeric >= $2) AND ` +
+				`NOT EXISTS (SELECT 1 FROM jsonb_array_elements(items) AS e2(value) WHERE (e2.value->>'status' = $3) IS NOT TRUE)`,
+			args: []interface{}{int64(100), int64(2), "done"},
+		},
+		{
+			query:   `orders[*].items[*].sku in ["a","b"] || all(items, shipped_at is null || shipped_at < dt:"2020-01-01" && paid = true)`,
+			dialect: Postgres,
+			where: `EXISTS (SELECT 1 FROM jsonb_array_elements(orders) AS e1(value) WHERE EXISTS (SELECT 1 FROM jsonb_array_elements(e1.value->'items') AS e2(value) WHERE e2.value->>'sku' IN ($1, $2))) OR ` +
+				`NOT EXISTS (SELECT 1 FROM jsonb_array_elements(items) AS e3(value) WHERE (e3.value->>'shipped_at' IS NULL OR (e3.value->>'shipped_at')::timestamptz < $3 AND (e3.value->>'paid')::boolean = $4) IS NOT TRUE)`,
+			args: []interface{}{"a", "b", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		},
+		{
+			query:   `any(items, price * qty > total && address.city starts_with "Ber" && price between 1 and 10)`,
+			dialect: Postgres,
+			where: `EXISTS (SELECT 1 FROM jsonb_array_elements(items) AS e1(value) WHERE (e1.value->>'total')::numeric < (e1.value->>'price')::numeric * (e1.value->>'qty')::numeric AND ` +
+				`e1.value->'address'->>'city' LIKE $1 AND (e1.value->>'price')::numeric BETWEEN $2 AND $3)`,
+			args: []interface{}{"Ber%", int64(1), int64(10)},
+		},
+		{
+			query:   "tags[0] = \"a\" && attrs[\"content-type\"] starts_with \"text/\" && matrix[1][-1] > 3 && `first name` = \"x\" && a.b[\"it's\"] = true",
+			dialect: Postgres,
+			where:   `tags->>0 = $1 AND attrs->>'content-type' LIKE $2 AND (matrix->1->>(-1))::numeric > $3 AND "first name" = $4 AND (a.b->>'it''s')::boolean = $5`,
+			args:    []interface{}{"a", "text/%", int64(3), "x", true},
+		},
+		{
+			query:   `tags[0] = "a" && matrix[1][-1] > 3 && m[-3] = 1 && a.b["x\"y"] = true && attrs["a"] > attrs["b"]`,
+			dialect: MySQL,
+			where:   `tags->>'$[0]' = ? AND matrix->>'$[1][last]' > ? AND m->>'$[last-2]' = ? AND a.b->>'$."x\\"y"' = ? AND attrs->>'$."a"' > attrs->>'$."b"'`,
+			args:    []interface{}{"a", int64(3), int64(1), true},
+		},
+		{
+			query:   `tags[0] = "a" && matrix[1][-1] > 3 && a.b["x\"y"] = true`,
+			dialect: SQLite,
+			where:   `json_extract(tags, '$[0]') = ? AND json_extract(matrix, '$[1][#-1]') > ? AND json_extract(a.b, '$."x\"y"') = ?`,
+			args:    []interface{}{"a", int64(3), true},
+		},
+		{
+			query:   `attrs["a"] > attrs["b"] && any(orders[-1].items, tags[0] = "x")`,
+			dialect: Postgres,
+			where: `attrs->'a' > attrs->'b' AND ` +
+				`EXISTS (SELECT 1 FROM jsonb_array_elements(orders->(-1)->'items') AS e1(value) WHERE e1.value->'tags'->>0 = $1)`,
+			args: []interface{}{"x"},
+		},
+		{
+			query:   `any(items, price > cost)`,
+			dialect: Postgres,
+			where:   `EXISTS (SELECT 1 FROM jsonb_array_elements(items) AS e1(value) WHERE e1.value->'price' > e1.value->'cost')`,
+		},
+	}
+
+	for _, tt := range tests {
+		expr, err := lep.ParseExpression(tt.query)
+		if assert.NoError(t, err) {
+			where, args, err := Translate(expr, tt.dialect)
+			if assert.NoError(t, err, tt.query) {
+				assert.Equal(t, tt.where, where)
+				assert.Equal(t, tt.args, args)
+			}
+		}
+	}
+}
+
+func TestTranslate_Glob(t *testing.T) {
+	for _, query := range []string{`e glob f`, `e glob "[]a]"`} {
+		expr, err := lep.ParseExpression(query)
+		if assert.NoError(t, err) {
+			_, _, err = Translate(expr, SQLite)
+			assert.Equal(t, ErrUnsupported{Dialect: SQLite, Expression: expr}, err, query)
+		}
+	}
+}
+
+func TestTranslate_Checks(t *testing.T) {
+	for _, query := range []string{`exists(a)`, `a is empty`, `a is not empty`} {
+		expr, err := lep.ParseExpression(query)
+		if assert.NoError(t, err) {
+			_, _, err = Translate(expr, Postgres)
+			assert.Equal(t, ErrUnsupported{Dialect: Postgres, Expression: expr}, err, query)
+		}
+	}
+}
+
+func TestTranslate_Quantifiers(t *testing.T) {
+	expr, err := lep.ParseExpression(`any(items, price > 1)`)
+	if assert.NoError(t, err) {
+		for _, dialect := range []Dialect{MySQL, SQLite} {
+			_, _, err = Translate(expr, dialect)
+			assert.Equal(t, ErrUnsupported{Dialect: dialect, Expression: expr}, err)
+		}
+	}
+
+	expr, err = lep.ParseExpression(`any(items, tags has "a")`)
+	if assert.NoError(t, err) {
+		_, _, err = Translate(expr, Postgres)
+		assert.Equal(t, ErrUnsupported{Dialect: Postgres, Expression: expr.(*lep.AnyX).Expr}, err)
+	}
+}
+
+func TestTranslate_Clock(t *testing.T) {
+	now := time.Date(2021, 5, 12, 15, 4, 5, 0, time.UTC)
+	expr, err := lep.ParseExpression(`created_at>now() - 7d && created_at<startOf("month")`)
+	if assert.NoError(t, err) {
+		where, args, err := Translate(expr, Postgres, Clock(func() time.Time { return now }))
+		if assert.NoError(t, err) {
+			assert.Equal(t, `created_at > $1 AND created_at < $2`, where)
+			assert.Equal(t, []interface{}{
+				time.Date(2021, 5, 5, 15, 4, 5, 0, time.UTC),
+				time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC),
+			}, args)
+		}
+	}
+}
+
+func TestTranslate_NFKC(t *testing.T) {
+	expr, err := lep.ParseExpression(`name istarts_with "ﬁ" && title =* nick && a = "ﬁ"`)
+	if assert.NoError(t, err) {
+		where, args, err := Translate(expr, Postgres, NFKC())
+		if assert.NoError(t, err) {
+			assert.Equal(t, `NORMALIZE(name, NFKC) ILIKE NORMALIZE($1, NFKC) AND `+
+				`LOWER(NORMALIZE(title, NFKC)) = LOWER(NORMALIZE(nick, NFKC)) AND a = $2`, where)
+			assert.Equal(t, []interface{}{"ﬁ%", "ﬁ"}, args)
+		}
+
+		_, _, err = Translate(expr, MySQL, NFKC())
+		assert.Equal(t, ErrUnsupported{Dialect: MySQL, Expression: expr.(*lep.AndX).Conjuncts[0]}, err)
+	}
+}
+
+func TestTranslate_Decimal(t *testing.T) {
+	expr, err := lep.ParseExpression(`amount=0.10 && id in [123456789012345678901,2]`, lep.DecimalNumbers())
+	if assert.NoError(t, err) {
+		where, args, err := Translate(expr, MySQL)
+		if assert.NoError(t, err) {
+			assert.Equal(t, `amount = ? AND id IN (?, ?)`, where)
+			assert.Equal(t, []interface{}{"0.1", "123456789012345678901", "2"}, args)
+		}
+	}
+}
+
+func TestTranslate_Placeholders(t *testing.T) {
+	expr, err := lep.ParseExpression(`tenant_id = :tenant && status in :statuses`)
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, _, err = Translate(expr, Postgres)
+	assert.Equal(t, lep.UnboundPlaceholder("tenant"), err)
+
+	bound, err := lep.Bind(expr, map[string]interface{}{"tenant": 7, "statuses": []string{"new", "paid"}})
+	if assert.NoError(t, err) {
+		where, args, err := Translate(bound, Postgres)
+		if assert.NoError(t, err) {
+			assert.Equal(t, `tenant_id = $1 AND status IN ($2, $3)`, where)
+			assert.Equal(t, []interface{}{int64(7), "new", "paid"}, args)
+		}
+	}
+}
+
+func TestTranslate_EmptySlices(t *testing.T) {
+	expr, err := lep.ParseExpression(`a has_any :a && b has_all :b && c in :c || d not_in :d`)
+	if !assert.NoError(t, err) {
+		return
+	}
+	expr, err = lep.Bind(expr, map[string]interface{}{"a": []int{}, "b": []int{}, "c": []int{}, "d": []int{}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	for _, dialect := range []Dialect{Postgres, MySQL, SQLite} {
+		where, args, err := Translate(expr, dialect)
+		if assert.NoError(t, err, dialect) {
+			assert.Equal(t, `1 = 0 AND 1 = 1 AND 1 = 0 OR 1 = 1`, where, dialect)
+			assert.Empty(t, args, dialect)
+		}
+	}
+}
+
+func TestTranslate_ParamWithoutPath(t *testing.T) {
+	expr := lep.And(lep.Equals(&lep.ParamX{Name: "users.age"}, lep.Integer(5)), lep.Equals(lep.Param("users.age"), lep.Integer(6)))
+	where, args, err := Translate(expr, Postgres)
+	if assert.NoError(t, err) {
+		assert.Equal(t, `users.age = $1 AND users.age = $2`, where)
+		assert.Equal(t, []interface{}{int64(5), int64(6)}, args)
+	}
+}
+
+func TestTranslate_Errors(t *testing.T) {
+	type testTranslateErrors struct {
+		expr    lep.Expression
+		dialect Dialect
+		err     error
+	}
+	var tests = []testTranslateErrors{
+		{
+			expr:    lep.Param("a"),
+			dialect: Postgres,
+			err:     lep.UnsupportedExpression("Translate", lep.Param("a")),
+		},
+		{
+			expr:    lep.Has(lep.Param("a"), lep.Integer(1)),
+			dialect: "oracle",
+			err:     ErrUnsupported{Dialect: "oracle", Expression: lep.Has(lep.Param("a"), lep.Integer(1))},
+		},
+	}
+
+	for _, tt := range tests {
+		_, _, err := Translate(tt.expr, tt.dialect)
+		assert.EqualError(t, err, tt.err.Error())
+	}
+
+	_, _, err := Translate(lep.GreaterThan(lep.Param("a"), lep.Null()), Postgres)
+	assert.Error(t, err)
+
+	cmp := lep.Compare(lep.String("foo"), "starts_with", lep.Param("a"))
+	_, _, err = Translate(cmp, Postgres)
+	assert.Equal(t, ErrUnsupported{Dialect: Postgres, Expression: cmp}, err)
+
+	call := lep.Call("len", lep.Param("tags"))
+	_, _, err = Translate(lep.Compare(call, ">", lep.Integer(3)), Postgres)
+	assert.Equal(t, ErrUnsupported{Dialect: Postgres, Expression: call}, err)
+
+	custom := &lep.FunctionCallX{Name: "lower", Args: []lep.Value{lep.Param("a")}, Function: &lep.Function{}}
+	_, _, err = Translate(lep.Equals(lep.Param("b"), custom), Postgres)
+	assert.Equal(t, ErrUnsupported{Dialect: Postgres, Expression: custom}, err)
+
+	_, _, err = Translate(lep.GreaterThan(lep.Param("a"), lep.Duration(1500)), Postgres)
+	assert.Error(t, err)
+
+	re := lep.MatchRegexp(lep.Param("a"), lep.MustCompileRegexp("x", "m"))
+	_, _, err = Translate(re, Postgres)
+	assert.Equal(t, ErrUnsupported{Dialect: Postgres, Expression: re}, err)
+
+	re = lep.MatchRegexp(lep.Param("a"), lep.MustCompileRegexp("x", "U"))
+	_, _, err = Translate(re, MySQL)
+	assert.Equal(t, ErrUnsupported{Dialect: MySQL, Expression: re}, err)
+}
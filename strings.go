@@ -91,3 +91,237 @@ func parseEndsWith(left, right interface{}) (*EndsWithX, error) {
 	}
 	return EndsWith(param, stringify), nil
 }
+
+// IStartsWithX is starts_with ignoring case.
+type IStartsWithX struct {
+	Param *ParamX
+	Value Stringify
+}
+
+var _ Expression = (*IStartsWithX)(nil)
+var _ Statement = (*IStartsWithX)(nil)
+
+func IStartsWith(param *ParamX, value Stringify) *IStartsWithX {
+	return &IStartsWithX{
+		Param: param,
+		Value: value,
+	}
+}
+
+func (e IStartsWithX) GetParam() *ParamX {
+	return e.Param
+}
+
+func (e IStartsWithX) GetValue() Value {
+	return e.Value
+}
+
+func (e IStartsWithX) Equals(other Expression) bool {
+	if expr, ok := other.(*IStartsWithX); ok {
+		return e.Param.Equals(expr.Param) && e.Value.Equals(expr.Value)
+	}
+	return false
+}
+
+func (e IStartsWithX) String() string {
+	return e.Param.String() + " istarts_with " + e.Value.String()
+}
+
+func parseIStartsWith(left, right interface{}) (*IStartsWithX, error) {
+	param, value, err := parseStatement(left, right)
+	if err != nil {
+		return nil, err
+	}
+	stringify, ok := value.(Stringify)
+	if !ok {
+		return nil, IncorrectType("parseIStartsWith", (Stringify)(nil), right)
+	}
+	return IStartsWith(param, stringify), nil
+}
+
+// IEndsWithX is ends_with ignoring case.
+type IEndsWithX struct {
+	Param *ParamX
+	Value Stringify
+}
+
+var _ Expression = (*IEndsWithX)(nil)
+var _ Statement = (*IEndsWithX)(nil)
+
+func IEndsWith(param *ParamX, value Stringify) *IEndsWithX {
+	return &IEndsWithX{
+		Param: param,
+		Value: value,
+	}
+}
+
+func (e IEndsWithX) GetParam() *ParamX {
+	return e.Param
+}
+
+func (e IEndsWithX) GetValue() Value {
+	return e.Value
+}
+
+func (e IEndsWithX) Equals(other Expression) bool {
+	if expr, ok := other.(*IEndsWithX); ok {
+		return e.Param.Equals(expr.Param) && e.Value.Equals(expr.Value)
+	}
+	return false
+}
+
+func (e IEndsWithX) String() string {
+	return e.Param.String() + " iends_with " + e.Value.String()
+}
+
+func parseIEndsWith(left, right interface{}) (*IEndsWithX, error) {
+	param, value, err := parseStatement(left, right)
+	if err != nil {
+		return nil, err
+	}
+	stringify, ok := value.(Stringify)
+	if !ok {
+		return nil, IncorrectType("parseIEndsWith", (Stringify)(nil), right)
+	}
+	return IEndsWith(param, stringify), nil
+}
+
+// IEqualsX is = ignoring case, written =* or ieq.
+type IEqualsX struct {
+	Param *ParamX
+	Value Stringify
+}
+
+var _ Expression = (*IEqualsX)(nil)
+var _ Statement = (*IEqualsX)(nil)
+
+func IEquals(param *ParamX, value Stringify) *IEqualsX {
+	return &IEqualsX{
+		Param: param,
+		Value: value,
+	}
+}
+
+func (e IEqualsX) GetParam() *ParamX {
+	return e.Param
+}
+
+func (e IEqualsX) GetValue() Value {
+	return e.Value
+}
+
+func (e IEqualsX) Equals(other Expression) bool {
+	if expr, ok := other.(*IEqualsX); ok {
+		return e.Param.Equals(expr.Param) && e.Value.Equals(expr.Value)
+	}
+	return false
+}
+
+func (e IEqualsX) String() string {
+	return e.Param.String() + "=*" + e.Value.String()
+}
+
+func parseIEquals(left, right interface{}) (*IEqualsX, error) {
+	param, value, err := parseStatement(left, right)
+	if err != nil {
+		return nil, err
+	}
+	stringify, ok := value.(Stringify)
+	if !ok {
+		return nil, IncorrectType("parseIEquals", (Stringify)(nil), right)
+	}
+	return IEquals(param, stringify), nil
+}
+
+// ContainsX matches strings which contain the value.
+type ContainsX struct {
+	Param *ParamX
+	Value Stringify
+}
+
+var _ Expression = (*ContainsX)(nil)
+var _ Statement = (*ContainsX)(nil)
+
+func Contains(param *ParamX, value Stringify) *ContainsX {
+	return &ContainsX{
+		Param: param,
+		Value: value,
+	}
+}
+
+func (e ContainsX) GetParam() *ParamX {
+	return e.Param
+}
+
+func (e ContainsX) GetValue() Value {
+	return e.Value
+}
+
+func (e ContainsX) Equals(other Expression) bool {
+	if expr, ok := other.(*ContainsX); ok {
+		return e.Param.Equals(expr.Param) && e.Value.Equals(expr.Value)
+	}
+	return false
+}
+
+func (e ContainsX) String() string {
+	return e.Param.String() + " contains " + e.Value.String()
+}
+
+func parseContains(left, right interface{}) (*ContainsX, error) {
+	param, value, err := parseStatement(left, right)
+	if err != nil {
+		return nil, err
+	}
+	stringify, ok := value.(Stringify)
+	if !ok {
+		return nil, IncorrectType("parseContains", (Stringify)(nil), right)
+	}
+	return Contains(param, stringify), nil
+}
+
+type NotContainsX struct {
+	Param *ParamX
+	Value Stringify
+}
+
+var _ Expression = (*NotContainsX)(nil)
+var _ Statement = (*NotContainsX)(nil)
+
+func NotContains(param *ParamX, value Stringify) *NotContainsX {
+	return &NotContainsX{
+		Param: param,
+		Value: value,
+	}
+}
+
+func (e NotContainsX) GetParam() *ParamX {
+	return e.Param
+}
+
+func (e NotContainsX) GetValue() Value {
+	return e.Value
+}
+
+func (e NotContainsX) Equals(other Expression) bool {
+	if expr, ok := other.(*NotContainsX); ok {
+		return e.Param.Equals(expr.Param) && e.Value.Equals(expr.Value)
+	}
+	return false
+}
+
+func (e NotContainsX) String() string {
+	return e.Param.String() + " not_contains " + e.Value.String()
+}
+
+func parseNotContains(left, right interface{}) (*NotContainsX, error) {
+	param, value, err := parseStatement(left, right)
+	if err != nil {
+		return nil, err
+	}
+	stringify, ok := value.(Stringify)
+	if !ok {
+		return nil, IncorrectType("parseNotContains", (Stringify)(nil), right)
+	}
+	return NotContains(param, stringify), nil
+}
@@ -160,3 +160,82 @@ func TestEndsWith_Equals(t *testing.T) {
 		assert.Equal(t, tt.result, tt.e2.Equals(tt.e1))
 	}
 }
+
+func TestParseStringOperators(t *testing.T) {
+	var (
+		name = Param("name")
+		nick = Param("nick")
+	)
+
+	type testParseStringOperators struct {
+		query string
+		expr  Expression
+		str   string
+	}
+	var tests = []testParseStringOperators{
+		{query: `name istarts_with "jo"`, expr: IStartsWith(name, String("jo")), str: `name istarts_with "jo"`},
+		{query: `name iends_with nick`, expr: IEndsWith(name, nick), str: `name iends_with nick`},
+		{query: `name =* "JOHN"`, expr: IEquals(name, String("JOHN")), str: `name=*"JOHN"`},
+		{query: `name ieq "JOHN"`, expr: IEquals(name, String("JOHN")), str: `name=*"JOHN"`},
+		{query: `"JOHN" =* name`, expr: IEquals(name, String("JOHN")), str: `name=*"JOHN"`},
+		{query: `name contains "oh"`, expr: Contains(name, String("oh")), str: `name contains "oh"`},
+		{query: `name not_contains lower(nick)`, expr: NotContains(name, Call("lower", nick)), str: `name not_contains lower(nick)`},
+		{query: `"John" contains nick`, expr: Compare(String("John"), "contains", nick), str: `"John" contains nick`},
+		{query: `containsX = 1`, expr: Equals(Param("containsX"), Integer(1)), str: `containsX=1`},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query)
+		if assert.NoError(t, err, tt.query) {
+			assert.True(t, tt.expr.Equals(expr), "%s: %s", tt.query, expr)
+			assert.Equal(t, tt.str, expr.String())
+
+			reparsed, err := ParseExpression(expr.String())
+			if assert.NoError(t, err, expr.String()) {
+				assert.True(t, expr.Equals(reparsed), expr.String())
+			}
+		}
+	}
+
+	for _, query := range []string{`name =* 1`, `name ieqx "a"`, `name contains [1]`, `name icontains "a"`} {
+		_, err := ParseExpression(query)
+		assert.Error(t, err, query)
+	}
+}
+
+func TestStringOperators_Evaluate(t *testing.T) {
+	data := map[string]interface{}{
+		"name":   "Jürgen Straße",
+		"title":  "ﬁnance Ｍanager",
+		"nick":   "JÜRGEN",
+		"number": 42,
+	}
+
+	type testStringOperatorsEvaluate struct {
+		query  string
+		opts   []EvalOption
+		result bool
+	}
+	var tests = []testStringOperatorsEvaluate{
+		{query: `name istarts_with "jürgen" && name iends_with "STRASSE" && name istarts_with nick`, result: true},
+		{query: `name starts_with "jürgen" || name ends_with "STRASSE"`, result: false},
+		{query: `nick =* "jürgen" && nick ieq "JürGen" && "JÜRGEN" =* nick`, result: true},
+		{query: `nick =* "jurgen" || number =* "42" || missing =* "x"`, result: false},
+		{query: `name contains "gen Str" && name not_contains "gen str" && number not_contains "4"`, result: true},
+		{query: `name contains "" && "Jürgen" contains "rg"`, result: true},
+		{query: `title starts_with "fi" || title contains "Manager"`, result: false},
+		{query: `title istarts_with "FI" && name iends_with "strasse"`, result: true},
+		{query: `title starts_with "fi" && title contains "Manager" && title istarts_with "FI"`, opts: []EvalOption{EvalNFKC()}, result: true},
+		{query: `title = "finance Manager"`, opts: []EvalOption{EvalNFKC()}, result: false},
+	}
+
+	for _, tt := range tests {
+		expr, err := ParseExpression(tt.query)
+		if assert.NoError(t, err, tt.query) {
+			result, err := Evaluate(expr, data, tt.opts...)
+			if assert.NoError(t, err, tt.query) {
+				assert.Equal(t, tt.result, result, tt.query)
+			}
+		}
+	}
+}
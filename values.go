@@ -1,6 +1,7 @@
 package lep
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -23,8 +24,10 @@ func (s StringX) Equals(other Expression) bool {
 	return false
 }
 
+var stringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
 func (s StringX) String() string {
-	return `"` + s.Val + `"`
+	return `"` + stringEscaper.Replace(s.Val) + `"`
 }
 
 func (s StringX) Value() interface{} {
@@ -39,11 +42,30 @@ func parseString(b []byte) (*StringX, error) {
 	val := strings.TrimSpace(string(b))
 	val = strings.TrimPrefix(val, `"`)
 	val = strings.TrimSuffix(val, `"`)
-	return String(val), nil
+	return String(unescapeString(val)), nil
 }
 
+// unescapeString replaces \" and \\ with the characters they stand for; any
+// other backslash is kept as it is.
+func unescapeString(val string) string {
+	if !strings.Contains(val, `\`) {
+		return val
+	}
+	var b strings.Builder
+	for i := 0; i < len(val); i++ {
+		if val[i] == '\\' && i+1 < len(val) && (val[i+1] == '"' || val[i+1] == '\\') {
+			i++
+		}
+		b.WriteByte(val[i])
+	}
+	return b.String()
+}
+
+// IntegerX is an int64 constant. Raw is the text of the literal when it is
+// spelled differently from String, like 0xFF or 1_000.
 type IntegerX struct {
 	Val int64
+	Raw string
 }
 
 var _ Value = (*IntegerX)(nil)
@@ -60,6 +82,9 @@ func (i IntegerX) Equals(other Expression) bool {
 }
 
 func (i IntegerX) String() string {
+	if i.Raw != "" {
+		return i.Raw
+	}
 	return fmt.Sprintf("%d", i.Val)
 }
 
@@ -68,15 +93,41 @@ func (i IntegerX) Value() interface{} {
 }
 
 func parseInteger(b []byte) (*IntegerX, error) {
-	val, err := strconv.ParseInt(strings.TrimSpace(string(b)), 10, 64)
+	raw := strings.TrimSpace(string(b))
+	digits, base := numberDigits(raw)
+	val, err := strconv.ParseInt(digits, base, 64)
 	if err != nil {
-		return nil, err
+		if errors.Is(err, strconv.ErrRange) {
+			return nil, OutOfRange(raw, "int64")
+		}
+		return nil, &strconv.NumError{Func: "ParseInt", Num: raw, Err: errors.Unwrap(err)}
+	}
+	integer := Integer(val)
+	if raw != integer.String() {
+		integer.Raw = raw
 	}
-	return Integer(val), nil
+	return integer, nil
 }
 
+// numberDigits returns the digits of a numeric literal without underscores
+// and the base prefix, with the sign kept.
+func numberDigits(raw string) (string, int) {
+	digits := strings.ReplaceAll(raw, "_", "")
+	var sign string
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		sign, digits = digits[:1], digits[1:]
+	}
+	if strings.HasPrefix(digits, "0x") || strings.HasPrefix(digits, "0X") {
+		return sign + digits[2:], 16
+	}
+	return sign + digits, 10
+}
+
+// FloatX is a float64 constant. Raw is the text of the literal when it is
+// spelled differently from String, like 1e6 or .5.
 type FloatX struct {
 	Val float64
+	Raw string
 }
 
 var _ Value = (*FloatX)(nil)
@@ -93,7 +144,14 @@ func (f FloatX) Equals(other Expression) bool {
 }
 
 func (f FloatX) String() string {
-	return strings.TrimRight(fmt.Sprintf("%f", f.Val), "0")
+	if f.Raw != "" {
+		return f.Raw
+	}
+	s := strconv.FormatFloat(f.Val, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
 }
 
 func (f FloatX) Value() interface{} {
@@ -101,11 +159,19 @@ func (f FloatX) Value() interface{} {
 }
 
 func parseFloat(b []byte) (*FloatX, error) {
-	val, err := strconv.ParseFloat(strings.TrimSpace(string(b)), 64)
+	raw := strings.TrimSpace(string(b))
+	val, err := strconv.ParseFloat(strings.ReplaceAll(raw, "_", ""), 64)
 	if err != nil {
-		return nil, err
+		if errors.Is(err, strconv.ErrRange) {
+			return nil, OutOfRange(raw, "float64")
+		}
+		return nil, &strconv.NumError{Func: "ParseFloat", Num: raw, Err: errors.Unwrap(err)}
+	}
+	float := Float(val)
+	if raw != float.String() {
+		float.Raw = raw
 	}
-	return Float(val), nil
+	return float, nil
 }
 
 type BooleanX struct {
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"strconv"
-	"strings"
 	"testing"
 )
 
@@ -12,27 +11,38 @@ func TestParseString(t *testing.T) {
 	type testParseString struct {
 		raw    []byte
 		result string
+		str    string
 	}
 	var tests = []testParseString{
 		{
 			raw:    []byte(`foo bar`),
 			result: `foo bar`,
+			str:    `"foo bar"`,
 		},
 		{
 			raw:    []byte(`"foo bar"`),
 			result: `foo bar`,
+			str:    `"foo bar"`,
 		},
 		{
-			raw:    []byte(`"foo "bar""`),
+			raw:    []byte(`"foo \"bar\""`),
 			result: `foo "bar"`,
+			str:    `"foo \"bar\""`,
+		},
+		{
+			raw:    []byte(`"C:\\dir\file \d"`),
+			result: `C:\dir\file \d`,
+			str:    `"C:\\dir\\file \\d"`,
 		},
 		{
 			raw:    []byte(`    "foo bar"    `),
 			result: `foo bar`,
+			str:    `"foo bar"`,
 		},
 		{
 			raw:    []byte(`"!@#$%^&*()"`),
 			result: `!@#$%^&*()`,
+			str:    `"!@#$%^&*()"`,
 		},
 	}
 
@@ -43,7 +53,7 @@ func TestParseString(t *testing.T) {
 			assert.Equal(t, tt.result, v.Val)
 			assert.Equal(t, tt.result, v.Value())
 			assert.Equal(t, true, v.IsStringify())
-			assert.Equal(t, `"`+tt.result+`"`, v.String())
+			assert.Equal(t, tt.str, v.String())
 		}
 	}
 }
@@ -52,6 +62,7 @@ func TestParseInteger(t *testing.T) {
 	type testParseInteger struct {
 		raw    []byte
 		result int64
+		str    string
 		err    error
 	}
 	var tests = []testParseInteger{
@@ -71,6 +82,39 @@ func TestParseInteger(t *testing.T) {
 			raw:    []byte("    -1000    "),
 			result: -1000,
 		},
+		{
+			raw:    []byte("0xFF"),
+			result: 255,
+			str:    "0xFF",
+		},
+		{
+			raw:    []byte("-0x7fff_ffff_ffff_ffff"),
+			result: -9223372036854775807,
+			str:    "-0x7fff_ffff_ffff_ffff",
+		},
+		{
+			raw:    []byte("1_000_000"),
+			result: 1000000,
+			str:    "1_000_000",
+		},
+		{
+			raw:    []byte("+5"),
+			result: 5,
+			str:    "+5",
+		},
+		{
+			raw:    []byte("007"),
+			result: 7,
+			str:    "007",
+		},
+		{
+			raw: []byte("9223372036854775808"),
+			err: OutOfRange("9223372036854775808", "int64"),
+		},
+		{
+			raw: []byte("0x1_0000_0000_0000_0000"),
+			err: OutOfRange("0x1_0000_0000_0000_0000", "int64"),
+		},
 		{
 			raw:    []byte("not_integer"),
 			result: 0,
@@ -85,10 +129,13 @@ func TestParseInteger(t *testing.T) {
 	for _, tt := range tests {
 		v, err := parseInteger(tt.raw)
 		if tt.err == nil && assert.NoError(t, err) {
+			if tt.str == "" {
+				tt.str = fmt.Sprintf("%d", tt.result)
+			}
 			assert.IsType(t, (*IntegerX)(nil), v)
 			assert.Equal(t, tt.result, v.Val)
 			assert.Equal(t, tt.result, v.Value())
-			assert.Equal(t, fmt.Sprintf("%d", tt.result), v.String())
+			assert.Equal(t, tt.str, v.String())
 		} else {
 			assert.EqualError(t, err, tt.err.Error())
 		}
@@ -99,24 +146,63 @@ func TestParseFloat(t *testing.T) {
 	type testParseFloat struct {
 		raw    []byte
 		result float64
+		str    string
 		err    error
 	}
 	var tests = []testParseFloat{
 		{
 			raw:    []byte("123.45"),
 			result: 123.45,
+			str:    "123.45",
 		},
 		{
 			raw:    []byte("    123.45    "),
 			result: 123.45,
+			str:    "123.45",
 		},
 		{
 			raw:    []byte("-123.45"),
 			result: -123.45,
+			str:    "-123.45",
 		},
 		{
 			raw:    []byte("    -123.45    "),
 			result: -123.45,
+			str:    "-123.45",
+		},
+		{
+			raw:    []byte("10.0"),
+			result: 10,
+			str:    "10.0",
+		},
+		{
+			raw:    []byte("0.123456789"),
+			result: 0.123456789,
+			str:    "0.123456789",
+		},
+		{
+			raw:    []byte("1e6"),
+			result: 1e6,
+			str:    "1e6",
+		},
+		{
+			raw:    []byte("-1.5E-3"),
+			result: -1.5e-3,
+			str:    "-1.5E-3",
+		},
+		{
+			raw:    []byte(".5"),
+			result: 0.5,
+			str:    ".5",
+		},
+		{
+			raw:    []byte("+1_000.25"),
+			result: 1000.25,
+			str:    "+1_000.25",
+		},
+		{
+			raw: []byte("1e400"),
+			err: OutOfRange("1e400", "float64"),
 		},
 		{
 			raw:    []byte("not_float"),
@@ -135,7 +221,7 @@ func TestParseFloat(t *testing.T) {
 			assert.IsType(t, (*FloatX)(nil), v)
 			assert.Equal(t, tt.result, v.Val)
 			assert.Equal(t, tt.result, v.Value())
-			assert.Equal(t, strings.TrimRight(fmt.Sprintf("%f", tt.result), "0"), v.String())
+			assert.Equal(t, tt.str, v.String())
 		} else {
 			assert.EqualError(t, err, tt.err.Error())
 		}